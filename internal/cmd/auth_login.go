@@ -82,7 +82,10 @@ Examples:
   # Login using environment variables
   export TIGER_PUBLIC_KEY="your-public-key"
   export TIGER_SECRET_KEY="your-secret-key"
-  tiger auth login`,
+  tiger auth login
+
+  # Login to a named profile (see 'tiger profile')
+  tiger auth login --profile staging`,
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -118,7 +121,7 @@ Examples:
 				app.SetClient(client, projectID)
 				// Identify the user for analytics.
 				common.IdentifyOAuthUser(cmd.Context(), cfg, client, projectID)
-				finishLogin(cmd, projectID)
+				finishLogin(cmd, cfg, projectID)
 				return nil
 			} else if creds.publicKey == "" || creds.secretKey == "" {
 				creds, err = promptForCredentials(cmd, cfg.ConsoleURL, creds)
@@ -147,7 +150,7 @@ Examples:
 			// See the OAuth branch above: keep the App's client in sync with the
 			// credentials we just stored.
 			app.SetClient(client, authInfo.APIKey.Project.ID)
-			finishLogin(cmd, authInfo.APIKey.Project.ID)
+			finishLogin(cmd, cfg, authInfo.APIKey.Project.ID)
 			return nil
 		},
 	}
@@ -158,8 +161,17 @@ Examples:
 	return cmd
 }
 
-func finishLogin(cmd *cobra.Command, projectID string) {
-	cmd.Printf("Successfully logged in (project: %s)\n", projectID)
+func finishLogin(cmd *cobra.Command, cfg *config.Config, projectID string) {
+	if cfg.Profile == config.DefaultProfile {
+		cmd.Printf("Successfully logged in (project: %s)\n", projectID)
+	} else {
+		cmd.Printf("Successfully logged in to profile %s (project: %s)\n", cfg.Profile, projectID)
+	}
+
+	// Logging in to a profile via --profile doesn't switch to it
+	if selected, err := config.SelectedProfile(cfg.ConfigDir); err == nil && selected != cfg.Profile {
+		cmd.Printf("To make it the active profile, run: tiger profile use %s\n", cfg.Profile)
+	}
 	cmd.Print(nextStepsMessage)
 }
 
@@ -405,14 +417,10 @@ func openBrowserImpl(url string) error {
 }
 
 func (l *oauthLogin) selectProjectID(ctx context.Context, client *api.ClientWithResponses) (string, error) {
-	resp, err := client.GetProjectsWithResponse(ctx)
+	projects, err := fetchProjects(ctx, client)
 	if err != nil {
-		return "", fmt.Errorf("failed to get user projects: %w", err)
-	}
-	if resp.JSON200 == nil {
-		return "", common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+		return "", err
 	}
-	projects := *resp.JSON200
 
 	switch len(projects) {
 	case 0:
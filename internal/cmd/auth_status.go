@@ -25,7 +25,7 @@ func buildStatusCmd(app *common.App) *cobra.Command {
 		Use:               "status",
 		Aliases:           []string{"whoami"},
 		Short:             "Show current authentication status and project ID",
-		Long:              "Displays whether you are logged in and shows your currently configured project ID.",
+		Long:              "Displays whether you are logged in and shows the active profile and your currently configured project ID.",
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -60,7 +60,7 @@ func buildStatusCmd(app *common.App) *cobra.Command {
 			authInfo := *resp.JSON200
 
 			// Output auth info in requested format
-			return outputAuthInfo(cmd, authInfo, cfg.Output)
+			return outputAuthInfo(cmd, authStatus{Profile: cfg.Profile, AuthInfo: authInfo}, cfg.Output)
 		},
 	}
 
@@ -69,8 +69,15 @@ func buildStatusCmd(app *common.App) *cobra.Command {
 	return cmd
 }
 
+// authStatus is the auth info reported by `tiger auth status`, along with the
+// profile it was resolved for
+type authStatus struct {
+	Profile string `json:"profile"`
+	api.AuthInfo
+}
+
 // outputAuthInfo formats and outputs authentication information based on the specified format
-func outputAuthInfo(cmd *cobra.Command, authInfo api.AuthInfo, format string) error {
+func outputAuthInfo(cmd *cobra.Command, authInfo authStatus, format string) error {
 
 	outputWriter := cmd.OutOrStdout()
 
@@ -84,10 +91,11 @@ func outputAuthInfo(cmd *cobra.Command, authInfo api.AuthInfo, format string) er
 	}
 }
 
-func outputAuthInfoTable(authInfo api.AuthInfo, output io.Writer) error {
+func outputAuthInfoTable(authInfo authStatus, output io.Writer) error {
 	table := tablewriter.NewWriter(output)
 	table.Header("PROPERTY", "VALUE")
 	table.Append("Status", "Logged in")
+	table.Append("Profile", authInfo.Profile)
 
 	switch authInfo.Type {
 	case api.AuthInfoTypeAPIKey:
@@ -67,6 +67,9 @@ func TestAuthStatus_LoggedIn(t *testing.T) {
 	if !strings.Contains(output, "Free") {
 		t.Errorf("Expected output to contain plan type 'Free': '%s'", output)
 	}
+	if !strings.Contains(output, "Profile") || !strings.Contains(output, config.DefaultProfile) {
+		t.Errorf("Expected output to contain the active profile: '%s'", output)
+	}
 }
 
 func TestAuthStatus_NotLoggedIn(t *testing.T) {
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// Chart styles accepted by --chart-style.
+const (
+	chartStyleLine      = "line"
+	chartStyleSparkline = "sparkline"
+)
+
+const (
+	// defaultChartWidth is used when the output isn't a terminal (or its size
+	// can't be read), e.g. when piping to a file.
+	defaultChartWidth = 80
+
+	// lineChartHeight is the height of a braille line chart in rows. Each row
+	// holds four vertical dots.
+	lineChartHeight = 8
+)
+
+// sparkBlocks are the eight block elements a sparkline is drawn with, from
+// lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// chartWidth returns the number of columns available for a chart written to w.
+func chartWidth(w io.Writer) int {
+	if width := util.TerminalWidth(w); width > 0 {
+		return width
+	}
+	return defaultChartWidth
+}
+
+// renderMetricSeriesCharts draws one chart per labeled series, followed by a
+// min/avg/p95/max/last summary line, scaled to the given width in columns.
+func renderMetricSeriesCharts(w io.Writer, series []api.MetricSeries, style string, width int) {
+	for i, s := range series {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintln(w, labelString(s.Labels))
+
+		summary := common.SummarizeMetricData(s.Data)
+		if summary.Count == 0 {
+			fmt.Fprintln(w, "  (no data)")
+			continue
+		}
+
+		values := metricValues(s.Data)
+		switch style {
+		case chartStyleSparkline:
+			fmt.Fprintln(w, "  "+renderSparkline(values, width-2, summary.Min, summary.Max))
+		default:
+			for _, line := range renderLineChart(values, width, lineChartHeight, summary.Min, summary.Max) {
+				fmt.Fprintln(w, line)
+			}
+			fmt.Fprintln(w, chartTimeAxis(s.Data, width))
+		}
+		fmt.Fprintln(w, "  "+formatMetricSummary(summary))
+	}
+}
+
+// formatMetricSummary renders the one-line statistics shown under each chart.
+func formatMetricSummary(summary common.MetricSummary) string {
+	line := fmt.Sprintf("min %s  avg %s  p95 %s  max %s  last %s",
+		formatMetricValue(summary.Min),
+		formatMetricValue(summary.Avg),
+		formatMetricValue(summary.P95),
+		formatMetricValue(summary.Max),
+		formatMetricValue(summary.Last),
+	)
+	if summary.Gaps > 0 {
+		line += fmt.Sprintf("  (%d gaps)", summary.Gaps)
+	}
+	return line
+}
+
+// formatMetricValue renders a value compactly using SI suffixes (k, M, G, T),
+// since metric values range from fractions of a core to terabytes.
+func formatMetricValue(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 1e12:
+		return fmt.Sprintf("%.1fT", v/1e12)
+	case abs >= 1e9:
+		return fmt.Sprintf("%.1fG", v/1e9)
+	case abs >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case abs >= 1e4:
+		return fmt.Sprintf("%.1fk", v/1e3)
+	case abs >= 100 || v == math.Trunc(v):
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return fmt.Sprintf("%.2f", v)
+	}
+}
+
+func metricValues(points []api.MetricDataPoint) []*float64 {
+	values := make([]*float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+	return values
+}
+
+// resampleValues stretches or shrinks values to exactly n points. When
+// shrinking, each output point averages the non-null inputs it covers; a point
+// that covers only gaps stays nil.
+func resampleValues(values []*float64, n int) []*float64 {
+	out := make([]*float64, n)
+	if len(values) == 0 || n <= 0 {
+		return out
+	}
+	for i := range out {
+		start := i * len(values) / n
+		end := max((i+1)*len(values)/n, start+1)
+
+		var sum float64
+		var count int
+		for _, v := range values[start:end] {
+			if v != nil {
+				sum += *v
+				count++
+			}
+		}
+		if count > 0 {
+			out[i] = util.Ptr(sum / float64(count))
+		}
+	}
+	return out
+}
+
+// scaleValue maps v in [lo, hi] onto [0, steps-1]. A flat series (lo == hi)
+// is drawn in the middle.
+func scaleValue(v, lo, hi float64, steps int) int {
+	if hi <= lo {
+		return (steps - 1) / 2
+	}
+	scaled := int(math.Round((v - lo) / (hi - lo) * float64(steps-1)))
+	return max(0, min(scaled, steps-1))
+}
+
+// renderSparkline draws values as a single line of block elements, width
+// columns wide. Gaps are drawn as spaces.
+func renderSparkline(values []*float64, width int, lo, hi float64) string {
+	var b strings.Builder
+	for _, v := range resampleValues(values, max(width, 1)) {
+		if v == nil {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(sparkBlocks[scaleValue(*v, lo, hi, len(sparkBlocks))])
+	}
+	return b.String()
+}
+
+// brailleDots maps a dot's (x, y) position within a 2×4 braille cell to its
+// bit in the Unicode braille block (U+2800).
+var brailleDots = [2][4]rune{
+	{0x01, 0x02, 0x04, 0x40},
+	{0x08, 0x10, 0x20, 0x80},
+}
+
+// renderLineChart draws values as a braille line chart with a labeled y axis,
+// width columns wide (including the axis) and height rows tall. Each braille
+// character packs 2×4 dots, so the plot has twice the horizontal and four
+// times the vertical resolution of the character grid. Consecutive points are
+// joined with a vertical run of dots so steep changes stay connected; gaps
+// break the line.
+func renderLineChart(values []*float64, width, height int, lo, hi float64) []string {
+	hiLabel, loLabel := formatMetricValue(hi), formatMetricValue(lo)
+	gutter := max(len(hiLabel), len(loLabel))
+	plotWidth := max(width-gutter-3, 1) // label + " ┤" + trailing margin
+	pxWidth, pxHeight := plotWidth*2, height*4
+
+	grid := make([][]rune, height)
+	for row := range grid {
+		grid[row] = make([]rune, plotWidth)
+	}
+	set := func(x, y int) {
+		// y counts up from the bottom; rows count down from the top.
+		row, dotY := (pxHeight-1-y)/4, (pxHeight-1-y)%4
+		grid[row][x/2] |= brailleDots[x%2][dotY]
+	}
+
+	prev := -1
+	for x, v := range resampleValues(values, pxWidth) {
+		if v == nil {
+			prev = -1
+			continue
+		}
+		y := scaleValue(*v, lo, hi, pxHeight)
+		from, to := y, y
+		if prev >= 0 {
+			from, to = min(prev, y), max(prev, y)
+		}
+		for yy := from; yy <= to; yy++ {
+			set(x, yy)
+		}
+		prev = y
+	}
+
+	lines := make([]string, height)
+	for row := range grid {
+		label := ""
+		switch row {
+		case 0:
+			label = hiLabel
+		case height - 1:
+			label = loLabel
+		}
+		axis := "│"
+		if label != "" {
+			axis = "┤"
+		}
+
+		var b strings.Builder
+		for _, dots := range grid[row] {
+			b.WriteRune(0x2800 + dots)
+		}
+		lines[row] = fmt.Sprintf("%*s %s%s", gutter, label, axis, b.String())
+	}
+	return lines
+}
+
+// chartTimeAxis labels the time of the first and last bucket under a line
+// chart, at the left and right edges of its width.
+func chartTimeAxis(points []api.MetricDataPoint, width int) string {
+	if len(points) == 0 {
+		return ""
+	}
+	const layout = "2006-01-02 15:04Z"
+	first := points[0].Time.UTC().Format(layout)
+	last := points[len(points)-1].Time.UTC().Format(layout)
+
+	// The line chart leaves one trailing column empty; end the label there too.
+	padding := max(width-len(first)-len(last)-1, 1)
+	return first + strings.Repeat(" ", padding) + last
+}
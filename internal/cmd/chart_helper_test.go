@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func testMetricSeries(values ...*float64) api.MetricSeries {
+	start := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	s := api.MetricSeries{Labels: map[string]string{"role": "primary"}}
+	for i, v := range values {
+		s.Data = append(s.Data, api.MetricDataPoint{Time: start.Add(time.Duration(i) * time.Minute), Value: v})
+	}
+	return s
+}
+
+func TestRenderSparkline(t *testing.T) {
+	values := []*float64{util.Ptr(0.0), util.Ptr(7.0), nil, util.Ptr(3.5)}
+
+	if got, want := renderSparkline(values, 4, 0, 7), "▁█ ▅"; got != want {
+		t.Errorf("renderSparkline() = %q, want %q", got, want)
+	}
+
+	// Shrinking averages neighbouring points into one column.
+	if got, want := renderSparkline(values, 2, 0, 7), "▅▅"; got != want {
+		t.Errorf("renderSparkline() shrunk = %q, want %q", got, want)
+	}
+
+	// A flat series is drawn at mid height rather than dividing by zero.
+	if got, want := renderSparkline([]*float64{util.Ptr(5.0), util.Ptr(5.0)}, 2, 5, 5), "▄▄"; got != want {
+		t.Errorf("renderSparkline() flat = %q, want %q", got, want)
+	}
+}
+
+func TestRenderLineChart(t *testing.T) {
+	values := []*float64{util.Ptr(0.0), util.Ptr(100.0)}
+	lines := renderLineChart(values, 10, 2, 0, 100)
+
+	if len(lines) != 2 {
+		t.Fatalf("renderLineChart() returned %d lines, want 2", len(lines))
+	}
+	for _, line := range lines {
+		if n := utf8.RuneCountInString(line); n != 9 {
+			t.Errorf("line %q is %d columns wide, want 9 (width minus trailing margin)", line, n)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "100 ┤") || !strings.HasPrefix(lines[1], "  0 ┤") {
+		t.Errorf("y axis labels not aligned:\n%s", strings.Join(lines, "\n"))
+	}
+
+	// The first half of the plot runs along the bottom and the second half
+	// along the top, joined by a vertical run of dots.
+	if !strings.HasSuffix(lines[0], "⠀⠀⡏⠉") || !strings.HasSuffix(lines[1], "⣀⣀⡇⠀") {
+		t.Errorf("unexpected plot:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestRenderMetricSeriesCharts(t *testing.T) {
+	series := []api.MetricSeries{
+		testMetricSeries(util.Ptr(1.0), util.Ptr(2.0), nil, util.Ptr(4.0)),
+		{Labels: map[string]string{"role": "replica"}},
+	}
+
+	buf := new(bytes.Buffer)
+	renderMetricSeriesCharts(buf, series, chartStyleLine, 60)
+	out := buf.String()
+
+	for _, want := range []string{
+		`{role="primary"}`,
+		"2026-05-13 00:00Z",
+		"2026-05-13 00:03Z",
+		"min 1  avg 2.33  p95 4  max 4  last 4  (1 gaps)",
+		`{role="replica"}`,
+		"(no data)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("chart output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatMetricValue(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0"},
+		{0.5, "0.50"},
+		{42, "42"},
+		{812.4, "812"},
+		{12_500, "12.5k"},
+		{8_589_934_592, "8.6G"},
+		{2.5e12, "2.5T"},
+	}
+	for _, tt := range tests {
+		if got := formatMetricValue(tt.in); got != tt.want {
+			t.Errorf("formatMetricValue(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -24,6 +24,7 @@ func TestConfigSet_ValidValues(t *testing.T) {
 		{"analytics", "false", "Set analytics = false"},
 		{"password_storage", "pgpass", "Set password_storage = pgpass"},
 		{"password_storage", "none", "Set password_storage = none"},
+		{"password_storage", "exec", "Set password_storage = exec"},
 		{"password_storage", "keyring", "Set password_storage = keyring"},
 	}
 
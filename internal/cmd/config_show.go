@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -30,8 +31,13 @@ func buildConfigShowCmd(app *common.App) *cobra.Command {
 
 			// Values are re-read free of env and CLI flags (unless --with-env
 			// is given), so `config show -o json` reports the configured
-			// `output` value rather than the flag's.
-			cfgOut, err := config.LoadForOutput(cfg.ConfigDir, withEnv, noDefaults)
+			// `output` value rather than the flag's. An explicit --profile
+			// still selects which profile's overrides are shown.
+			var profile string
+			if cmd.Flags().Changed("profile") {
+				profile = cfg.Profile
+			}
+			cfgOut, err := config.LoadForOutput(cfg.ConfigDir, profile, withEnv, noDefaults)
 			if err != nil {
 				return err
 			}
@@ -74,6 +80,9 @@ func outputTable(w io.Writer, cfg *config.ConfigOutput) error {
 	if cfg.ConsoleURL != nil {
 		table.Append("console_url", *cfg.ConsoleURL)
 	}
+	if cfg.CredentialHelper != nil {
+		table.Append("credential_helper", *cfg.CredentialHelper)
+	}
 	if cfg.DocsMCP != nil {
 		table.Append("docs_mcp", fmt.Sprintf("%t", *cfg.DocsMCP))
 	}
@@ -83,9 +92,30 @@ func outputTable(w io.Writer, cfg *config.ConfigOutput) error {
 	if cfg.GatewayURL != nil {
 		table.Append("gateway_url", *cfg.GatewayURL)
 	}
+	if cfg.MCPAllowedServices != nil {
+		table.Append("mcp_allowed_services", strings.Join(*cfg.MCPAllowedServices, ","))
+	}
+	if cfg.MCPAllowedTools != nil {
+		table.Append("mcp_allowed_tools", strings.Join(*cfg.MCPAllowedTools, ","))
+	}
+	if cfg.MCPAuditLog != nil {
+		table.Append("mcp_audit_log", *cfg.MCPAuditLog)
+	}
+	if cfg.MCPConfirm != nil {
+		table.Append("mcp_confirm", fmt.Sprintf("%t", *cfg.MCPConfirm))
+	}
+	if cfg.MCPDeniedTools != nil {
+		table.Append("mcp_denied_tools", strings.Join(*cfg.MCPDeniedTools, ","))
+	}
 	if cfg.MCPMaxRows != nil {
 		table.Append("mcp_max_rows", fmt.Sprintf("%d", *cfg.MCPMaxRows))
 	}
+	if cfg.MCPProxies != nil {
+		table.Append("mcp_proxies", formatMCPProxies(*cfg.MCPProxies))
+	}
+	if cfg.MCPSQLGuard != nil {
+		table.Append("mcp_sql_guard", *cfg.MCPSQLGuard)
+	}
 	if cfg.Color != nil {
 		table.Append("color", fmt.Sprintf("%t", *cfg.Color))
 	}
@@ -95,6 +125,9 @@ func outputTable(w io.Writer, cfg *config.ConfigOutput) error {
 	if cfg.PasswordStorage != nil {
 		table.Append("password_storage", *cfg.PasswordStorage)
 	}
+	if cfg.Profile != nil {
+		table.Append("profile", *cfg.Profile)
+	}
 	if cfg.ReadOnly != nil {
 		table.Append("read_only", fmt.Sprintf("%t", *cfg.ReadOnly))
 	}
@@ -109,3 +142,17 @@ func outputTable(w io.Writer, cfg *config.ConfigOutput) error {
 	}
 	return table.Render()
 }
+
+// formatMCPProxies summarizes mcp_proxies entries for the table output as
+// comma-separated prefix=url pairs. Headers are left out since they often
+// carry credentials.
+func formatMCPProxies(proxies []config.MCPProxy) string {
+	entries := make([]string, len(proxies))
+	for i, proxy := range proxies {
+		entries[i] = proxy.ToolPrefix + "=" + proxy.URL
+		if !proxy.IsEnabled() {
+			entries[i] += " (disabled)"
+		}
+	}
+	return strings.Join(entries, ",")
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"os"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
@@ -86,25 +87,34 @@ password_storage: keyring
 
 	// Verify ALL JSON keys and their expected values
 	expectedValues := map[string]interface{}{
-		"api_url":          "https://json.api.com/v1",
-		"console_url":      "https://console.cloud.tigerdata.com",
-		"gateway_url":      "https://console.cloud.tigerdata.com/api",
-		"docs_mcp":         true,
-		"docs_mcp_url":     "https://mcp.tigerdata.com/docs?disabled_skills=ghost-database",
-		"service_id":       "",
-		"color":            true,
-		"output":           "json",
-		"analytics":        false,
-		"password_storage": "keyring",
-		"read_only":        false,
-		"config_dir":       tmpDir,
-		"releases_url":     "https://cli.tigerdata.com",
-		"version_check":    true,
-		"mcp_max_rows":     float64(config.DefaultMCPMaxRows),
+		"api_url":              "https://json.api.com/v1",
+		"console_url":          "https://console.cloud.tigerdata.com",
+		"credential_helper":    "",
+		"gateway_url":          "https://console.cloud.tigerdata.com/api",
+		"docs_mcp":             true,
+		"docs_mcp_url":         "https://mcp.tigerdata.com/docs?disabled_skills=ghost-database",
+		"service_id":           "",
+		"color":                true,
+		"output":               "json",
+		"analytics":            false,
+		"password_storage":     "keyring",
+		"profile":              config.DefaultProfile,
+		"read_only":            false,
+		"config_dir":           tmpDir,
+		"releases_url":         "https://cli.tigerdata.com",
+		"version_check":        true,
+		"mcp_allowed_services": []any{},
+		"mcp_allowed_tools":    []any{},
+		"mcp_audit_log":        "",
+		"mcp_confirm":          config.DefaultMCPConfirm,
+		"mcp_denied_tools":     []any{},
+		"mcp_max_rows":         float64(config.DefaultMCPMaxRows),
+		"mcp_proxies":          []any{},
+		"mcp_sql_guard":        config.DefaultMCPSQLGuard,
 	}
 
 	for key, expectedValue := range expectedValues {
-		if result[key] != expectedValue {
+		if !reflect.DeepEqual(result[key], expectedValue) {
 			t.Errorf("Expected %s '%v', got %v", key, expectedValue, result[key])
 		}
 	}
@@ -143,25 +153,34 @@ password_storage: keyring
 
 	// Verify ALL YAML keys and their expected values
 	expectedValues := map[string]any{
-		"api_url":          "https://yaml.api.com/v1",
-		"console_url":      "https://console.cloud.tigerdata.com",
-		"gateway_url":      "https://console.cloud.tigerdata.com/api",
-		"docs_mcp":         true,
-		"docs_mcp_url":     "https://mcp.tigerdata.com/docs?disabled_skills=ghost-database",
-		"service_id":       "",
-		"color":            true,
-		"output":           "yaml",
-		"analytics":        false,
-		"password_storage": "keyring",
-		"read_only":        false,
-		"config_dir":       tmpDir,
-		"releases_url":     "https://cli.tigerdata.com",
-		"version_check":    true,
-		"mcp_max_rows":     config.DefaultMCPMaxRows,
+		"api_url":              "https://yaml.api.com/v1",
+		"console_url":          "https://console.cloud.tigerdata.com",
+		"credential_helper":    "",
+		"gateway_url":          "https://console.cloud.tigerdata.com/api",
+		"docs_mcp":             true,
+		"docs_mcp_url":         "https://mcp.tigerdata.com/docs?disabled_skills=ghost-database",
+		"service_id":           "",
+		"color":                true,
+		"output":               "yaml",
+		"analytics":            false,
+		"password_storage":     "keyring",
+		"profile":              config.DefaultProfile,
+		"read_only":            false,
+		"config_dir":           tmpDir,
+		"releases_url":         "https://cli.tigerdata.com",
+		"version_check":        true,
+		"mcp_allowed_services": []any{},
+		"mcp_allowed_tools":    []any{},
+		"mcp_audit_log":        "",
+		"mcp_confirm":          config.DefaultMCPConfirm,
+		"mcp_denied_tools":     []any{},
+		"mcp_max_rows":         config.DefaultMCPMaxRows,
+		"mcp_proxies":          []any{},
+		"mcp_sql_guard":        config.DefaultMCPSQLGuard,
 	}
 
 	for key, expectedValue := range expectedValues {
-		if result[key] != expectedValue {
+		if !reflect.DeepEqual(result[key], expectedValue) {
 			t.Errorf("Expected %s '%v', got %v", key, expectedValue, result[key])
 		}
 	}
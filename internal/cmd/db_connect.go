@@ -37,7 +37,7 @@ from your configuration. This command will launch an interactive psql session
 with the appropriate connection parameters.
 
 Authentication is handled automatically using:
-1. Stored password (keyring, ~/.pgpass, or none based on --password-storage setting)
+1. Stored password (keyring, ~/.pgpass, credential helper, or none based on --password-storage setting)
 2. PGPASSWORD environment variable
 3. If authentication fails, offers interactive options:
    - Enter password manually (will be saved for future use)
@@ -660,14 +660,15 @@ func buildPsqlCommand(cfg *config.Config, details *common.ConnectionDetails, psq
 		psqlCmd.Env = append(os.Environ(), "PGPASSWORD="+password)
 	} else {
 		storage := common.GetPasswordStorage(cfg)
-		// Only set PGPASSWORD for keyring storage method
+		// Only set PGPASSWORD for keyring and credential helper storage
 		// pgpass storage relies on psql automatically reading ~/.pgpass file
-		if _, isKeyring := storage.(*common.KeyringStorage); isKeyring {
+		switch storage.(type) {
+		case *common.KeyringStorage, *common.ExecStorage:
 			if storedPassword, err := storage.Get(service, details.Role); err == nil && storedPassword != "" {
-				// Set PGPASSWORD environment variable for psql when using keyring
+				// Set PGPASSWORD environment variable for psql
 				psqlCmd.Env = append(os.Environ(), "PGPASSWORD="+storedPassword)
 			}
-			// Note: If keyring password retrieval fails, we let psql try without it
+			// Note: If password retrieval fails, we let psql try without it
 			// This allows fallback to other authentication methods
 		}
 	}
@@ -36,7 +36,7 @@ By default, a secure random password is auto-generated for the new role. You can
 - Use TIGER_NEW_PASSWORD environment variable
 - Let it auto-generate (default)
 
-The password is saved according to your --password-storage setting (keyring, pgpass, or none).
+The password is saved according to your --password-storage setting (keyring, pgpass, exec, or none).
 
 Read-Only Mode for AI Agents:
 The --read-only flag enables permanent read-only enforcement at the PostgreSQL level
@@ -135,7 +135,13 @@ PostgreSQL Configuration Parameters That May Be Set:
 			defer conn.Close(ctx)
 
 			// Create the role with all options in a transaction
-			if err := createRoleWithOptions(ctx, conn, roleName, rolePassword, readOnly, statementTimeout, fromRoles); err != nil {
+			if err := common.CreateRole(ctx, conn, common.CreateRoleOptions{
+				Name:             roleName,
+				Password:         rolePassword,
+				ReadOnly:         readOnly,
+				StatementTimeout: statementTimeout,
+				FromRoles:        fromRoles,
+			}); err != nil {
 				return fmt.Errorf("failed to create role: %w", err)
 			}
 
@@ -165,132 +171,6 @@ PostgreSQL Configuration Parameters That May Be Set:
 	return cmd
 }
 
-// buildCreateRoleSQL generates the CREATE ROLE SQL statement with LOGIN, PASSWORD, and optional IN ROLE clause
-func buildCreateRoleSQL(roleName string, quotedPassword string, fromRoles []string) string {
-	sanitizedRoleName := pgx.Identifier{roleName}.Sanitize()
-	createSQL := fmt.Sprintf("CREATE ROLE %s WITH LOGIN PASSWORD %s", sanitizedRoleName, quotedPassword)
-
-	// Add IN ROLE clause if fromRoles is specified
-	// IN ROLE adds the new role as a member of existing roles (equivalent to GRANT existing_role TO new_role)
-	if len(fromRoles) > 0 {
-		var sanitizedRoles []string
-		for _, role := range fromRoles {
-			sanitizedRoles = append(sanitizedRoles, pgx.Identifier{role}.Sanitize())
-		}
-		createSQL += " IN ROLE " + strings.Join(sanitizedRoles, ", ")
-	}
-
-	return createSQL
-}
-
-// buildReadOnlyAlterSQL generates the ALTER ROLE SQL statement for read-only enforcement
-func buildReadOnlyAlterSQL(roleName string) string {
-	sanitizedRoleName := pgx.Identifier{roleName}.Sanitize()
-	return fmt.Sprintf("ALTER ROLE %s SET tsdb_admin.read_only_role = true", sanitizedRoleName)
-}
-
-// buildStatementTimeoutAlterSQL generates the ALTER ROLE SQL statement for statement timeout configuration
-func buildStatementTimeoutAlterSQL(roleName string, timeout time.Duration) string {
-	sanitizedRoleName := pgx.Identifier{roleName}.Sanitize()
-	timeoutMs := timeout.Milliseconds()
-	return fmt.Sprintf("ALTER ROLE %s SET statement_timeout = %d", sanitizedRoleName, timeoutMs)
-}
-
-// createRoleWithOptions creates a new PostgreSQL role with all specified options in a single transaction
-func createRoleWithOptions(ctx context.Context, conn *pgx.Conn, roleName, rolePassword string, readOnly bool, statementTimeout time.Duration, fromRoles []string) error {
-	// Begin transaction for atomic operation
-	tx, err := conn.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
-
-	// Check if tsdbadmin is in the fromRoles list
-	hasTsdbadmin := false
-	var otherRoles []string
-	for _, role := range fromRoles {
-		if role == "tsdbadmin" {
-			hasTsdbadmin = true
-		} else {
-			otherRoles = append(otherRoles, role)
-		}
-	}
-
-	// If tsdbadmin is requested, use special TimescaleDB Cloud functions
-	if hasTsdbadmin {
-		// Enforce read-only requirement when inheriting from tsdbadmin
-		if !readOnly {
-			return fmt.Errorf("roles inheriting from tsdbadmin must be read-only (use --read-only flag)")
-		}
-
-		// Cannot set statement_timeout on roles created with create_bare_readonly_role
-		// due to permission restrictions on altering special roles
-		if statementTimeout > 0 {
-			return fmt.Errorf("cannot use --statement-timeout with --from tsdbadmin (permission denied to alter special roles)")
-		}
-
-		// Use timescale_functions.create_bare_readonly_role to create the role
-		// This function creates a read-only role that can inherit tsdbadmin privileges
-		if _, err := tx.Exec(ctx, "SELECT timescale_functions.create_bare_readonly_role($1, $2)",
-			roleName, rolePassword); err != nil {
-			return fmt.Errorf("failed to create role with create_bare_readonly_role: %w", err)
-		}
-
-		// Grant tsdbadmin privileges using the special function
-		if _, err := tx.Exec(ctx, "SELECT timescale_functions.grant_tsdbadmin_to_role($1)",
-			roleName); err != nil {
-			return fmt.Errorf("failed to grant tsdbadmin privileges: %w", err)
-		}
-
-		// Grant any other roles (besides tsdbadmin) if specified
-		// This is necessary because the special functions don't support IN ROLE clause
-		for _, role := range otherRoles {
-			grantSQL := fmt.Sprintf("GRANT %s TO %s",
-				pgx.Identifier{role}.Sanitize(),
-				pgx.Identifier{roleName}.Sanitize())
-			if _, err := tx.Exec(ctx, grantSQL); err != nil {
-				return fmt.Errorf("failed to grant role %s: %w", role, err)
-			}
-		}
-	} else {
-		// Use standard CREATE ROLE for non-tsdbadmin cases
-		// Fail if password contains a single quote (we don't support escaping)
-		if strings.Contains(rolePassword, "'") {
-			return fmt.Errorf("password cannot contain single quotes")
-		}
-		// Wrap password in single quotes for SQL literal
-		quotedPassword := "'" + rolePassword + "'"
-		// IN ROLE clause handles all role grants, so no need for separate GRANT statements
-		createSQL := buildCreateRoleSQL(roleName, quotedPassword, fromRoles)
-		if _, err := tx.Exec(ctx, createSQL); err != nil {
-			return fmt.Errorf("failed to create role: %w", err)
-		}
-
-		// Configure read-only mode if requested
-		if readOnly {
-			alterSQL := buildReadOnlyAlterSQL(roleName)
-			if _, err := tx.Exec(ctx, alterSQL); err != nil {
-				return fmt.Errorf("failed to configure read-only mode: %w", err)
-			}
-		}
-	}
-
-	// Set statement timeout if requested
-	if statementTimeout > 0 {
-		alterSQL := buildStatementTimeoutAlterSQL(roleName, statementTimeout)
-		if _, err := tx.Exec(ctx, alterSQL); err != nil {
-			return fmt.Errorf("failed to set statement timeout: %w", err)
-		}
-	}
-
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	return nil
-}
-
 // getPasswordForRole determines the password based on flags and environment
 func getPasswordForRole(passwordFlag string) (string, error) {
 	// Priority order:
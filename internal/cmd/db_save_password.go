@@ -26,7 +26,7 @@ from your configuration. The password can be provided via:
 3. Interactive prompt (if neither provided)
 
 The password will be saved according to your --password-storage setting
-(keyring, pgpass, or none).
+(keyring, pgpass, exec, or none).
 
 Examples:
   # Save password with explicit value (highest precedence)
@@ -62,3 +62,22 @@ func (o *outputWithBareFlag) String() string {
 func (o *outputWithBareFlag) Type() string {
 	return "string"
 }
+
+// outputWithMetricsFlag implements the [github.com/spf13/pflag.Value] interface.
+type outputWithMetricsFlag string
+
+func (o *outputWithMetricsFlag) Set(val string) error {
+	if err := config.ValidateOutputFormat(val, metricsOutputCSV, metricsOutputProm); err != nil {
+		return err
+	}
+	*o = outputWithMetricsFlag(val)
+	return nil
+}
+
+func (o *outputWithMetricsFlag) String() string {
+	return string(*o)
+}
+
+func (o *outputWithMetricsFlag) Type() string {
+	return "string"
+}
@@ -31,6 +31,8 @@ Use 'tiger mcp start' to launch the MCP server.`,
 
 	// Add subcommands
 	cmd.AddCommand(buildMCPInstallCmd(app))
+	cmd.AddCommand(buildMCPUninstallCmd(app))
+	cmd.AddCommand(buildMCPStatusCmd(app))
 	cmd.AddCommand(buildMCPStartCmd(app))
 	cmd.AddCommand(buildMCPListCmd(app))
 	cmd.AddCommand(buildMCPGetCmd(app))
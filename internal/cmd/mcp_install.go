@@ -26,6 +26,9 @@ import (
 func buildMCPInstallCmd(app *common.App) *cobra.Command {
 	var noBackup bool
 	var configPath string
+	var scope string
+	var readOnly bool
+	var tools []string
 
 	cmd := &cobra.Command{
 		Use:     "install [client]",
@@ -44,6 +47,19 @@ The command will:
 - Merge with existing MCP server configurations (doesn't overwrite other servers)
 - Validate the configuration after installation
 
+By default, the server is installed in the client's user-global configuration.
+With --scope project, it is instead written to the client's per-project
+configuration file in the current directory (e.g. .mcp.json for Claude Code,
+.cursor/mcp.json for Cursor), so it can be committed and shared with everyone
+working on the repository. Project-scoped entries run 'tiger' from PATH rather
+than the absolute path of the current binary.
+
+The --read-only, --service-id, and --tools flags are baked into the installed
+server's environment (TIGER_READ_ONLY, TIGER_SERVICE_ID and
+TIGER_MCP_ALLOWED_SERVICES, and TIGER_MCP_ALLOWED_TOOLS respectively), so they
+apply whenever the client starts the server, regardless of the user's own
+Tiger configuration.
+
 If no client is specified, you'll be prompted to select one interactively.
 
 Examples:
@@ -60,12 +76,41 @@ Examples:
   tiger mcp install claude-code --no-backup
 
   # Use custom configuration file path
-  tiger mcp install claude-code --config-path ~/custom/config.json`, generateSupportedEditorsHelp()),
+  tiger mcp install claude-code --config-path ~/custom/config.json
+
+  # Share a read-only server pinned to a dev service with everyone on the repo
+  tiger mcp install claude-code --scope project --read-only --service-id abc123
+
+  # Only expose the service and schema tools
+  tiger mcp install cursor --tools 'service_*,db_schema'`, generateSupportedEditorsHelp()),
 		Args:      cobra.MaximumNArgs(1),
 		ValidArgs: getValidEditorNames(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 
+			var projectDir string
+			switch scope {
+			case "user":
+			case "project":
+				if configPath != "" {
+					return fmt.Errorf("--config-path cannot be used with --scope project")
+				}
+				var err error
+				projectDir, err = os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get current directory: %w", err)
+				}
+			default:
+				return fmt.Errorf("invalid scope %q: must be 'user' or 'project'", scope)
+			}
+
+			// --service-id is the global flag; only an explicit value is
+			// pinned, not the default service from the user's config
+			var serviceID string
+			if cmd.Flags().Changed("service-id") {
+				serviceID, _ = cmd.Flags().GetString("service-id")
+			}
+
 			var clientName string
 			if len(args) == 0 {
 				// No client specified, prompt user to select one
@@ -84,13 +129,22 @@ Examples:
 				clientName = args[0]
 			}
 
-			return installTigerMCPForClient(cmd, clientName, !noBackup, configPath)
+			return installTigerMCPForClient(cmd, InstallOptions{
+				ClientName:       clientName,
+				CreateBackup:     !noBackup,
+				CustomConfigPath: configPath,
+				ProjectDir:       projectDir,
+				Env:              tigerMCPEnv(readOnly, serviceID, tools),
+			})
 		},
 	}
 
 	// Add flags
 	cmd.Flags().BoolVar(&noBackup, "no-backup", false, "Skip creating backup of existing configuration (default: create backup)")
 	cmd.Flags().StringVar(&configPath, "config-path", "", "Custom path to configuration file (overrides default locations)")
+	cmd.Flags().StringVar(&scope, "scope", "user", "Configuration scope: user (all projects) or project (the client's config file in the current directory)")
+	cmd.Flags().BoolVar(&readOnly, "read-only", false, "Run the installed server in read-only mode")
+	cmd.Flags().StringSliceVar(&tools, "tools", nil, "Only expose these MCP tools (comma-separated names or glob patterns)")
 
 	return cmd
 }
@@ -111,8 +165,9 @@ const (
 
 // MCPServerConfig represents the MCP server configuration
 type MCPServerConfig struct {
-	Command string   `json:"command"`
-	Args    []string `json:"args"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env,omitempty"`
 }
 
 // InstallOptions configures the MCP server installation behavior
@@ -129,6 +184,11 @@ type InstallOptions struct {
 	CreateBackup bool
 	// CustomConfigPath overrides the default config file location
 	CustomConfigPath string
+	// Env sets environment variables for the MCP server process (optional)
+	Env map[string]string
+	// ProjectDir, if set, installs into the client's per-project config file
+	// within this directory instead of the user-global configuration
+	ProjectDir string
 }
 
 // clientConfig represents our own client configuration for Tiger MCP installation
@@ -138,17 +198,55 @@ type clientConfig struct {
 	EditorNames          []string // Supported client names for this client
 	MCPServersPathPrefix string   // JSON path prefix for MCP servers config (only for JSON config manipulation clients like Cursor/Windsurf)
 	ConfigPaths          []string // Config file locations - used for backup on all clients, and for JSON manipulation on JSON-config clients
+	// serversPath is the JSON pointer (or, for .toml files, the table path) of
+	// the MCP server entries within ConfigPaths, for clients installed through
+	// their CLI. Used by uninstall and status; defaults to MCPServersPathPrefix.
+	serversPath string
+	// projectConfigPath is the client's per-project config file, relative to
+	// the project directory. Empty for clients without project-scoped config.
+	// It uses the same servers path as the user-global config.
+	projectConfigPath string
 	// buildInstallCommand builds the CLI install command for CLI-based clients
-	// Parameters: serverName (name to register), command (binary path), args (arguments to binary)
-	buildInstallCommand func(serverName, command string, args []string) ([]string, error)
+	// Parameters: serverName (name to register), command (binary path), args (arguments to binary),
+	// env (environment variables for the server process, may be empty)
+	buildInstallCommand func(serverName, command string, args []string, env map[string]string) ([]string, error)
+	// buildUninstallCommand builds the CLI command that removes serverName,
+	// for clients whose CLI supports it. Other clients have their config file
+	// edited directly.
+	buildUninstallCommand func(serverName string) []string
+}
+
+// serversPathPrefix returns the path of the MCP server entries in the
+// client's config file
+func (c *clientConfig) serversPathPrefix() string {
+	if c.serversPath != "" {
+		return c.serversPath
+	}
+	return c.MCPServersPathPrefix
 }
 
 // BuildInstallCommand constructs the install command with the given parameters
-func (c *clientConfig) BuildInstallCommand(serverName, command string, args []string) ([]string, error) {
+func (c *clientConfig) BuildInstallCommand(serverName, command string, args []string, env map[string]string) ([]string, error) {
 	if c.buildInstallCommand == nil {
 		return nil, nil
 	}
-	return c.buildInstallCommand(serverName, command, args)
+	return c.buildInstallCommand(serverName, command, args, env)
+}
+
+// envFlags returns flag followed by KEY=VALUE for each environment variable,
+// sorted by key so the generated command is deterministic
+func envFlags(flag string, env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var flags []string
+	for _, key := range keys {
+		flags = append(flags, flag, key+"="+env[key])
+	}
+	return flags
 }
 
 // supportedClients defines the clients we support for Tiger MCP installation
@@ -163,8 +261,15 @@ var supportedClients = []clientConfig{
 		ConfigPaths: []string{
 			"~/.claude.json",
 		},
-		buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
-			return append([]string{"claude", "mcp", "add", "-s", "user", serverName, command}, args...), nil
+		serversPath:       "/mcpServers",
+		projectConfigPath: ".mcp.json",
+		buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
+			installCommand := append([]string{"claude", "mcp", "add", "-s", "user"}, envFlags("-e", env)...)
+			installCommand = append(installCommand, serverName, "--", command)
+			return append(installCommand, args...), nil
+		},
+		buildUninstallCommand: func(serverName string) []string {
+			return []string{"claude", "mcp", "remove", "-s", "user", serverName}
 		},
 	},
 	{
@@ -175,6 +280,7 @@ var supportedClients = []clientConfig{
 		ConfigPaths: []string{
 			"~/.cursor/mcp.json",
 		},
+		projectConfigPath: ".cursor/mcp.json",
 	},
 	{
 		ClientType:           Windsurf,
@@ -193,8 +299,14 @@ var supportedClients = []clientConfig{
 			"~/.codex/config.toml",
 			"$CODEX_HOME/config.toml",
 		},
-		buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
-			return append([]string{"codex", "mcp", "add", serverName, command}, args...), nil
+		serversPath: "/mcp_servers",
+		buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
+			installCommand := append([]string{"codex", "mcp", "add", serverName}, envFlags("--env", env)...)
+			installCommand = append(installCommand, "--", command)
+			return append(installCommand, args...), nil
+		},
+		buildUninstallCommand: func(serverName string) []string {
+			return []string{"codex", "mcp", "remove", serverName}
 		},
 	},
 	{
@@ -204,8 +316,15 @@ var supportedClients = []clientConfig{
 		ConfigPaths: []string{
 			"~/.gemini/settings.json",
 		},
-		buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
-			return append([]string{"gemini", "mcp", "add", "-s", "user", serverName, command}, args...), nil
+		serversPath:       "/mcpServers",
+		projectConfigPath: ".gemini/settings.json",
+		buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
+			installCommand := append([]string{"gemini", "mcp", "add", "-s", "user"}, envFlags("-e", env)...)
+			installCommand = append(installCommand, serverName, command)
+			return append(installCommand, args...), nil
+		},
+		buildUninstallCommand: func(serverName string) []string {
+			return []string{"gemini", "mcp", "remove", "-s", "user", serverName}
 		},
 	},
 	{
@@ -217,12 +336,18 @@ var supportedClients = []clientConfig{
 			"~/Library/Application Support/Code/User/mcp.json",
 			"~/AppData/Roaming/Code/User/mcp.json",
 		},
-		buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
-			j, err := json.Marshal(map[string]any{
+		serversPath:       "/servers",
+		projectConfigPath: ".vscode/mcp.json",
+		buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
+			server := map[string]any{
 				"name":    serverName,
 				"command": command,
 				"args":    args,
-			})
+			}
+			if len(env) > 0 {
+				server["env"] = env
+			}
+			j, err := json.Marshal(server)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal MCP config: %w", err)
 			}
@@ -245,8 +370,14 @@ var supportedClients = []clientConfig{
 		ConfigPaths: []string{
 			"~/.kiro/settings/mcp.json",
 		},
-		buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
-			return []string{"kiro-cli", "mcp", "add", "--name", serverName, "--command", command, "--args", strings.Join(args, ",")}, nil
+		serversPath:       "/mcpServers",
+		projectConfigPath: ".kiro/settings/mcp.json",
+		buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
+			installCommand := []string{"kiro-cli", "mcp", "add", "--name", serverName, "--command", command, "--args", strings.Join(args, ",")}
+			return append(installCommand, envFlags("--env", env)...), nil
+		},
+		buildUninstallCommand: func(serverName string) []string {
+			return []string{"kiro-cli", "mcp", "remove", "--name", serverName}
 		},
 	},
 }
@@ -307,7 +438,15 @@ func InstallMCPForClient(opts InstallOptions) error {
 	mcpServersPathPrefix := clientCfg.MCPServersPathPrefix
 
 	var configPath string
-	if opts.CustomConfigPath != "" {
+	if opts.ProjectDir != "" {
+		// Project-scoped configs are always edited directly, since client
+		// CLIs don't consistently support writing them
+		if clientCfg.projectConfigPath == "" {
+			return fmt.Errorf("client %s does not support project-scoped MCP configuration", opts.ClientName)
+		}
+		configPath = filepath.Join(util.ExpandPath(opts.ProjectDir), clientCfg.projectConfigPath)
+		mcpServersPathPrefix = clientCfg.serversPathPrefix()
+	} else if opts.CustomConfigPath != "" {
 		// Expand custom config path for ~ and environment variables, then use it directly
 		configPath = util.ExpandPath(opts.CustomConfigPath)
 	} else if len(clientCfg.ConfigPaths) > 0 {
@@ -331,14 +470,14 @@ func InstallMCPForClient(opts InstallOptions) error {
 	}
 
 	// Add MCP server to configuration
-	if clientCfg.buildInstallCommand != nil {
+	if clientCfg.buildInstallCommand != nil && opts.ProjectDir == "" {
 		// Use CLI approach when install command builder is configured
-		if err := addMCPServerViaCLI(clientCfg, opts.ServerName, opts.Command, opts.Args); err != nil {
+		if err := addMCPServerViaCLI(clientCfg, opts.ServerName, opts.Command, opts.Args, opts.Env); err != nil {
 			return fmt.Errorf("failed to add MCP server configuration: %w", err)
 		}
 	} else {
 		// Use JSON patching approach for JSON-config clients
-		if err := addMCPServerViaJSON(configPath, mcpServersPathPrefix, opts.ServerName, opts.Command, opts.Args); err != nil {
+		if err := addMCPServerViaJSON(configPath, mcpServersPathPrefix, opts.ServerName, opts.Command, opts.Args, opts.Env); err != nil {
 			return fmt.Errorf("failed to add MCP server configuration: %w", err)
 		}
 	}
@@ -346,22 +485,41 @@ func InstallMCPForClient(opts InstallOptions) error {
 	return nil
 }
 
-// installTigerMCPForClient installs the Tiger MCP server configuration for the specified client.
-// This is the Tiger-specific wrapper used by the CLI that handles defaults and success messages.
-func installTigerMCPForClient(cmd *cobra.Command, clientName string, createBackup bool, customConfigPath string) error {
-	// Get the Tiger executable path
-	command, err := getTigerExecutablePath()
-	if err != nil {
-		return fmt.Errorf("failed to get executable path: %w", err)
+// tigerMCPEnv returns the environment variables that bake the given
+// settings into an installed Tiger MCP server entry
+func tigerMCPEnv(readOnly bool, serviceID string, tools []string) map[string]string {
+	env := make(map[string]string)
+	if readOnly {
+		env["TIGER_READ_ONLY"] = "true"
+	}
+	if serviceID != "" {
+		env["TIGER_SERVICE_ID"] = serviceID
+		env["TIGER_MCP_ALLOWED_SERVICES"] = serviceID
+	}
+	if len(tools) > 0 {
+		env["TIGER_MCP_ALLOWED_TOOLS"] = strings.Join(tools, ",")
 	}
+	return env
+}
 
-	opts := InstallOptions{
-		ClientName:       clientName,
-		ServerName:       mcp.ServerName,
-		Command:          command,
-		Args:             []string{"mcp", "start"},
-		CreateBackup:     createBackup,
-		CustomConfigPath: customConfigPath,
+// installTigerMCPForClient installs the Tiger MCP server configuration for the specified client.
+// This is the Tiger-specific wrapper used by the CLI that handles defaults and success messages.
+// The server name, command, and args in opts are filled in with Tiger's defaults.
+func installTigerMCPForClient(cmd *cobra.Command, opts InstallOptions) error {
+	clientName := opts.ClientName
+
+	opts.ServerName = mcp.ServerName
+	opts.Args = []string{"mcp", "start"}
+	if opts.ProjectDir != "" {
+		// Project configs are shared, so don't bake in this machine's path
+		opts.Command = "tiger"
+	} else {
+		// Get the Tiger executable path
+		command, err := getTigerExecutablePath()
+		if err != nil {
+			return fmt.Errorf("failed to get executable path: %w", err)
+		}
+		opts.Command = command
 	}
 
 	if err := InstallMCPForClient(opts); err != nil {
@@ -369,8 +527,12 @@ func installTigerMCPForClient(cmd *cobra.Command, clientName string, createBacku
 	}
 
 	// Print Tiger-specific success messages
-	configPath := customConfigPath
-	if configPath == "" {
+	configPath := opts.CustomConfigPath
+	if opts.ProjectDir != "" {
+		if clientCfg, _ := findClientConfig(clientName); clientCfg != nil {
+			configPath = filepath.Join(opts.ProjectDir, clientCfg.projectConfigPath)
+		}
+	} else if configPath == "" {
 		clientCfg, _ := findClientConfig(clientName)
 		if clientCfg != nil && len(clientCfg.ConfigPaths) > 0 {
 			configPath, _ = findClientConfigFile(clientCfg.ConfigPaths)
@@ -622,13 +784,13 @@ func (m clientSelectModel) View() tea.View {
 }
 
 // addMCPServerViaCLI adds an MCP server using a CLI command configured in clientConfig
-func addMCPServerViaCLI(clientCfg *clientConfig, serverName, command string, args []string) error {
+func addMCPServerViaCLI(clientCfg *clientConfig, serverName, command string, args []string, env map[string]string) error {
 	if clientCfg.buildInstallCommand == nil {
 		return fmt.Errorf("no install command configured for client %s", clientCfg.Name)
 	}
 
 	// Build the install command with the provided parameters
-	installCommand, err := clientCfg.BuildInstallCommand(serverName, command, args)
+	installCommand, err := clientCfg.BuildInstallCommand(serverName, command, args, env)
 	if err != nil {
 		return fmt.Errorf("failed to build install command: %w", err)
 	}
@@ -681,7 +843,7 @@ func createConfigBackup(configPath string) (string, error) {
 }
 
 // addMCPServerViaJSON adds an MCP server to the configuration file using JSON patching
-func addMCPServerViaJSON(configPath, mcpServersPathPrefix, serverName, command string, args []string) error {
+func addMCPServerViaJSON(configPath, mcpServersPathPrefix, serverName, command string, args []string, env map[string]string) error {
 	// Create configuration directory if it doesn't exist
 	configDir := filepath.Dir(configPath)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
@@ -692,6 +854,7 @@ func addMCPServerViaJSON(configPath, mcpServersPathPrefix, serverName, command s
 	serverConfig := MCPServerConfig{
 		Command: command,
 		Args:    args,
+		Env:     env,
 	}
 
 	// Get original file mode to preserve it, fallback to 0600 for new files
@@ -237,7 +237,7 @@ func TestAddTigerMCPServer(t *testing.T) {
 			require.NoError(t, err)
 
 			// Call the function under test
-			err = addMCPServerViaJSON(configPath, tt.mcpServersPathPrefix, "tiger", "tiger", []string{"mcp", "start"})
+			err = addMCPServerViaJSON(configPath, tt.mcpServersPathPrefix, "tiger", "tiger", []string{"mcp", "start"}, nil)
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -275,7 +275,7 @@ func TestAddTigerMCPServerFileOperations(t *testing.T) {
 		_, err := os.Stat(filepath.Dir(configPath))
 		assert.True(t, os.IsNotExist(err))
 
-		err = addMCPServerViaJSON(configPath, "/mcpServers", "tiger", "tiger", []string{"mcp", "start"})
+		err = addMCPServerViaJSON(configPath, "/mcpServers", "tiger", "tiger", []string{"mcp", "start"}, nil)
 		require.NoError(t, err)
 
 		// Directory should now exist
@@ -291,7 +291,7 @@ func TestAddTigerMCPServerFileOperations(t *testing.T) {
 		tempDir := t.TempDir()
 		configPath := filepath.Join(tempDir, "nonexistent.json")
 
-		err := addMCPServerViaJSON(configPath, "/mcpServers", "tiger", "tiger", []string{"mcp", "start"})
+		err := addMCPServerViaJSON(configPath, "/mcpServers", "tiger", "tiger", []string{"mcp", "start"}, nil)
 		require.NoError(t, err)
 
 		// File should now exist with correct content
@@ -321,7 +321,7 @@ func TestAddTigerMCPServerFileOperations(t *testing.T) {
 		err := os.WriteFile(configPath, []byte(""), 0644)
 		require.NoError(t, err)
 
-		err = addMCPServerViaJSON(configPath, "/mcpServers", "tiger", "tiger", []string{"mcp", "start"})
+		err = addMCPServerViaJSON(configPath, "/mcpServers", "tiger", "tiger", []string{"mcp", "start"}, nil)
 		require.NoError(t, err)
 
 		// File should now have correct content
@@ -689,7 +689,7 @@ func TestAddMCPServerViaCLI(t *testing.T) {
 			buildInstallCommand: nil, // No build function
 		}
 
-		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"})
+		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"}, nil)
 		assert.Error(t, err, "should error when no install command configured")
 		assert.Contains(t, err.Error(), "no install command configured for client Test Client", "error should mention missing install command")
 	})
@@ -699,12 +699,12 @@ func TestAddMCPServerViaCLI(t *testing.T) {
 		clientCfg := &clientConfig{
 			ClientType: "test-client",
 			Name:       "Test Client",
-			buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
+			buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
 				return []string{"nonexistent-command-12345", "arg1", "arg2"}, nil
 			},
 		}
 
-		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"})
+		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"}, nil)
 		// We expect this to fail since the command doesn't exist, but it shows we got past validation
 		assert.Error(t, err, "should error when command execution fails")
 		assert.Contains(t, err.Error(), "failed to run Test Client installation command", "error should mention installation command failure")
@@ -714,12 +714,12 @@ func TestAddMCPServerViaCLI(t *testing.T) {
 		clientCfg := &clientConfig{
 			ClientType: "test-client",
 			Name:       "Test Client",
-			buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
+			buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
 				return []string{"echo"}, nil // Command with no args - should work
 			},
 		}
 
-		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"})
+		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"}, nil)
 		// echo command should succeed
 		assert.NoError(t, err, "should not error for valid echo command")
 	})
@@ -728,12 +728,12 @@ func TestAddMCPServerViaCLI(t *testing.T) {
 		clientCfg := &clientConfig{
 			ClientType: "test-client",
 			Name:       "Test Client",
-			buildInstallCommand: func(serverName, command string, args []string) ([]string, error) {
+			buildInstallCommand: func(serverName, command string, args []string, env map[string]string) ([]string, error) {
 				return []string{"echo", "test", "output"}, nil // Command with args
 			},
 		}
 
-		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"})
+		err := addMCPServerViaCLI(clientCfg, "tiger", "/path/to/tiger", []string{"mcp", "start"}, nil)
 		// echo command should succeed
 		assert.NoError(t, err, "should not error for valid echo command with args")
 	})
@@ -833,7 +833,7 @@ func TestInstallMCPForEditor_Integration(t *testing.T) {
 		require.NoError(t, err, "should create initial config file")
 
 		// Call installTigerMCPForClient to install Tiger MCP server
-		err = installTigerMCPForClient(discardCmd(), "cursor", false, configPath)
+		err = installTigerMCPForClient(discardCmd(), InstallOptions{ClientName: "cursor", CustomConfigPath: configPath})
 		require.NoError(t, err, "installTigerMCPForClient should succeed")
 
 		// Verify the config file was modified
@@ -869,7 +869,7 @@ func TestInstallMCPForEditor_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Call installTigerMCPForClient with backup enabled for Cursor
-		err = installTigerMCPForClient(discardCmd(), "cursor", true, configPath)
+		err = installTigerMCPForClient(discardCmd(), InstallOptions{ClientName: "cursor", CreateBackup: true, CustomConfigPath: configPath})
 		require.NoError(t, err, "installTigerMCPForClient should succeed with backup")
 
 		// Check that a backup file was created
@@ -898,7 +898,7 @@ func TestInstallMCPForEditor_Integration(t *testing.T) {
 	})
 
 	t.Run("handles unsupported editor", func(t *testing.T) {
-		err := installTigerMCPForClient(discardCmd(), "unsupported-editor", false, "")
+		err := installTigerMCPForClient(discardCmd(), InstallOptions{ClientName: "unsupported-editor"})
 		assert.Error(t, err, "should error for unsupported editor")
 		assert.Contains(t, err.Error(), "unsupported client", "error should mention unsupported client")
 	})
@@ -928,7 +928,7 @@ func TestInstallMCPForEditor_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// First installation (should update existing tiger entry)
-		err = installTigerMCPForClient(discardCmd(), "cursor", false, configPath)
+		err = installTigerMCPForClient(discardCmd(), InstallOptions{ClientName: "cursor", CustomConfigPath: configPath})
 		require.NoError(t, err, "first installation should succeed")
 
 		// Read config after first installation
@@ -953,7 +953,7 @@ func TestInstallMCPForEditor_Integration(t *testing.T) {
 		assert.Equal(t, "start", args[1], "second arg should be 'start'")
 
 		// Second installation (should be idempotent, no changes)
-		err = installTigerMCPForClient(discardCmd(), "cursor", false, configPath)
+		err = installTigerMCPForClient(discardCmd(), InstallOptions{ClientName: "cursor", CustomConfigPath: configPath})
 		require.NoError(t, err, "second installation should succeed")
 
 		// Read config after second installation
@@ -977,3 +977,108 @@ func TestInstallMCPForEditor_Integration(t *testing.T) {
 		assert.Equal(t, tigerConfig, tigerConfig2, "tiger config should remain the same")
 	})
 }
+
+func TestInstallMCPForClient_ProjectScope(t *testing.T) {
+	t.Run("writes the project config with env", func(t *testing.T) {
+		projectDir := t.TempDir()
+
+		// Claude Code is normally installed via its CLI; project scope edits
+		// .mcp.json directly instead
+		err := installTigerMCPForClient(discardCmd(), InstallOptions{
+			ClientName: "claude-code",
+			ProjectDir: projectDir,
+			Env:        tigerMCPEnv(true, "svc-dev", []string{"service_*", "db_schema"}),
+		})
+		require.NoError(t, err)
+
+		entry, err := readMCPServerEntry(filepath.Join(projectDir, ".mcp.json"), "/mcpServers", "tiger")
+		require.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, "tiger", entry.Command, "project configs should not bake in an absolute path")
+		assert.Equal(t, []string{"mcp", "start"}, entry.Args)
+		assert.Equal(t, map[string]string{
+			"TIGER_READ_ONLY":            "true",
+			"TIGER_SERVICE_ID":           "svc-dev",
+			"TIGER_MCP_ALLOWED_SERVICES": "svc-dev",
+			"TIGER_MCP_ALLOWED_TOOLS":    "service_*,db_schema",
+		}, entry.Env)
+
+		// It can be removed again with the same scope
+		_, err = UninstallMCPForClient(UninstallOptions{
+			ClientName: "claude-code",
+			ServerName: "tiger",
+			ProjectDir: projectDir,
+		})
+		require.NoError(t, err)
+		entry, err = readMCPServerEntry(filepath.Join(projectDir, ".mcp.json"), "/mcpServers", "tiger")
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+
+	t.Run("VS Code uses the servers key", func(t *testing.T) {
+		projectDir := t.TempDir()
+		err := installTigerMCPForClient(discardCmd(), InstallOptions{ClientName: "vscode", ProjectDir: projectDir})
+		require.NoError(t, err)
+
+		entry, err := readMCPServerEntry(filepath.Join(projectDir, ".vscode", "mcp.json"), "/servers", "tiger")
+		require.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Empty(t, entry.Env)
+	})
+
+	t.Run("unsupported client", func(t *testing.T) {
+		err := installTigerMCPForClient(discardCmd(), InstallOptions{ClientName: "windsurf", ProjectDir: t.TempDir()})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not support project-scoped")
+	})
+}
+
+func TestBuildInstallCommandWithEnv(t *testing.T) {
+	env := map[string]string{"TIGER_SERVICE_ID": "svc-dev", "TIGER_READ_ONLY": "true"}
+
+	tests := []struct {
+		client   string
+		expected []string
+	}{
+		{
+			client:   "claude-code",
+			expected: []string{"claude", "mcp", "add", "-s", "user", "-e", "TIGER_READ_ONLY=true", "-e", "TIGER_SERVICE_ID=svc-dev", "tiger", "--", "/usr/bin/tiger", "mcp", "start"},
+		},
+		{
+			client:   "codex",
+			expected: []string{"codex", "mcp", "add", "tiger", "--env", "TIGER_READ_ONLY=true", "--env", "TIGER_SERVICE_ID=svc-dev", "--", "/usr/bin/tiger", "mcp", "start"},
+		},
+		{
+			client:   "gemini",
+			expected: []string{"gemini", "mcp", "add", "-s", "user", "-e", "TIGER_READ_ONLY=true", "-e", "TIGER_SERVICE_ID=svc-dev", "tiger", "/usr/bin/tiger", "mcp", "start"},
+		},
+		{
+			client:   "kiro-cli",
+			expected: []string{"kiro-cli", "mcp", "add", "--name", "tiger", "--command", "/usr/bin/tiger", "--args", "mcp,start", "--env", "TIGER_READ_ONLY=true", "--env", "TIGER_SERVICE_ID=svc-dev"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.client, func(t *testing.T) {
+			clientCfg, err := findClientConfig(tt.client)
+			require.NoError(t, err)
+
+			installCommand, err := clientCfg.BuildInstallCommand("tiger", "/usr/bin/tiger", []string{"mcp", "start"}, env)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, installCommand)
+		})
+	}
+
+	t.Run("vscode", func(t *testing.T) {
+		clientCfg, err := findClientConfig("vscode")
+		require.NoError(t, err)
+
+		installCommand, err := clientCfg.BuildInstallCommand("tiger", "/usr/bin/tiger", []string{"mcp", "start"}, env)
+		require.NoError(t, err)
+		require.Len(t, installCommand, 3)
+
+		var server MCPServerConfig
+		require.NoError(t, json.Unmarshal([]byte(installCommand[2]), &server))
+		assert.Equal(t, env, server.Env)
+	})
+}
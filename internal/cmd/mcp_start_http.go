@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,10 +18,23 @@ import (
 	"github.com/timescale/tiger-cli/internal/mcp"
 )
 
+// mcpHTTPOptions holds the http subcommand's flags
+type mcpHTTPOptions struct {
+	host           string
+	port           int
+	authToken      string
+	tlsCert        string
+	tlsKey         string
+	clientCA       string
+	allowedOrigins []string
+	stateful       bool
+	sessionAPIKeys bool
+	sessionTimeout time.Duration
+}
+
 // buildMCPHTTPCmd creates the http subcommand with port/host flags
 func buildMCPHTTPCmd(app *common.App) *cobra.Command {
-	var httpPort int
-	var httpHost string
+	var opts mcpHTTPOptions
 
 	cmd := &cobra.Command{
 		Use:   "http",
@@ -26,6 +43,23 @@ func buildMCPHTTPCmd(app *common.App) *cobra.Command {
 
 The server will automatically find an available port if the specified port is busy.
 
+By default the server accepts any request that reaches it, so only bind it to
+a non-loopback interface with authentication enabled:
+  - --auth-token (or TIGER_MCP_TOKEN) requires clients to send the token in an
+    "Authorization: Bearer <token>" header
+  - --tls-cert and --tls-key serve HTTPS, and --client-ca additionally requires
+    client certificates signed by the given CA (mutual TLS)
+
+Browser requests are rejected unless their Origin is a loopback address or is
+listed with --allowed-origin.
+
+By default every request is handled independently (stateless). --stateful
+keeps a session per client instead, which resource subscriptions need. With
+--session-api-keys, each client must also authenticate its session with its
+own Tiger API key in the X-Tiger-API-Key header (as <public key>:<secret key>),
+and its tool calls run against that key's project and permissions rather than
+the credentials tiger is logged in with.
+
 Examples:
   # Start HTTP server on default port 8080
   tiger mcp start http
@@ -37,27 +71,66 @@ Examples:
   tiger mcp start http --host 0.0.0.0 --port 8080
 
   # Start server and bind to specific interface
-  tiger mcp start http --host 192.168.1.100 --port 9000`,
+  tiger mcp start http --host 192.168.1.100 --port 9000
+
+  # Start a shared server requiring a bearer token over HTTPS
+  TIGER_MCP_TOKEN=$(openssl rand -hex 32) tiger mcp start http --host 0.0.0.0 \
+    --tls-cert server.crt --tls-key server.key
+
+  # Require client certificates (mutual TLS)
+  tiger mcp start http --host 0.0.0.0 --tls-cert server.crt --tls-key server.key \
+    --client-ca clients-ca.crt
+
+  # Shared server where each team member connects with their own API key
+  tiger mcp start http --host 0.0.0.0 --tls-cert server.crt --tls-key server.key \
+    --session-api-keys`,
 		Args:              cobra.NoArgs,
 		ValidArgsFunction: cobra.NoFileCompletions,
 		SilenceErrors:     true, // HTTP server uses slog for all output, including errors
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.clientCA != "" && opts.tlsCert == "" {
+				return fmt.Errorf("--client-ca requires --tls-cert and --tls-key")
+			}
+			if opts.authToken == "" {
+				opts.authToken = os.Getenv("TIGER_MCP_TOKEN")
+			}
+
 			cmd.SilenceUsage = true
-			return startHTTPServer(cmd, app, httpHost, httpPort)
+			return startHTTPServer(cmd, app, opts)
 		},
 	}
 
 	// Add HTTP-specific flags
-	cmd.Flags().IntVar(&httpPort, "port", 8080, "Port to run HTTP server on")
-	cmd.Flags().StringVar(&httpHost, "host", "localhost", "Host to bind to")
+	cmd.Flags().IntVar(&opts.port, "port", 8080, "Port to run HTTP server on")
+	cmd.Flags().StringVar(&opts.host, "host", "localhost", "Host to bind to")
+	cmd.Flags().StringVar(&opts.authToken, "auth-token", "", "Bearer token clients must send in the Authorization header (can also be set via TIGER_MCP_TOKEN)")
+	cmd.Flags().StringVar(&opts.tlsCert, "tls-cert", "", "TLS certificate file, to serve HTTPS")
+	cmd.Flags().StringVar(&opts.tlsKey, "tls-key", "", "TLS private key file, to serve HTTPS")
+	cmd.Flags().StringVar(&opts.clientCA, "client-ca", "", "CA certificate file to verify client certificates against (enables mutual TLS)")
+	cmd.Flags().StringSliceVar(&opts.allowedOrigins, "allowed-origin", nil, "Browser origin allowed to call the server, in addition to loopback origins (repeatable, or * for any)")
+	cmd.Flags().BoolVar(&opts.stateful, "stateful", false, "Keep a session per client instead of handling each request independently")
+	cmd.Flags().BoolVar(&opts.sessionAPIKeys, "session-api-keys", false, "Require each session to authenticate with its own API key in the X-Tiger-API-Key header (implies --stateful)")
+	cmd.Flags().DurationVar(&opts.sessionTimeout, "session-timeout", 30*time.Minute, "Close stateful sessions after this long without requests (0 keeps them open)")
+	cmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
 
 	return cmd
 }
 
 // startHTTPServer starts the MCP server with HTTP transport
-func startHTTPServer(cmd *cobra.Command, app *common.App, host string, port int) error {
+func startHTTPServer(cmd *cobra.Command, app *common.App, opts mcpHTTPOptions) error {
 	ctx := cmd.Context()
 	logger := newLogger(cmd.ErrOrStderr())
+	host, port := opts.host, opts.port
+
+	var tlsConfig *tls.Config
+	if opts.tlsCert != "" {
+		var err error
+		tlsConfig, err = buildMCPTLSConfig(opts.tlsCert, opts.tlsKey, opts.clientCA)
+		if err != nil {
+			logger.Error("Failed to load TLS configuration", slog.Any("error", err))
+			return err
+		}
+	}
 
 	// Create MCP server
 	server, err := mcp.NewServer(ctx, app, logger)
@@ -90,16 +163,47 @@ func startHTTPServer(cmd *cobra.Command, app *common.App, host string, port int)
 
 	// Create HTTP server
 	httpServer := &http.Server{
-		Handler: server.HTTPHandler(),
+		Handler: server.HTTPHandler(mcp.HTTPOptions{
+			AuthToken:      opts.authToken,
+			AllowedOrigins: opts.allowedOrigins,
+			Stateful:       opts.stateful,
+			SessionAPIKeys: opts.sessionAPIKeys,
+			SessionTimeout: opts.sessionTimeout,
+		}),
+		TLSConfig: tlsConfig,
+	}
+
+	if opts.authToken == "" && opts.clientCA == "" && !opts.sessionAPIKeys && !isLoopbackHost(host) {
+		logger.Warn("MCP server is reachable from other hosts without authentication; use --auth-token, --client-ca, or --session-api-keys",
+			slog.String("host", host),
+		)
 	}
 
-	logger.Info("Tiger MCP server started", slog.String("address", address))
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+	}
+	logger.Info("Tiger MCP server started",
+		slog.String("address", address),
+		slog.String("url", fmt.Sprintf("%s://%s", scheme, address)),
+		slog.Bool("auth_token", opts.authToken != ""),
+		slog.Bool("mtls", opts.clientCA != ""),
+		slog.Bool("stateful", opts.stateful || opts.sessionAPIKeys),
+		slog.Bool("session_api_keys", opts.sessionAPIKeys),
+	)
 	logger.Info("Use Ctrl+C to stop the server")
 
 	// Start server in goroutine using the existing listener
 	errCh := make(chan error, 1)
 	go func() {
-		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if tlsConfig != nil {
+			// The certificates are already loaded into TLSConfig
+			err = httpServer.ServeTLS(listener, "", "")
+		} else {
+			err = httpServer.Serve(listener)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			errCh <- err
 		}
 	}()
@@ -143,3 +247,41 @@ func getListener(host string, startPort int) (net.Listener, int, error) {
 	}
 	return nil, 0, fmt.Errorf("no available port found in range %d-%d", startPort, startPort+99)
 }
+
+// buildMCPTLSConfig loads the server certificate and, if clientCAFile is set,
+// requires client certificates signed by that CA.
+func buildMCPTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in client CA file %s", clientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+// isLoopbackHost reports whether host only accepts local connections.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+	"github.com/tailscale/hujson"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/mcp"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// MCPInstallStatus describes the Tiger MCP server entry (or lack thereof) in
+// one client configuration file
+type MCPInstallStatus struct {
+	Client     string `json:"client"`
+	ConfigPath string `json:"config_path"`
+	// Project is true for the client's project-scoped config file in the
+	// current directory
+	Project   bool     `json:"project"`
+	Installed bool     `json:"installed"`
+	Command   string   `json:"command,omitempty"`
+	Args      []string `json:"args,omitempty"`
+	// Stale is true when Command no longer resolves to an executable
+	Stale bool `json:"stale"`
+	// Current is true when Command resolves to the running tiger binary
+	Current bool   `json:"current"`
+	Error   string `json:"error,omitempty"`
+}
+
+// buildMCPStatusCmd creates the status subcommand for showing where the Tiger
+// MCP server is installed
+func buildMCPStatusCmd(app *common.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show which MCP clients have the Tiger MCP server installed",
+		Long: `Show which MCP clients have the Tiger MCP server installed.
+
+Every known configuration file location for the supported clients is scanned,
+along with the clients' project-scoped configuration files in the current
+directory (see 'tiger mcp install --scope project').
+For each client, the output shows the configuration file, the tiger binary the
+installed entry points at, and whether that binary still exists. A stale entry
+(e.g. left behind after moving or reinstalling tiger) can be fixed by running
+'tiger mcp install <client>' again.
+
+Examples:
+  # Show installation status in table format (default)
+  tiger mcp status
+
+  # Show installation status as JSON
+  tiger mcp status -o json`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			cfg := app.GetConfig()
+
+			// Project-scoped configs are best-effort; skip them if the
+			// current directory is unavailable
+			projectDir, _ := os.Getwd()
+			statuses := getMCPInstallStatuses(mcp.ServerName, projectDir)
+
+			output := cmd.OutOrStdout()
+			switch cfg.Output {
+			case "json":
+				return util.SerializeToJSON(output, statuses)
+			case "yaml":
+				return util.SerializeToYAML(output, statuses)
+			default:
+				return outputMCPStatusTable(output, statuses)
+			}
+		},
+	}
+
+	cmd.Flags().VarP(new(outputFlag), "output", "o", "output format (json, yaml, table)")
+
+	return cmd
+}
+
+// getMCPInstallStatuses scans the config files of every supported client for
+// the given MCP server. Clients with no config file on disk are reported as
+// not installed at their default config location. Project-scoped config files
+// in projectDir are included when they exist.
+func getMCPInstallStatuses(serverName, projectDir string) []MCPInstallStatus {
+	currentPath := resolveExecutable(getTigerExecutablePathOrEmpty())
+
+	var statuses []MCPInstallStatus
+	for i := range supportedClients {
+		clientCfg := &supportedClients[i]
+
+		found := false
+		for _, path := range clientCfg.ConfigPaths {
+			configPath := util.ExpandPath(path)
+			if _, err := os.Stat(configPath); err != nil {
+				continue
+			}
+			found = true
+			statuses = append(statuses, readMCPInstallStatus(clientCfg, configPath, serverName, currentPath))
+		}
+
+		if !found && len(clientCfg.ConfigPaths) > 0 {
+			statuses = append(statuses, MCPInstallStatus{
+				Client:     clientCfg.EditorNames[0],
+				ConfigPath: util.ExpandPath(clientCfg.ConfigPaths[0]),
+			})
+		}
+
+		if projectDir != "" && clientCfg.projectConfigPath != "" {
+			configPath := filepath.Join(projectDir, clientCfg.projectConfigPath)
+			if _, err := os.Stat(configPath); err == nil {
+				status := readMCPInstallStatus(clientCfg, configPath, serverName, currentPath)
+				status.Project = true
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	return statuses
+}
+
+// readMCPInstallStatus reads the entry for serverName from a single client
+// config file
+func readMCPInstallStatus(clientCfg *clientConfig, configPath, serverName, currentPath string) MCPInstallStatus {
+	status := MCPInstallStatus{
+		Client:     clientCfg.EditorNames[0],
+		ConfigPath: configPath,
+	}
+
+	entry, err := readMCPServerEntry(configPath, clientCfg.serversPathPrefix(), serverName)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	if entry == nil {
+		return status
+	}
+
+	status.Installed = true
+	status.Command = entry.Command
+	status.Args = entry.Args
+
+	resolved := resolveExecutable(entry.Command)
+	status.Stale = resolved == ""
+	status.Current = resolved != "" && resolved == currentPath
+	return status
+}
+
+// readMCPServerEntry returns the entry for serverName under serversPath (a
+// JSON pointer, also used as the table path for .toml files), or nil if the
+// config file has no such entry
+func readMCPServerEntry(configPath, serversPath, serverName string) (*MCPServerConfig, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc map[string]any
+	if filepath.Ext(configPath) == ".toml" {
+		if err := toml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	} else if len(strings.TrimSpace(string(content))) > 0 {
+		standardized, err := hujson.Standardize(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		if err := json.Unmarshal(standardized, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	servers := doc
+	for _, segment := range strings.Split(strings.Trim(serversPath, "/"), "/") {
+		next, ok := servers[segment].(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		servers = next
+	}
+
+	raw, ok := servers[serverName]
+	if !ok {
+		return nil, nil
+	}
+
+	// Round-trip through JSON to decode the generic map into MCPServerConfig
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s entry: %w", serverName, err)
+	}
+	var entry MCPServerConfig
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("invalid %s entry: %w", serverName, err)
+	}
+	return &entry, nil
+}
+
+// resolveExecutable returns the absolute, symlink-free path of command (which
+// is looked up in PATH if it isn't a path), or "" if it doesn't resolve to an
+// existing file
+func resolveExecutable(command string) string {
+	if command == "" {
+		return ""
+	}
+
+	path := command
+	if !strings.ContainsRune(command, filepath.Separator) && !strings.ContainsRune(command, '/') {
+		var err error
+		path, err = exec.LookPath(command)
+		if err != nil {
+			return ""
+		}
+	}
+
+	resolved, err := filepath.EvalSymlinks(util.ExpandPath(path))
+	if err != nil {
+		return ""
+	}
+	if abs, err := filepath.Abs(resolved); err == nil {
+		resolved = abs
+	}
+	if info, err := os.Stat(resolved); err != nil || info.IsDir() {
+		return ""
+	}
+	return resolved
+}
+
+// getTigerExecutablePathOrEmpty returns the path of the running tiger binary,
+// or "" if it can't be determined
+func getTigerExecutablePathOrEmpty() string {
+	path, err := getTigerExecutablePath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// outputMCPStatusTable outputs MCP installation status in table format
+func outputMCPStatusTable(output io.Writer, statuses []MCPInstallStatus) error {
+	table := tablewriter.NewWriter(output)
+	table.Header("CLIENT", "SCOPE", "STATUS", "COMMAND", "CONFIG PATH")
+
+	for _, status := range statuses {
+		scope := "user"
+		if status.Project {
+			scope = "project"
+		}
+		table.Append(status.Client, scope, formatMCPStatus(status), status.Command, status.ConfigPath)
+	}
+
+	return table.Render()
+}
+
+// formatMCPStatus summarizes an MCPInstallStatus for table output
+func formatMCPStatus(status MCPInstallStatus) string {
+	switch {
+	case status.Error != "":
+		return "error: " + status.Error
+	case !status.Installed:
+		return "not installed"
+	case status.Stale:
+		return "installed (stale)"
+	case !status.Current:
+		return "installed (other binary)"
+	default:
+		return "installed"
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMCPInstallStatuses(t *testing.T) {
+	tempHome := t.TempDir()
+	t.Setenv("HOME", tempHome)
+	t.Setenv("CODEX_HOME", "")
+
+	// A fake tiger binary standing in for the running executable
+	tigerPath := filepath.Join(tempHome, "bin", "tiger")
+	require.NoError(t, os.MkdirAll(filepath.Dir(tigerPath), 0755))
+	require.NoError(t, os.WriteFile(tigerPath, []byte("#!/bin/sh\n"), 0755))
+	originalFunc := tigerExecutablePathFunc
+	tigerExecutablePathFunc = func() (string, error) { return tigerPath, nil }
+	t.Cleanup(func() { tigerExecutablePathFunc = originalFunc })
+
+	// Cursor points at the current binary
+	cursorPath := filepath.Join(tempHome, ".cursor", "mcp.json")
+	require.NoError(t, addMCPServerViaJSON(cursorPath, "/mcpServers", "tiger", tigerPath, []string{"mcp", "start"}, nil))
+
+	// Windsurf points at a binary that no longer exists
+	windsurfPath := filepath.Join(tempHome, ".codeium", "windsurf", "mcp_config.json")
+	require.NoError(t, addMCPServerViaJSON(windsurfPath, "/mcpServers", "tiger", "/old/location/tiger", []string{"mcp", "start"}, nil))
+
+	// Codex (TOML) has the current binary installed
+	codexPath := filepath.Join(tempHome, ".codex", "config.toml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(codexPath), 0755))
+	require.NoError(t, os.WriteFile(codexPath, []byte(`model = "o3"
+
+[mcp_servers.tiger]
+command = "`+tigerPath+`"
+args = ["mcp", "start"]
+`), 0600))
+
+	// Claude Code has a config file without the tiger server
+	require.NoError(t, os.WriteFile(filepath.Join(tempHome, ".claude.json"), []byte(`{"mcpServers": {}}`), 0600))
+
+	// Gemini has an unparsable config file
+	geminiPath := filepath.Join(tempHome, ".gemini", "settings.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(geminiPath), 0755))
+	require.NoError(t, os.WriteFile(geminiPath, []byte(`{not json`), 0600))
+
+	statuses := make(map[string]MCPInstallStatus)
+	for _, status := range getMCPInstallStatuses("tiger", "") {
+		statuses[status.Client] = status
+	}
+
+	cursor := statuses["cursor"]
+	assert.True(t, cursor.Installed)
+	assert.Equal(t, cursorPath, cursor.ConfigPath)
+	assert.Equal(t, tigerPath, cursor.Command)
+	assert.Equal(t, []string{"mcp", "start"}, cursor.Args)
+	assert.False(t, cursor.Stale)
+	assert.True(t, cursor.Current)
+	assert.Equal(t, "installed", formatMCPStatus(cursor))
+
+	windsurf := statuses["windsurf"]
+	assert.True(t, windsurf.Installed)
+	assert.True(t, windsurf.Stale)
+	assert.False(t, windsurf.Current)
+	assert.Equal(t, "installed (stale)", formatMCPStatus(windsurf))
+
+	codex := statuses["codex"]
+	assert.True(t, codex.Installed)
+	assert.Equal(t, codexPath, codex.ConfigPath)
+	assert.True(t, codex.Current)
+
+	claude := statuses["claude-code"]
+	assert.False(t, claude.Installed)
+	assert.Empty(t, claude.Error)
+
+	assert.NotEmpty(t, statuses["gemini"].Error)
+
+	antigravity := statuses["antigravity"]
+	assert.False(t, antigravity.Installed)
+	assert.Equal(t, filepath.Join(tempHome, ".gemini", "antigravity", "mcp_config.json"), antigravity.ConfigPath)
+}
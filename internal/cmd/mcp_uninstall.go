@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tailscale/hujson"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/mcp"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// errMCPServerNotInstalled is returned when uninstalling a server that isn't
+// in the client's config file
+var errMCPServerNotInstalled = errors.New("MCP server is not installed")
+
+// buildMCPUninstallCmd creates the uninstall subcommand for removing the
+// Tiger MCP server from editors
+func buildMCPUninstallCmd(app *common.App) *cobra.Command {
+	var noBackup bool
+	var configPath string
+	var scope string
+
+	cmd := &cobra.Command{
+		Use:     "uninstall [client]",
+		Aliases: []string{"remove", "rm"},
+		Short:   "Remove the Tiger MCP server from a client",
+		Long: fmt.Sprintf(`Remove the Tiger MCP server configuration from a specific MCP client or AI assistant.
+
+This reverses 'tiger mcp install'. Clients with a CLI for managing MCP servers
+(e.g. Claude Code, Codex) are updated through it; for the others, the Tiger
+entry is removed from the client's configuration file, leaving other MCP
+servers untouched. A backup of the configuration file is created by default.
+
+%s
+If no client is specified, you'll be prompted to select one interactively.
+Use 'tiger mcp status' to see which clients have the Tiger MCP server installed.
+
+Examples:
+  # Interactive client selection
+  tiger mcp uninstall
+
+  # Remove from Cursor
+  tiger mcp uninstall cursor
+
+  # Remove from the project's Claude Code config (.mcp.json)
+  tiger mcp uninstall claude-code --scope project
+
+  # Remove without creating backup
+  tiger mcp uninstall claude-code --no-backup
+
+  # Use custom configuration file path
+  tiger mcp uninstall cursor --config-path ~/custom/mcp.json`, generateSupportedEditorsHelp()),
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: getValidEditorNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			var projectDir string
+			switch scope {
+			case "user":
+			case "project":
+				if configPath != "" {
+					return fmt.Errorf("--config-path cannot be used with --scope project")
+				}
+				var err error
+				projectDir, err = os.Getwd()
+				if err != nil {
+					return fmt.Errorf("failed to get current directory: %w", err)
+				}
+			default:
+				return fmt.Errorf("invalid scope %q: must be 'user' or 'project'", scope)
+			}
+
+			var clientName string
+			if len(args) == 0 {
+				// No client specified, prompt user to select one
+				if !util.IsTerminal(cmd.InOrStdin()) || !util.IsTerminal(cmd.ErrOrStderr()) {
+					return fmt.Errorf("TTY not detected - specify a client as an argument (e.g. 'tiger mcp uninstall claude-code')")
+				}
+				var err error
+				clientName, err = selectClientInteractively(cmd)
+				if err != nil {
+					return fmt.Errorf("failed to select client: %w", err)
+				}
+				if clientName == "" {
+					return fmt.Errorf("no client selected")
+				}
+			} else {
+				clientName = args[0]
+			}
+
+			backupPath, err := UninstallMCPForClient(UninstallOptions{
+				ClientName:       clientName,
+				ServerName:       mcp.ServerName,
+				CreateBackup:     !noBackup,
+				CustomConfigPath: configPath,
+				ProjectDir:       projectDir,
+			})
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("✅ Removed Tiger MCP server configuration from %s\n", clientName)
+			if backupPath != "" {
+				cmd.Printf("💾 Backup of previous configuration: %s\n", backupPath)
+			}
+			cmd.Printf("\n💡 Restart %s for the change to take effect\n", clientName)
+			return nil
+		},
+	}
+
+	// Add flags
+	cmd.Flags().BoolVar(&noBackup, "no-backup", false, "Skip creating backup of existing configuration (default: create backup)")
+	cmd.Flags().StringVar(&configPath, "config-path", "", "Custom path to configuration file (overrides default locations)")
+	cmd.Flags().StringVar(&scope, "scope", "user", "Configuration scope: user (all projects) or project (the client's config file in the current directory)")
+
+	return cmd
+}
+
+// UninstallOptions configures MCP server removal
+type UninstallOptions struct {
+	// ClientName is the name of the client to configure (required)
+	ClientName string
+	// ServerName is the name the MCP server was registered as (required)
+	ServerName string
+	// CreateBackup creates a backup of existing config files before modification
+	CreateBackup bool
+	// CustomConfigPath overrides the default config file location
+	CustomConfigPath string
+	// ProjectDir, if set, removes the server from the client's per-project
+	// config file within this directory instead of the user-global configuration
+	ProjectDir string
+}
+
+// UninstallMCPForClient removes an MCP server configuration from the
+// specified client. It is the reverse of InstallMCPForClient and is exported
+// for use by external projects via pkg/mcpinstall. Returns the path of the
+// backup it created, if any.
+func UninstallMCPForClient(opts UninstallOptions) (string, error) {
+	if opts.ClientName == "" {
+		return "", fmt.Errorf("missing required option: ClientName")
+	}
+	if opts.ServerName == "" {
+		return "", fmt.Errorf("missing required option: ServerName")
+	}
+
+	clientCfg, err := findClientConfig(opts.ClientName)
+	if err != nil {
+		return "", err
+	}
+
+	var configPath string
+	if opts.ProjectDir != "" {
+		if clientCfg.projectConfigPath == "" {
+			return "", fmt.Errorf("client %s does not support project-scoped MCP configuration", opts.ClientName)
+		}
+		configPath = filepath.Join(util.ExpandPath(opts.ProjectDir), clientCfg.projectConfigPath)
+	} else if opts.CustomConfigPath != "" {
+		configPath = util.ExpandPath(opts.CustomConfigPath)
+	} else if len(clientCfg.ConfigPaths) > 0 {
+		configPath, err = findClientConfigFile(clientCfg.ConfigPaths)
+		if err != nil {
+			return "", fmt.Errorf("failed to find configuration for %s: %w", opts.ClientName, err)
+		}
+	}
+
+	var backupPath string
+	if opts.CreateBackup && configPath != "" {
+		backupPath, err = createConfigBackup(configPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+
+	// Like install, clients with a CLI are always managed through it, since
+	// their config file may not be JSON (e.g. Codex's config.toml)
+	if clientCfg.buildUninstallCommand != nil && opts.ProjectDir == "" {
+		err = removeMCPServerViaCLI(clientCfg, opts.ServerName)
+	} else if configPath != "" {
+		err = removeMCPServerViaJSON(configPath, clientCfg.serversPathPrefix(), opts.ServerName)
+	} else {
+		return "", fmt.Errorf("client %s has no ConfigPaths or buildUninstallCommand defined", opts.ClientName)
+	}
+	if err != nil {
+		if errors.Is(err, errMCPServerNotInstalled) && backupPath != "" {
+			// Nothing changed, so the backup isn't needed
+			_ = os.Remove(backupPath)
+		}
+		return "", fmt.Errorf("failed to remove MCP server configuration: %w", err)
+	}
+
+	return backupPath, nil
+}
+
+// removeMCPServerViaCLI removes an MCP server using the client's CLI
+func removeMCPServerViaCLI(clientCfg *clientConfig, serverName string) error {
+	uninstallCommand := clientCfg.buildUninstallCommand(serverName)
+
+	output, err := exec.Command(uninstallCommand[0], uninstallCommand[1:]...).CombinedOutput()
+	if err != nil {
+		cmdStr := strings.Join(uninstallCommand, " ")
+		if string(output) != "" {
+			return fmt.Errorf("failed to run %s uninstall command: %w\nCommand: %s\nOutput: %s", clientCfg.Name, err, cmdStr, string(output))
+		}
+		return fmt.Errorf("failed to run %s uninstall command: %w\nCommand: %s", clientCfg.Name, err, cmdStr)
+	}
+
+	return nil
+}
+
+// removeMCPServerViaJSON removes an MCP server entry from a JSON config file,
+// preserving the file's comments, formatting of other entries, and mode
+func removeMCPServerViaJSON(configPath, mcpServersPathPrefix, serverName string) error {
+	info, err := os.Stat(configPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s: %w", configPath, errMCPServerNotInstalled)
+	} else if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	value, err := hujson.Parse(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse existing config: %w", err)
+	}
+
+	serverPath := mcpServersPathPrefix + "/" + escapeJSONPointer(serverName)
+	if value.Find(serverPath) == nil {
+		return fmt.Errorf("%s not found in %s: %w", serverName, configPath, errMCPServerNotInstalled)
+	}
+
+	patch := fmt.Sprintf(`[{ "op": "remove", "path": %q }]`, serverPath)
+	if err := value.Patch([]byte(patch)); err != nil {
+		return fmt.Errorf("failed to apply JSON patch: %w", err)
+	}
+
+	formatted, err := hujson.Format(value.Pack())
+	if err != nil {
+		return fmt.Errorf("failed to format patched JSON: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, formatted, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// escapeJSONPointer escapes a JSON pointer reference token (RFC 6901)
+func escapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveMCPServerViaJSON(t *testing.T) {
+	t.Run("removes only the named server and keeps comments", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "mcp.json")
+		content := `{
+  // user comment
+  "mcpServers": {
+    "tiger": {"command": "/usr/local/bin/tiger", "args": ["mcp", "start"]},
+    "other": {"command": "other"}
+  }
+}`
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0640))
+
+		require.NoError(t, removeMCPServerViaJSON(configPath, "/mcpServers", "tiger"))
+
+		entry, err := readMCPServerEntry(configPath, "/mcpServers", "tiger")
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+
+		other, err := readMCPServerEntry(configPath, "/mcpServers", "other")
+		require.NoError(t, err)
+		require.NotNil(t, other)
+		assert.Equal(t, "other", other.Command)
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "// user comment")
+
+		info, err := os.Stat(configPath)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), "mcp.json")
+		require.NoError(t, os.WriteFile(configPath, []byte(`{"mcpServers": {}}`), 0600))
+
+		err := removeMCPServerViaJSON(configPath, "/mcpServers", "tiger")
+		assert.ErrorIs(t, err, errMCPServerNotInstalled)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := removeMCPServerViaJSON(filepath.Join(t.TempDir(), "missing.json"), "/mcpServers", "tiger")
+		assert.ErrorIs(t, err, errMCPServerNotInstalled)
+	})
+}
+
+func TestUninstallMCPForClient(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "mcp.json")
+	require.NoError(t, addMCPServerViaJSON(configPath, "/mcpServers", "tiger", "/usr/local/bin/tiger", []string{"mcp", "start"}, nil))
+
+	backupPath, err := UninstallMCPForClient(UninstallOptions{
+		ClientName:       "cursor",
+		ServerName:       "tiger",
+		CreateBackup:     true,
+		CustomConfigPath: configPath,
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, backupPath)
+
+	entry, err := readMCPServerEntry(configPath, "/mcpServers", "tiger")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+
+	// Uninstalling again fails and doesn't leave another backup behind
+	_, err = UninstallMCPForClient(UninstallOptions{
+		ClientName:       "cursor",
+		ServerName:       "tiger",
+		CreateBackup:     true,
+		CustomConfigPath: configPath,
+	})
+	assert.ErrorIs(t, err, errMCPServerNotInstalled)
+	backups, err := filepath.Glob(configPath + ".backup.*")
+	require.NoError(t, err)
+	assert.Len(t, backups, 1)
+}
+
+func TestUninstallMCPForClient_CLIClientWithConfigPath(t *testing.T) {
+	// Keep the real codex CLI from being found
+	t.Setenv("PATH", t.TempDir())
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	content := "[mcp_servers.tiger]\ncommand = \"tiger\"\n"
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+
+	// Like install, a client with a CLI is uninstalled through it rather than
+	// by parsing its (TOML) config file as JSON
+	_, err := UninstallMCPForClient(UninstallOptions{
+		ClientName:       "codex",
+		ServerName:       "tiger",
+		CustomConfigPath: configPath,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to run Codex uninstall command")
+
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+)
+
+// Extra output formats accepted by `tiger service metrics series -o`.
+const (
+	metricsOutputCSV  = "csv"
+	metricsOutputProm = "prom"
+)
+
+// writeMetricSeriesCSV writes one CSV row per data point. Every label key seen
+// across the series becomes its own column (sorted, after the metric name) so
+// the file loads straight into a spreadsheet or dataframe; gap buckets have an
+// empty value.
+func writeMetricSeriesCSV(w io.Writer, metric string, series []api.MetricSeries) error {
+	keySet := make(map[string]struct{})
+	for _, s := range series {
+		for k := range s.Labels {
+			keySet[k] = struct{}{}
+		}
+	}
+	keys := slices.Sorted(maps.Keys(keySet))
+
+	cw := csv.NewWriter(w)
+	header := append(append([]string{"metric"}, keys...), "time", "value")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, s := range series {
+		for _, p := range s.Data {
+			row := make([]string, 0, len(header))
+			row = append(row, metric)
+			for _, k := range keys {
+				row = append(row, s.Labels[k])
+			}
+			val := ""
+			if p.Value != nil {
+				val = strconv.FormatFloat(*p.Value, 'f', -1, 64)
+			}
+			row = append(row, p.Time.UTC().Format(time.RFC3339), val)
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// promSample is one line of the Prometheus text exposition format.
+type promSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+
+	// timestamp is omitted when zero, which is what a scrape target normally
+	// does: Prometheus then stamps the sample with the scrape time.
+	timestamp time.Time
+}
+
+// promSamplesFromSeries converts every non-null data point of a metric's
+// series to a timestamped sample. extraLabels (e.g. service_id) are added to
+// each sample's own labels.
+func promSamplesFromSeries(metric string, series []api.MetricSeries, extraLabels map[string]string) []promSample {
+	var samples []promSample
+	for _, s := range series {
+		labels := promMergeLabels(s.Labels, extraLabels)
+		for _, p := range s.Data {
+			if p.Value == nil {
+				continue
+			}
+			samples = append(samples, promSample{name: metric, labels: labels, value: *p.Value, timestamp: p.Time})
+		}
+	}
+	return samples
+}
+
+// latestPromSamples converts the most recent non-null data point of each
+// series to an untimestamped sample. Series with no data are skipped.
+func latestPromSamples(metric string, series []api.MetricSeries, extraLabels map[string]string) []promSample {
+	var samples []promSample
+	for _, s := range series {
+		for i := len(s.Data) - 1; i >= 0; i-- {
+			if v := s.Data[i].Value; v != nil {
+				samples = append(samples, promSample{name: metric, labels: promMergeLabels(s.Labels, extraLabels), value: *v})
+				break
+			}
+		}
+	}
+	return samples
+}
+
+func promMergeLabels(labels, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(labels)+len(extra))
+	maps.Copy(merged, labels)
+	maps.Copy(merged, extra)
+	return merged
+}
+
+// writePromSamples writes samples in the Prometheus text exposition format.
+// Samples are grouped by metric name with a single TYPE line per metric; all
+// metrics are exposed as gauges, since the API returns per-bucket aggregates
+// rather than raw counters.
+func writePromSamples(w io.Writer, samples []promSample) error {
+	samples = slices.Clone(samples)
+	slices.SortStableFunc(samples, func(a, b promSample) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	var b strings.Builder
+	for i, s := range samples {
+		name := promName(s.name)
+		if i == 0 || s.name != samples[i-1].name {
+			fmt.Fprintf(&b, "# TYPE %s gauge\n", name)
+		}
+		b.WriteString(name)
+		if len(s.labels) > 0 {
+			b.WriteByte('{')
+			for j, k := range slices.Sorted(maps.Keys(s.labels)) {
+				if j > 0 {
+					b.WriteByte(',')
+				}
+				fmt.Fprintf(&b, "%s=\"%s\"", promName(k), promEscape(s.labels[k]))
+			}
+			b.WriteByte('}')
+		}
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatFloat(s.value, 'g', -1, 64))
+		if !s.timestamp.IsZero() {
+			fmt.Fprintf(&b, " %d", s.timestamp.UnixMilli())
+		}
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// promName replaces characters that aren't valid in a Prometheus metric or
+// label name with underscores.
+func promName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		valid := r == '_' || r == ':' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9')
+		if !valid {
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promEscape escapes a label value for the text exposition format.
+func promEscape(value string) string {
+	return promEscaper.Replace(value)
+}
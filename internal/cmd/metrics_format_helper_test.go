@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func TestWriteMetricSeriesCSV(t *testing.T) {
+	replica := testMetricSeries(nil, util.Ptr(1.5))
+	replica.Labels = map[string]string{"role": "replica", "ordinal": "1"}
+	series := []api.MetricSeries{testMetricSeries(util.Ptr(812.0)), replica}
+
+	var buf bytes.Buffer
+	if err := writeMetricSeriesCSV(&buf, "cpu", series); err != nil {
+		t.Fatalf("writeMetricSeriesCSV() error = %v", err)
+	}
+
+	want := "metric,ordinal,role,time,value\n" +
+		"cpu,,primary,2026-05-13T00:00:00Z,812\n" +
+		"cpu,1,replica,2026-05-13T00:00:00Z,\n" +
+		"cpu,1,replica,2026-05-13T00:01:00Z,1.5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeMetricSeriesCSV() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWritePromSamples(t *testing.T) {
+	series := []api.MetricSeries{testMetricSeries(util.Ptr(812.0), nil, util.Ptr(0.25))}
+	extra := map[string]string{"service_id": "svc-12345"}
+
+	tests := []struct {
+		name    string
+		samples []promSample
+		want    string
+	}{
+		{
+			name:    "all points with timestamps",
+			samples: promSamplesFromSeries("cpu_usage", series, extra),
+			want: "# TYPE cpu_usage gauge\n" +
+				"cpu_usage{role=\"primary\",service_id=\"svc-12345\"} 812 1778630400000\n" +
+				"cpu_usage{role=\"primary\",service_id=\"svc-12345\"} 0.25 1778630520000\n",
+		},
+		{
+			name:    "latest point",
+			samples: latestPromSamples("cpu_usage", series, extra),
+			want: "# TYPE cpu_usage gauge\n" +
+				"cpu_usage{role=\"primary\",service_id=\"svc-12345\"} 0.25\n",
+		},
+		{
+			name: "grouped by name, names and values escaped",
+			samples: []promSample{
+				{name: "b.metric", labels: map[string]string{"path": "C:\\\"x\"\n"}, value: 1},
+				{name: "a", value: 2, timestamp: time.UnixMilli(5)},
+				{name: "b.metric", value: 3},
+			},
+			want: "# TYPE a gauge\n" +
+				"a 2 5\n" +
+				"# TYPE b_metric gauge\n" +
+				"b_metric{path=\"C:\\\\\\\"x\\\"\\n\"} 1\n" +
+				"b_metric 3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writePromSamples(&buf, tt.samples); err != nil {
+				t.Fatalf("writePromSamples() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writePromSamples() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
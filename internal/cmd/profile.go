@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+func buildProfileCmd(app *common.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage credential and configuration profiles",
+		Long: `Manage named profiles. Each profile has its own credentials (and therefore its
+own project), default service, and configuration overrides, so you can switch
+between Tiger Cloud projects and accounts without logging in again.
+
+Profiles are created by logging in with --profile, and any command can be run
+against a profile other than the active one with --profile (or TIGER_PROFILE).
+
+Examples:
+  # Log in to a new profile and make it the active one
+  tiger auth login --profile staging
+  tiger profile use staging
+
+  # Run a single command against another profile
+  tiger service list --profile prod
+
+  # Set a configuration override for a profile
+  tiger config set service_id svc-12345 --profile prod`,
+	}
+
+	cmd.AddCommand(buildProfileListCmd(app))
+	cmd.AddCommand(buildProfileUseCmd(app))
+	cmd.AddCommand(buildProfileDeleteCmd(app))
+
+	return cmd
+}
+
+// profileCompletion completes profile names. It only needs the config
+// directory, so it loads the config directly rather than via withAppLoad.
+func profileCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := config.Load(cmd.Flags())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	profiles, err := config.ListProfiles(cfg.ConfigDir)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var results []string
+	for _, profile := range profiles {
+		if strings.HasPrefix(profile, toComplete) {
+			results = append(results, profile)
+		}
+	}
+	return results, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+func buildProfileDeleteCmd(app *common.App) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete <profile>",
+		Aliases:           []string{"rm"},
+		Short:             "Delete a profile",
+		Long:              `Delete a profile's stored credentials and configuration overrides. If it is the active profile, the default profile becomes active. The default profile cannot be deleted; use 'tiger auth logout' and 'tiger config reset' instead.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: profileCompletion,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			cfg := app.GetConfig()
+
+			profile := args[0]
+			if err := config.ValidateProfileName(profile); err != nil {
+				return err
+			}
+			exists, err := config.ProfileExists(cfg.ConfigDir, profile)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("profile %q does not exist", profile)
+			}
+
+			if err := cfg.DeleteProfile(profile); err != nil {
+				return fmt.Errorf("failed to delete profile: %w", err)
+			}
+
+			cmd.Printf("Deleted profile %s\n", profile)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// ProfileInfo describes a profile in `tiger profile list` output
+type ProfileInfo struct {
+	Name      string `json:"name"`
+	Active    bool   `json:"active"`
+	LoggedIn  bool   `json:"logged_in"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+func buildProfileListCmd(app *common.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list",
+		Aliases:           []string{"ls"},
+		Short:             "List profiles",
+		Long:              `List all profiles, marking the active one and showing the project each is logged in to.`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			cfg := app.GetConfig()
+
+			names, err := config.ListProfiles(cfg.ConfigDir)
+			if err != nil {
+				return err
+			}
+
+			profiles := make([]ProfileInfo, 0, len(names))
+			for _, name := range names {
+				info := ProfileInfo{Name: name, Active: name == cfg.Profile}
+				creds, err := cfg.WithProfile(name).GetStoredCredentials()
+				switch {
+				case err == nil:
+					info.LoggedIn = true
+					info.ProjectID = creds.ProjectID
+				case !errors.Is(err, config.ErrNotLoggedIn):
+					cmd.PrintErrf("warning: failed to read credentials for profile %s: %v\n", name, err)
+				}
+				profiles = append(profiles, info)
+			}
+
+			output := cmd.OutOrStdout()
+			switch cfg.Output {
+			case "json":
+				return util.SerializeToJSON(output, profiles)
+			case "yaml":
+				return util.SerializeToYAML(output, profiles)
+			default:
+				return outputProfilesTable(output, profiles)
+			}
+		},
+	}
+
+	cmd.Flags().VarP(new(outputFlag), "output", "o", "output format (json, yaml, table)")
+
+	return cmd
+}
+
+func outputProfilesTable(w io.Writer, profiles []ProfileInfo) error {
+	table := tablewriter.NewWriter(w)
+	table.Header("ACTIVE", "PROFILE", "PROJECT ID")
+	for _, profile := range profiles {
+		active := ""
+		if profile.Active {
+			active = "*"
+		}
+		projectID := profile.ProjectID
+		if !profile.LoggedIn {
+			projectID = "(not logged in)"
+		}
+		table.Append(active, profile.Name, projectID)
+	}
+	return table.Render()
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+func TestAuthLogin_Profile(t *testing.T) {
+	tmpDir := setupAuthTest(t)
+
+	output, err := executeAuthCommand(t.Context(), "auth", "login", "--profile", "staging", "--public-key", "staging-public", "--secret-key", "staging-secret")
+	if err != nil {
+		t.Fatalf("Login failed: %v", err)
+	}
+	if !strings.Contains(output, "Successfully logged in to profile staging") {
+		t.Errorf("Expected output to name the profile: '%s'", output)
+	}
+	if !strings.Contains(output, "tiger profile use staging") {
+		t.Errorf("Expected output to suggest switching profiles: '%s'", output)
+	}
+
+	creds, err := testConfig(t).WithProfile("staging").GetStoredCredentials()
+	if err != nil {
+		t.Fatalf("Failed to get staging credentials: %v", err)
+	}
+	if creds.APIKey != "staging-public:staging-secret" {
+		t.Errorf("Expected staging API key, got %s", creds.APIKey)
+	}
+	if _, err := testConfig(t).GetStoredCredentials(); err == nil {
+		t.Error("Expected the default profile to remain logged out")
+	}
+
+	exists, err := config.ProfileExists(tmpDir, "staging")
+	if err != nil || !exists {
+		t.Errorf("Expected staging profile to exist (err: %v)", err)
+	}
+}
+
+func TestProfileCommands(t *testing.T) {
+	tmpDir := setupAuthTest(t)
+
+	if err := testConfig(t).WithProfile("staging").StoreCredentials("staging-key", "staging-project"); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+
+	if _, err := executeAuthCommand(t.Context(), "profile", "use", "missing"); err == nil {
+		t.Error("Expected switching to a missing profile to fail")
+	}
+
+	output, err := executeAuthCommand(t.Context(), "profile", "use", "staging")
+	if err != nil {
+		t.Fatalf("profile use failed: %v", err)
+	}
+	if !strings.Contains(output, "Switched to profile staging") {
+		t.Errorf("Unexpected output: '%s'", output)
+	}
+	if cfg := testConfig(t); cfg.Profile != "staging" {
+		t.Errorf("Expected active profile staging, got %s", cfg.Profile)
+	}
+
+	// Per-profile config overrides
+	if _, err := executeAuthCommand(t.Context(), "config", "set", "service_id", "staging-service"); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if cfg := testConfig(t); cfg.ServiceID != "staging-service" {
+		t.Errorf("Expected staging service ID, got %q", cfg.ServiceID)
+	}
+	flags := testFlags(t, tmpDir)
+	flags.String("profile", "", "profile")
+	if err := flags.Set("profile", config.DefaultProfile); err != nil {
+		t.Fatalf("Failed to set profile flag: %v", err)
+	}
+	if cfg, err := config.Load(flags); err != nil || cfg.ServiceID != "" {
+		t.Errorf("Expected no service ID for the default profile, got %v (err: %v)", cfg, err)
+	}
+
+	output, err = executeAuthCommand(t.Context(), "profile", "list", "-o", "json")
+	if err != nil {
+		t.Fatalf("profile list failed: %v", err)
+	}
+	var profiles []ProfileInfo
+	if err := json.Unmarshal([]byte(output), &profiles); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+	expected := []ProfileInfo{
+		{Name: "default"},
+		{Name: "staging", Active: true, LoggedIn: true, ProjectID: "staging-project"},
+	}
+	if len(profiles) != len(expected) {
+		t.Fatalf("Expected %d profiles, got %+v", len(expected), profiles)
+	}
+	for i := range expected {
+		if profiles[i] != expected[i] {
+			t.Errorf("Expected profile %+v, got %+v", expected[i], profiles[i])
+		}
+	}
+
+	if _, err := executeAuthCommand(t.Context(), "profile", "delete", "staging"); err != nil {
+		t.Fatalf("profile delete failed: %v", err)
+	}
+	cfg := testConfig(t)
+	if cfg.Profile != config.DefaultProfile {
+		t.Errorf("Expected active profile to revert to default, got %s", cfg.Profile)
+	}
+	if _, err := cfg.WithProfile("staging").GetStoredCredentials(); err == nil {
+		t.Error("Expected staging credentials to be removed")
+	}
+}
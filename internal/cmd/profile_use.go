@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+func buildProfileUseCmd(app *common.App) *cobra.Command {
+	return &cobra.Command{
+		Use:               "use <profile>",
+		Aliases:           []string{"switch"},
+		Short:             "Switch the active profile",
+		Long:              `Make the given profile the active one for subsequent commands. The selection is saved to ~/.config/tiger/config.yaml; the --profile flag and TIGER_PROFILE still take precedence over it.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: profileCompletion,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			cfg := app.GetConfig()
+
+			profile := args[0]
+			if err := config.ValidateProfileName(profile); err != nil {
+				return err
+			}
+			exists, err := config.ProfileExists(cfg.ConfigDir, profile)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("profile %q does not exist (create it with 'tiger auth login --profile %s')", profile, profile)
+			}
+
+			if err := cfg.Set("profile", profile); err != nil {
+				return fmt.Errorf("failed to switch profile: %w", err)
+			}
+
+			cmd.Printf("Switched to profile %s\n", profile)
+			return nil
+		},
+	}
+}
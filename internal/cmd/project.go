@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+)
+
+func buildProjectCmd(app *common.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "project",
+		Aliases: []string{"projects", "proj"},
+		Short:   "Manage the current project",
+		Long: `List the projects you have access to and switch between them.
+
+Switching projects re-scopes the stored OAuth session, so no new browser login
+is needed. API key (PAT) credentials are tied to a single project; use a
+separate profile per project instead (see 'tiger profile').`,
+	}
+
+	cmd.AddCommand(buildProjectListCmd(app))
+	cmd.AddCommand(buildProjectUseCmd(app))
+
+	return cmd
+}
+
+// fetchProjects returns the projects the authenticated user can access
+func fetchProjects(ctx context.Context, client api.ClientWithResponsesInterface) ([]api.Project, error) {
+	resp, err := client.GetProjectsWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user projects: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	return *resp.JSON200, nil
+}
+
+// formatProject returns a project's name and ID for display, falling back to
+// just the ID if the project isn't in the list
+func formatProject(projects []api.Project, projectID string) string {
+	for _, project := range projects {
+		if project.ID == projectID && project.Name != "" {
+			return fmt.Sprintf("%s (%s)", project.Name, project.ID)
+		}
+	}
+	return projectID
+}
+
+// describeProject looks up the current project's name for display. Lookup
+// failures are not fatal, and just fall back to the project ID.
+func describeProject(ctx context.Context, client api.ClientWithResponsesInterface, projectID string) string {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	projects, err := fetchProjects(ctx, client)
+	if err != nil {
+		return projectID
+	}
+	return formatProject(projects, projectID)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// OutputProject describes a project in `tiger project list` output
+type OutputProject struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
+func buildProjectListCmd(app *common.App) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list",
+		Aliases:           []string{"ls"},
+		Short:             "List accessible projects",
+		Long:              `List the projects you have access to, marking the current one.`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			cfg, client, projectID, err := app.GetAll()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			projects, err := fetchProjects(ctx, client)
+			if err != nil {
+				return err
+			}
+
+			outputProjects := make([]OutputProject, len(projects))
+			for i, project := range projects {
+				outputProjects[i] = OutputProject{
+					ID:      project.ID,
+					Name:    project.Name,
+					Current: project.ID == projectID,
+				}
+			}
+
+			output := cmd.OutOrStdout()
+			switch cfg.Output {
+			case "json":
+				return util.SerializeToJSON(output, outputProjects)
+			case "yaml":
+				return util.SerializeToYAML(output, outputProjects)
+			default:
+				return outputProjectsTable(output, outputProjects)
+			}
+		},
+	}
+
+	cmd.Flags().VarP(new(outputFlag), "output", "o", "output format (json, yaml, table)")
+
+	return cmd
+}
+
+func outputProjectsTable(w io.Writer, projects []OutputProject) error {
+	table := tablewriter.NewWriter(w)
+	table.Header("CURRENT", "PROJECT ID", "NAME")
+	for _, project := range projects {
+		current := ""
+		if project.Current {
+			current = "*"
+		}
+		table.Append(current, project.ID, project.Name)
+	}
+	return table.Render()
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+// setupProjectTest serves two projects (each with no services) and points
+// the test config at the mock server
+func setupProjectTest(t *testing.T) string {
+	t.Helper()
+	tmpDir := setupAuthTest(t)
+
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/projects":
+			w.Write([]byte(`[{"id": "proj-1", "name": "First Project"}, {"id": "proj-2", "name": "Second Project"}]`))
+		case strings.HasSuffix(r.URL.Path, "/services"):
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(mockServer.Close)
+
+	if _, err := config.UseTestConfig(tmpDir, map[string]any{
+		"api_url":       mockServer.URL,
+		"version_check": false,
+	}); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	return tmpDir
+}
+
+func storeTestOAuthCredentials(t *testing.T, projectID string) {
+	t.Helper()
+	token := &oauth2.Token{
+		AccessToken:  "test-access-token",
+		RefreshToken: "test-refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	}
+	if err := testConfig(t).StoreOAuthCredentials(token, projectID); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+}
+
+func TestProjectList(t *testing.T) {
+	setupProjectTest(t)
+	storeTestOAuthCredentials(t, "proj-1")
+
+	output, err := executeAuthCommand(t.Context(), "project", "list", "-o", "json")
+	if err != nil {
+		t.Fatalf("project list failed: %v", err)
+	}
+
+	var projects []OutputProject
+	if err := json.Unmarshal([]byte(output), &projects); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, output)
+	}
+	expected := []OutputProject{
+		{ID: "proj-1", Name: "First Project", Current: true},
+		{ID: "proj-2", Name: "Second Project"},
+	}
+	if len(projects) != len(expected) {
+		t.Fatalf("Expected %d projects, got %+v", len(expected), projects)
+	}
+	for i := range expected {
+		if projects[i] != expected[i] {
+			t.Errorf("Expected project %+v, got %+v", expected[i], projects[i])
+		}
+	}
+}
+
+func TestProjectUse(t *testing.T) {
+	setupProjectTest(t)
+	storeTestOAuthCredentials(t, "proj-1")
+	if err := testConfig(t).Set("service_id", "svc-proj1"); err != nil {
+		t.Fatalf("Failed to set default service: %v", err)
+	}
+
+	if _, err := executeAuthCommand(t.Context(), "project", "use", "proj-3"); err == nil {
+		t.Error("Expected switching to an inaccessible project to fail")
+	}
+
+	output, err := executeAuthCommand(t.Context(), "project", "use", "proj-2")
+	if err != nil {
+		t.Fatalf("project use failed: %v", err)
+	}
+	if !strings.Contains(output, "Switched to project Second Project (proj-2)") {
+		t.Errorf("Unexpected output: '%s'", output)
+	}
+
+	stored, err := testConfig(t).GetStoredCredentials()
+	if err != nil {
+		t.Fatalf("Failed to get stored credentials: %v", err)
+	}
+	if stored.ProjectID != "proj-2" {
+		t.Errorf("Expected stored project proj-2, got %s", stored.ProjectID)
+	}
+	if stored.OAuth == nil || stored.OAuth.RefreshToken != "test-refresh-token" {
+		t.Errorf("Expected the OAuth session to be kept, got %+v", stored.OAuth)
+	}
+
+	// The default service belonged to the old project
+	if !strings.Contains(output, "Cleared default service 'svc-proj1'") {
+		t.Errorf("Expected the output to mention the cleared default service: '%s'", output)
+	}
+	if serviceID := testConfig(t).ServiceID; serviceID != "" {
+		t.Errorf("Expected the default service to be cleared, got %s", serviceID)
+	}
+
+	// The service list names the new project
+	output, err = executeAuthCommand(t.Context(), "service", "list")
+	if err != nil {
+		t.Fatalf("service list failed: %v", err)
+	}
+	if !strings.Contains(output, "Project: Second Project (proj-2)") {
+		t.Errorf("Expected service list to name the project: '%s'", output)
+	}
+}
+
+func TestProjectUse_APIKeyCredentials(t *testing.T) {
+	setupProjectTest(t)
+	if err := testConfig(t).StoreCredentials("test-public:test-secret", "proj-1"); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+
+	_, err := executeAuthCommand(t.Context(), "project", "use", "proj-2")
+	if err == nil || !strings.Contains(err.Error(), "API key credentials are tied to a single project") {
+		t.Errorf("Expected API key credentials to be rejected, got: %v", err)
+	}
+
+	stored, err := testConfig(t).GetStoredCredentials()
+	if err != nil {
+		t.Fatalf("Failed to get stored credentials: %v", err)
+	}
+	if stored.ProjectID != "proj-1" {
+		t.Errorf("Expected stored project to be unchanged, got %s", stored.ProjectID)
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func buildProjectUseCmd(app *common.App) *cobra.Command {
+	return &cobra.Command{
+		Use:     "use [project-id]",
+		Aliases: []string{"switch"},
+		Short:   "Switch the current project",
+		Long: `Switch the project subsequent commands operate on, without logging in again.
+
+The stored OAuth session is re-scoped to the given project, and the default
+service (which belongs to the previous project) is cleared. Without a project
+ID, an interactive picker lists the projects you have access to.
+
+Examples:
+  # Pick a project interactively
+  tiger project use
+
+  # Switch to a specific project
+  tiger project use abc123xyz`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: projectIDCompletion(app),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			if os.Getenv("TIGER_PUBLIC_KEY") != "" || os.Getenv("TIGER_SECRET_KEY") != "" {
+				return errors.New("cannot switch projects while authenticating with TIGER_PUBLIC_KEY/TIGER_SECRET_KEY; API keys are tied to a single project")
+			}
+
+			cfg, client, currentProjectID, err := app.GetAll()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			projects, err := fetchProjects(ctx, client)
+			if err != nil {
+				return err
+			}
+
+			var projectID string
+			if len(args) > 0 {
+				projectID = args[0]
+				if !slices.ContainsFunc(projects, func(p api.Project) bool { return p.ID == projectID }) {
+					return fmt.Errorf("project %s not found or not accessible (see 'tiger project list')", projectID)
+				}
+			} else {
+				if !util.IsTerminal(cmd.InOrStdin()) || !util.IsTerminal(cmd.ErrOrStderr()) {
+					return errors.New("TTY not detected - project ID required")
+				}
+				projectID, err = selectProjectInteractively(cmd, projects)
+				if err != nil {
+					return err
+				}
+			}
+
+			if projectID == currentProjectID {
+				cmd.Printf("Already using project %s\n", formatProject(projects, projectID))
+				return nil
+			}
+
+			// Re-read the credentials after the API call above, in case the
+			// client refreshed (and persisted) the OAuth token
+			stored, err := cfg.GetStoredCredentials()
+			if err != nil {
+				if errors.Is(err, config.ErrNotLoggedIn) {
+					return common.ExitWithCode(common.ExitAuthenticationError, config.ErrNotLoggedIn)
+				}
+				return err
+			}
+			if stored.OAuth == nil {
+				return errors.New("API key credentials are tied to a single project; log in with 'tiger auth login' (without API keys) to switch projects, or use a profile per project (see 'tiger profile')")
+			}
+
+			creds := &config.Credentials{OAuth: stored.OAuth, ProjectID: projectID}
+			if err := cfg.StoreOAuthCredentials(creds.OAuth, creds.ProjectID); err != nil {
+				return fmt.Errorf("failed to store credentials: %w", err)
+			}
+
+			// The old client would persist refreshed tokens under the old
+			// project, so hand the App one scoped to the new project (later
+			// readers such as analytics reuse it)
+			newClient, err := api.NewTigerClientForCredentials(cfg, creds)
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+			app.SetClient(newClient, projectID)
+
+			cmd.Printf("Switched to project %s\n", formatProject(projects, projectID))
+			return clearDefaultService(cmd, cfg)
+		},
+	}
+}
+
+// clearDefaultService unsets the default service after switching projects,
+// since it belongs to the previous project. A default service set through
+// TIGER_SERVICE_ID or --service-id can't be cleared, so it's warned about.
+func clearDefaultService(cmd *cobra.Command, cfg *config.Config) error {
+	serviceID := cfg.ServiceID
+	if serviceID == "" {
+		return nil
+	}
+
+	if err := cfg.Unset("service_id"); err != nil {
+		return fmt.Errorf("failed to clear default service: %w", err)
+	}
+	if cfg.ServiceID != "" {
+		cmd.PrintErrf("⚠️  Warning: the default service %s (set through TIGER_SERVICE_ID or --service-id) belongs to the previous project\n", cfg.ServiceID)
+		return nil
+	}
+
+	cmd.PrintErrf("🎯 Cleared default service '%s', which belongs to the previous project.\n", serviceID)
+	return nil
+}
+
+// projectIDCompletion completes the IDs of the projects the user can access
+func projectIDCompletion(app *common.App) cobra.CompletionFunc {
+	return withAppLoad(app, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		client, _, err := app.GetClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		projects, err := fetchProjects(cmd.Context(), client)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var results []string
+		for _, project := range projects {
+			results = append(results, cobra.CompletionWithDesc(project.ID, project.Name))
+		}
+		return results, cobra.ShellCompDirectiveNoFileComp
+	})
+}
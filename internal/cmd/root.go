@@ -73,7 +73,8 @@ tiger auth login
 	cmd.PersistentFlags().Bool("analytics", true, "enable/disable usage analytics")
 	cmd.PersistentFlags().Bool("color", true, "enable colored output")
 	cmd.PersistentFlags().String("config-dir", config.GetDefaultConfigDir(), "config directory")
-	cmd.PersistentFlags().String("password-storage", config.DefaultPasswordStorage, "password storage method (keyring, pgpass, none)")
+	cmd.PersistentFlags().String("password-storage", config.DefaultPasswordStorage, "password storage method (keyring, pgpass, exec, none)")
+	cmd.PersistentFlags().String("profile", config.DefaultProfile, "credentials and configuration profile to use")
 	cmd.PersistentFlags().String("service-id", "", "service ID")
 	skipUpdateCheck := cmd.PersistentFlags().Bool("skip-update-check", false, "skip checking for updates on startup")
 
@@ -82,6 +83,8 @@ tiger auth login
 	cmd.AddCommand(buildUpgradeCmd(app))
 	cmd.AddCommand(buildConfigCmd(app))
 	cmd.AddCommand(buildAuthCmd(app))
+	cmd.AddCommand(buildProfileCmd(app))
+	cmd.AddCommand(buildProjectCmd(app))
 	cmd.AddCommand(buildServiceCmd(app))
 	cmd.AddCommand(buildDbCmd(app))
 	cmd.AddCommand(buildMCPCmd(app))
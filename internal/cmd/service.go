@@ -15,9 +15,9 @@ import (
 )
 
 // buildServiceCmd creates the main service command with all subcommands.
-// experimental gates preview-stage subcommands (currently `metrics`); when
-// false, those subtrees are not added to the tree at all — matching ghost's
-// TIGER_EXPERIMENTAL pattern. See CLAUDE.md's "Experimental Feature Gating".
+// Preview-stage subcommands are only added when app.Experimental is set,
+// matching ghost's TIGER_EXPERIMENTAL pattern (none are currently in preview).
+// See CLAUDE.md's "Experimental Feature Gating".
 func buildServiceCmd(app *common.App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "service",
@@ -37,11 +37,9 @@ func buildServiceCmd(app *common.App) *cobra.Command {
 	cmd.AddCommand(buildServiceForkCmd(app))
 	cmd.AddCommand(buildServiceResizeCmd(app))
 	cmd.AddCommand(buildServiceLogsCmd(app))
-
-	// Experimental commands, unregistered until the preview graduates.
-	if app.Experimental {
-		cmd.AddCommand(buildServiceMetricsCmd(app))
-	}
+	cmd.AddCommand(buildServiceMetricsCmd(app))
+	cmd.AddCommand(buildServiceTopCmd(app))
+	cmd.AddCommand(buildServiceRecommendSizeCmd(app))
 
 	return cmd
 }
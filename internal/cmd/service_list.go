@@ -54,6 +54,12 @@ func buildServiceListCmd(app *common.App) *cobra.Command {
 			}
 			services := *resp.JSON200
 
+			// Name the project above the table, so it's clear which project
+			// is being listed (even when it has no services)
+			if format := strings.ToLower(cfg.Output); format != "json" && format != "yaml" && format != "env" {
+				cmd.Printf("Project: %s\n", describeProject(cmd.Context(), client, projectID))
+			}
+
 			if len(services) == 0 {
 				cmd.PrintErrln("🏜️  No services found! Your project is looking a bit empty.")
 				cmd.PrintErrln("🚀 Ready to get started? Create your first service with: tiger service create")
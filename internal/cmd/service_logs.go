@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,6 +21,9 @@ func buildServiceLogsCmd(app *common.App) *cobra.Command {
 	var since time.Time
 	var until time.Time
 	var node int
+	var exportPath string
+	var exportFormat string
+	var resume bool
 
 	cmd := &cobra.Command{
 		Use:     "logs [service-id]",
@@ -48,10 +54,34 @@ Examples:
   tiger service logs --tail 50
 
   # View last 1000 lines
-  tiger service logs --tail 1000`,
+  tiger service logs --tail 1000
+
+  # Export every log entry in a time range to a file, newest first
+  tiger service logs --export logs.ndjson \
+    --since "2024-01-15T00:00:00Z" --until "2024-01-16T00:00:00Z"
+
+  # Export as CSV or as OpenTelemetry (OTLP/JSON) log records
+  tiger service logs --export logs.csv --format csv --since "2024-01-15T00:00:00Z"
+  tiger service logs --export logs.otlp.jsonl --format otlp-json --since "2024-01-15T00:00:00Z"
+
+  # Continue an export that was interrupted
+  tiger service logs --export logs.ndjson --resume`,
 		Args:              cobra.MaximumNArgs(1),
 		ValidArgsFunction: serviceIDCompletion(app),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if exportPath == "" {
+				if cmd.Flags().Changed("format") || resume {
+					return fmt.Errorf("--format and --resume can only be used with --export")
+				}
+			} else {
+				if cmd.Flags().Changed("tail") {
+					return fmt.Errorf("--tail cannot be used with --export; bound the export with --since and --until instead")
+				}
+				if !slices.Contains(common.ValidLogExportFormats(), exportFormat) {
+					return fmt.Errorf("invalid --format: %s (must be one of: %s)", exportFormat, strings.Join(common.ValidLogExportFormats(), ", "))
+				}
+			}
+
 			cfg, client, projectID, err := app.GetAll()
 			if err != nil {
 				cmd.SilenceUsage = true
@@ -84,6 +114,20 @@ Examples:
 				nodePtr = &node
 			}
 
+			if exportPath != "" {
+				return exportServiceLogs(cmd, common.ExportServiceLogsArgs{
+					Client:    client,
+					ProjectID: projectID,
+					ServiceID: serviceID,
+					Since:     sincePtr,
+					Until:     untilPtr,
+					Node:      nodePtr,
+					Path:      exportPath,
+					Format:    exportFormat,
+					Resume:    resume,
+				})
+			}
+
 			// Fetch logs with pagination support
 			ctx, cancel := context.WithTimeout(cmd.Context(), time.Minute)
 			defer cancel()
@@ -138,10 +182,44 @@ Examples:
 	cmd.Flags().TimeVar(&until, "until", time.Time{}, []string{time.RFC3339}, "Fetch logs before this timestamp (RFC3339 format, e.g., 2024-01-15T10:00:00Z)")
 	cmd.Flags().IntVar(&node, "node", 0, "Specific service node to fetch logs from (for services with HA replicas, 0 is valid)")
 	cmd.Flags().VarP(new(outputFlag), "output", "o", "Output format (text, json, yaml)")
+	cmd.Flags().StringVar(&exportPath, "export", "", "Stream every log entry in the time range to this file instead of printing the last --tail lines")
+	cmd.Flags().StringVar(&exportFormat, "format", common.LogExportFormatNDJSON, "Export file format (ndjson, csv, otlp-json); requires --export")
+	cmd.Flags().BoolVar(&resume, "resume", false, "Continue an interrupted --export from its last checkpoint")
 
 	return cmd
 }
 
+// exportServiceLogs streams logs to the export file, showing a running count
+// of exported entries. Unlike the regular fetch there is no overall timeout:
+// an export can span any number of pages, and Ctrl+C leaves a checkpoint
+// behind for --resume.
+func exportServiceLogs(cmd *cobra.Command, args common.ExportServiceLogsArgs) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	spinner := common.NewSpinner(common.SpinnerArgs{
+		Input:   cmd.InOrStdin(),
+		Output:  cmd.ErrOrStderr(),
+		Message: fmt.Sprintf("Exporting logs to %s", args.Path),
+		Cancel:  cancel,
+	})
+	args.Progress = func(exported int) {
+		spinner.Update(fmt.Sprintf("Exported %d log entries to %s", exported, args.Path))
+	}
+
+	exported, err := common.ExportServiceLogs(ctx, args)
+	spinner.Stop()
+	if err != nil {
+		if _, statErr := os.Stat(common.LogExportCheckpointPath(args.Path)); statErr == nil {
+			return fmt.Errorf("export interrupted after %d log entries; re-run with --resume to continue: %w", exported, err)
+		}
+		return err
+	}
+
+	cmd.PrintErrf("📦 Exported %d log entries to %s\n", exported, args.Path)
+	return nil
+}
+
 // colorizeLogEntry colorizes the severity token (e.g. "ERROR:") within the log
 // line using the API-provided severity field. Using the structured field avoids
 // false positives where a severity word appears in the message body rather than
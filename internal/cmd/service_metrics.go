@@ -6,12 +6,7 @@ import (
 	"github.com/timescale/tiger-cli/internal/common"
 )
 
-// buildServiceMetricsCmd creates the metrics subcommand group. The metrics
-// surface targets gateway endpoints marked `x-tigerdata-preview: true` in the
-// OpenAPI spec — their request/response contract is still in flux. Registration is
-// gated on TIGER_EXPERIMENTAL in buildServiceCmd, so this builder is only
-// called when the env var is set; the tree doesn't include `metrics` at all
-// otherwise.
+// buildServiceMetricsCmd creates the metrics subcommand group.
 func buildServiceMetricsCmd(app *common.App) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "metrics",
@@ -20,5 +15,8 @@ func buildServiceMetricsCmd(app *common.App) *cobra.Command {
 	}
 	cmd.AddCommand(buildServiceMetricsAvailableSeriesCmd(app))
 	cmd.AddCommand(buildServiceMetricsSeriesCmd(app))
+	cmd.AddCommand(buildServiceMetricsCompareCmd(app))
+	cmd.AddCommand(buildServiceMetricsExporterCmd(app))
+	cmd.AddCommand(buildServiceMetricsWatchCmd(app))
 	return cmd
 }
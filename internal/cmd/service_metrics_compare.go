@@ -0,0 +1,452 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// buildServiceMetricsCompareCmd fetches the same metric for several services
+// and lines them up side by side
+func buildServiceMetricsCompareCmd(app *common.App) *cobra.Command {
+	var metric string
+	var from string
+	var to string
+	var role string
+	var filters []string
+	var bucketSeconds int
+	var fn string
+	var chart bool
+
+	cmd := &cobra.Command{
+		Use:   "compare <service-id> <service-id>...",
+		Short: "Compare a metric across services",
+		Long: `Fetch the same metric series for several services over the same window and
+show them side by side.
+
+Buckets are aligned by time, so each row of the table holds every service's
+value for the same bucket. The first service is the baseline: every other
+service gets a delta column with its difference from the baseline, and the
+summary rows show each service's min/avg/max against the baseline's. A service
+that returns several labeled series (e.g. a primary and a replica) gets one
+column per series, compared against the baseline's series with the same
+labels; use --role or --filter to narrow them down.
+
+Examples:
+  # Compare CPU usage of a production service and a fork under load test
+  tiger service metrics compare svc-prod svc-fork \
+    --metric timescale_cloud_system_cpu_usage_millicores \
+    --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z
+
+  # Compare a service with its read replica
+  tiger service metrics compare svc-12345 svc-replica \
+    --metric timescale_cloud_database_num_connections \
+    --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z
+
+  # Compare memory usage of three services at 5 minute resolution, as JSON
+  tiger service metrics compare svc-a svc-b svc-c \
+    --metric timescale_cloud_system_memory_usage_bytes \
+    --from 2026-05-13T00:00:00Z --to 2026-05-14T00:00:00Z \
+    --bucket-seconds 300 --output json
+
+  # Chart the services as sparklines on a shared scale
+  tiger service metrics compare svc-prod svc-fork \
+    --metric timescale_cloud_system_cpu_usage_millicores \
+    --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z --chart`,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fromTime, err := time.Parse(time.RFC3339, from)
+			if err != nil {
+				return fmt.Errorf("--from must be RFC3339 (e.g., 2026-05-13T00:00:00Z): %w", err)
+			}
+			toTime, err := time.Parse(time.RFC3339, to)
+			if err != nil {
+				return fmt.Errorf("--to must be RFC3339 (e.g., 2026-05-13T01:00:00Z): %w", err)
+			}
+			if chart && cmd.Flags().Changed("output") {
+				return fmt.Errorf("--chart cannot be combined with --output")
+			}
+
+			labelFilters, err := parseMetricFilters(role, filters)
+			if err != nil {
+				return err
+			}
+
+			cfg, client, projectID, err := app.GetAll()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			// Every service gets the identical request, so the server picks the
+			// same buckets for all of them.
+			body := api.MetricsSeriesRequest{
+				Name: metric,
+				From: fromTime,
+				To:   toTime,
+			}
+			if bucketSeconds > 0 {
+				bs := bucketSeconds
+				body.BucketSeconds = &bs
+			}
+			if fn != "" {
+				f := api.MetricsSeriesRequestFn(strings.ToUpper(fn))
+				body.Fn = &f
+			}
+			if len(labelFilters) > 0 {
+				body.Filters = &labelFilters
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			series, err := fetchMetricSeriesForServices(ctx, client, projectID, args, body)
+			if err != nil {
+				return err
+			}
+
+			comparison := buildMetricComparison(metric, args, series)
+			if !slices.ContainsFunc(series[0], func(s api.MetricSeries) bool {
+				return common.SummarizeMetricData(s.Data).Count > 0
+			}) {
+				cmd.PrintErrf("⚠️  Warning: baseline service %s returned no data for the requested window, so no deltas can be computed\n", args[0])
+			}
+			out := cmd.OutOrStdout()
+			if chart {
+				renderMetricComparisonChart(out, comparison, chartWidth(out))
+				return nil
+			}
+			switch strings.ToLower(cfg.Output) {
+			case "json":
+				return util.SerializeToJSON(out, comparison)
+			case "yaml":
+				return util.SerializeToYAML(out, comparison)
+			default:
+				if len(comparison.Columns) == 0 {
+					cmd.Println("No metric data returned for the requested window.")
+					return nil
+				}
+				return renderMetricComparisonTable(out, comparison)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&metric, "metric", "", "Metric series name")
+	cmd.Flags().StringVar(&from, "from", "", "Start of the time window (RFC3339)")
+	cmd.Flags().StringVar(&to, "to", "", "End of the time window (RFC3339)")
+	cmd.Flags().StringVar(&role, "role", "", "Filter to a specific instance role (PRIMARY or REPLICA)")
+	cmd.Flags().StringSliceVar(&filters, "filter", nil, "Arbitrary label filter as name=value (repeatable)")
+	cmd.Flags().IntVar(&bucketSeconds, "bucket-seconds", 0, "Aggregation bucket size in seconds (optional; server auto-selects based on the time window when omitted, minimum 60s)")
+	cmd.Flags().StringVar(&fn, "fn", "", "Aggregation function applied per bucket. One of: RATE, INCREASE, SUM, AVG, MIN, MAX, COUNT, P50, P90, P99, LAST")
+	cmd.Flags().VarP(new(outputFlag), "output", "o", "Output format (json, yaml, table)")
+	cmd.Flags().BoolVar(&chart, "chart", false, "Draw a sparkline per service on a shared scale instead of a table")
+
+	cmd.MarkFlagRequired("metric")
+	cmd.MarkFlagRequired("from")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// fetchMetricSeriesForServices runs the same series request for every
+// service concurrently. The result is indexed like serviceIDs.
+func fetchMetricSeriesForServices(ctx context.Context, client api.ClientWithResponsesInterface, projectID string, serviceIDs []string, req api.MetricsSeriesRequest) ([][]api.MetricSeries, error) {
+	results := make([][]api.MetricSeries, len(serviceIDs))
+	errs := make([]error, len(serviceIDs))
+
+	var wg sync.WaitGroup
+	for i, serviceID := range serviceIDs {
+		wg.Go(func() {
+			results[i], errs[i] = common.FetchMetricSeries(ctx, client, projectID, serviceID, req)
+		})
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			// Keep the API error's exit code while naming the failing service.
+			var exitErr common.ExitCodeError
+			if errors.As(err, &exitErr) {
+				return nil, common.ExitWithCode(exitErr.ExitCode(), fmt.Errorf("service %s: %w", serviceIDs[i], err))
+			}
+			return nil, fmt.Errorf("service %s: %w", serviceIDs[i], err)
+		}
+	}
+	return results, nil
+}
+
+// metricComparison is a set of series from several services with their
+// buckets aligned. The columns of the first service are the baselines that
+// the other columns' deltas are relative to.
+type metricComparison struct {
+	Metric  string                   `json:"metric"`
+	Columns []metricComparisonColumn `json:"columns"`
+	Rows    []metricComparisonRow    `json:"rows"`
+}
+
+type metricComparisonColumn struct {
+	ServiceID string               `json:"service_id"`
+	Labels    map[string]string    `json:"labels"`
+	Summary   common.MetricSummary `json:"summary"`
+
+	// Baseline is the index of the column this one is compared against: the
+	// first service's series with the same labels, or its only series. It's
+	// omitted for the first service's own columns, and when the first
+	// service has no matching series.
+	Baseline *int `json:"baseline,omitempty"`
+
+	// AvgDelta is the difference between this column's average and the
+	// baseline's. It's omitted when there's no baseline or either has no data.
+	AvgDelta *float64 `json:"avg_delta,omitempty"`
+
+	// name is the column header: the service ID, plus the labels when the
+	// service returned more than one series.
+	name string
+	// compared is set for the columns of every service but the first, which
+	// get a delta column in the table.
+	compared bool
+}
+
+// metricComparisonRow holds every column's value for one bucket. Deltas[i] is
+// Values[i] minus its baseline column's value, or nil when either is a gap or
+// there is no baseline (always for the first service's columns).
+type metricComparisonRow struct {
+	Time   time.Time  `json:"time"`
+	Values []*float64 `json:"values"`
+	Deltas []*float64 `json:"deltas"`
+}
+
+// buildMetricComparison aligns the series of every service by bucket time.
+// series is indexed like serviceIDs.
+func buildMetricComparison(metric string, serviceIDs []string, series [][]api.MetricSeries) metricComparison {
+	comparison := metricComparison{Metric: metric, Columns: []metricComparisonColumn{}, Rows: []metricComparisonRow{}}
+
+	var byTime []map[time.Time]*float64
+	var times []time.Time
+	seen := make(map[time.Time]bool)
+	for i, serviceID := range serviceIDs {
+		svcSeries := slices.Clone(series[i])
+		slices.SortFunc(svcSeries, func(a, b api.MetricSeries) int {
+			return strings.Compare(labelString(a.Labels), labelString(b.Labels))
+		})
+		for _, s := range svcSeries {
+			name := serviceID
+			if len(svcSeries) > 1 {
+				name += labelString(s.Labels)
+			}
+			comparison.Columns = append(comparison.Columns, metricComparisonColumn{
+				ServiceID: serviceID,
+				Labels:    s.Labels,
+				Summary:   common.SummarizeMetricData(s.Data),
+				name:      name,
+				compared:  i > 0,
+			})
+
+			values := make(map[time.Time]*float64, len(s.Data))
+			for _, p := range s.Data {
+				t := p.Time.UTC()
+				values[t] = p.Value
+				if !seen[t] {
+					seen[t] = true
+					times = append(times, t)
+				}
+			}
+			byTime = append(byTime, values)
+		}
+	}
+	slices.SortFunc(times, time.Time.Compare)
+
+	for i := range comparison.Columns {
+		col := &comparison.Columns[i]
+		if !col.compared {
+			continue
+		}
+		b := baselineColumn(comparison.Columns, col.Labels)
+		if b < 0 {
+			continue
+		}
+		col.Baseline = util.Ptr(b)
+		if base := comparison.Columns[b].Summary; col.Summary.Count > 0 && base.Count > 0 {
+			col.AvgDelta = util.Ptr(col.Summary.Avg - base.Avg)
+		}
+	}
+
+	for _, t := range times {
+		row := metricComparisonRow{
+			Time:   t,
+			Values: make([]*float64, len(byTime)),
+			Deltas: make([]*float64, len(byTime)),
+		}
+		for i, values := range byTime {
+			row.Values[i] = values[t]
+		}
+		for i, col := range comparison.Columns {
+			if b := col.Baseline; b != nil && row.Values[i] != nil && row.Values[*b] != nil {
+				row.Deltas[i] = util.Ptr(*row.Values[i] - *row.Values[*b])
+			}
+		}
+		comparison.Rows = append(comparison.Rows, row)
+	}
+	return comparison
+}
+
+// baselineColumn returns the index of the first service's column with the
+// given labels, falling back to its only column when it has just one, or -1.
+func baselineColumn(columns []metricComparisonColumn, labels map[string]string) int {
+	key := labelString(labels)
+	baselines := 0
+	for i, col := range columns {
+		if col.compared {
+			break
+		}
+		if labelString(col.Labels) == key {
+			return i
+		}
+		baselines++
+	}
+	if baselines == 1 {
+		return 0
+	}
+	return -1
+}
+
+// renderMetricComparisonTable renders one row per bucket with a delta column
+// after every non-baseline column, followed by min/avg/max summary rows.
+func renderMetricComparisonTable(w io.Writer, comparison metricComparison) error {
+	header := []any{"TIME"}
+	for _, col := range comparison.Columns {
+		header = append(header, col.name)
+		if col.compared {
+			header = append(header, "Δ "+col.name)
+		}
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.Header(header...)
+	for _, row := range comparison.Rows {
+		cells := []any{row.Time.Format(time.RFC3339)}
+		for i, col := range comparison.Columns {
+			cells = append(cells, formatComparisonValue(row.Values[i]))
+			if col.compared {
+				var baseValue *float64
+				if col.Baseline != nil {
+					baseValue = row.Values[*col.Baseline]
+				}
+				cells = append(cells, formatMetricDelta(row.Deltas[i], baseValue))
+			}
+		}
+		table.Append(cells...)
+	}
+
+	summaries := []struct {
+		name  string
+		value func(common.MetricSummary) float64
+	}{
+		{"min", func(s common.MetricSummary) float64 { return s.Min }},
+		{"avg", func(s common.MetricSummary) float64 { return s.Avg }},
+		{"max", func(s common.MetricSummary) float64 { return s.Max }},
+	}
+	for _, summary := range summaries {
+		cells := []any{summary.name}
+		for _, col := range comparison.Columns {
+			var v *float64
+			if col.Summary.Count > 0 {
+				v = util.Ptr(summary.value(col.Summary))
+			}
+			cells = append(cells, formatComparisonValue(v))
+			if !col.compared {
+				continue
+			}
+			var delta, baseValue *float64
+			if col.Baseline != nil {
+				if base := comparison.Columns[*col.Baseline].Summary; base.Count > 0 {
+					baseValue = util.Ptr(summary.value(base))
+					if v != nil {
+						delta = util.Ptr(*v - *baseValue)
+					}
+				}
+			}
+			cells = append(cells, formatMetricDelta(delta, baseValue))
+		}
+		table.Append(cells...)
+	}
+	return table.Render()
+}
+
+func formatComparisonValue(v *float64) string {
+	if v == nil {
+		return "null"
+	}
+	return fmt.Sprintf("%.3f", *v)
+}
+
+// formatMetricDelta renders a delta with an explicit sign, plus the relative
+// change against the baseline value when that's nonzero.
+func formatMetricDelta(delta, base *float64) string {
+	if delta == nil {
+		return ""
+	}
+	s := fmt.Sprintf("%+.3f", *delta)
+	if base != nil && *base != 0 {
+		s += fmt.Sprintf(" (%+.1f%%)", *delta/math.Abs(*base)*100)
+	}
+	return s
+}
+
+// renderMetricComparisonChart draws a sparkline per column, all on the same
+// scale so their heights can be compared directly, each followed by its
+// average and the difference from the baseline's.
+func renderMetricComparisonChart(w io.Writer, comparison metricComparison, width int) {
+	if len(comparison.Columns) == 0 {
+		fmt.Fprintln(w, "No metric data returned for the requested window.")
+		return
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	nameWidth := 0
+	for _, col := range comparison.Columns {
+		if col.Summary.Count > 0 {
+			lo, hi = min(lo, col.Summary.Min), max(hi, col.Summary.Max)
+		}
+		nameWidth = max(nameWidth, len(col.name))
+	}
+	if lo > hi {
+		fmt.Fprintln(w, "No metric data returned for the requested window.")
+		return
+	}
+
+	fmt.Fprintf(w, "%s (scale %s – %s)\n", comparison.Metric, formatMetricValue(lo), formatMetricValue(hi))
+	for i, col := range comparison.Columns {
+		if col.Summary.Count == 0 {
+			fmt.Fprintf(w, "%-*s  (no data)\n", nameWidth, col.name)
+			continue
+		}
+
+		values := make([]*float64, len(comparison.Rows))
+		for j, row := range comparison.Rows {
+			values[j] = row.Values[i]
+		}
+		stats := "avg " + formatMetricValue(col.Summary.Avg)
+		if col.AvgDelta != nil {
+			stats += " " + formatMetricDelta(col.AvgDelta, &comparison.Columns[*col.Baseline].Summary.Avg)
+		}
+		sparkWidth := max(width-nameWidth-len(stats)-4, 10)
+		fmt.Fprintf(w, "%-*s  %s  %s\n", nameWidth, col.name, renderSparkline(values, sparkWidth, lo, hi), stats)
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func TestBuildMetricComparison(t *testing.T) {
+	f := util.Ptr[float64]
+
+	// svc-b starts a bucket later and has a gap; svc-c returns two series.
+	prod := testMetricSeries(f(100), f(200), f(300))
+	fork := testMetricSeries(f(150), nil)
+	for i := range fork.Data {
+		fork.Data[i].Time = fork.Data[i].Time.Add(time.Minute)
+	}
+	replica := testMetricSeries(f(50))
+	replica.Labels = map[string]string{"role": "replica"}
+
+	comparison := buildMetricComparison("cpu", []string{"svc-a", "svc-b", "svc-c"}, [][]api.MetricSeries{
+		{prod},
+		{fork},
+		{replica, testMetricSeries(f(400))},
+	})
+
+	var names []string
+	for _, col := range comparison.Columns {
+		names = append(names, col.name)
+	}
+	if got, want := strings.Join(names, ","), `svc-a,svc-b,svc-c{role="primary"},svc-c{role="replica"}`; got != want {
+		t.Errorf("column names = %s, want %s", got, want)
+	}
+
+	if len(comparison.Rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(comparison.Rows))
+	}
+	second := comparison.Rows[1]
+	if *second.Values[1] != 150 || *second.Deltas[1] != -50 {
+		t.Errorf("second row svc-b value/delta = %v/%v, want 150/-50", *second.Values[1], *second.Deltas[1])
+	}
+	if second.Values[2] != nil || second.Deltas[2] != nil || second.Deltas[0] != nil {
+		t.Errorf("second row should have no svc-c value and no baseline delta: %+v", second)
+	}
+	if third := comparison.Rows[2]; third.Values[1] != nil || third.Deltas[1] != nil {
+		t.Errorf("gap bucket should have no value or delta: %+v", third)
+	}
+
+	if comparison.Columns[0].AvgDelta != nil {
+		t.Error("baseline should have no average delta")
+	}
+	if d := comparison.Columns[1].AvgDelta; d == nil || *d != -50 {
+		t.Errorf("svc-b average delta = %v, want -50", d)
+	}
+}
+
+func TestBuildMetricComparison_BaselineByLabels(t *testing.T) {
+	f := util.Ptr[float64]
+	series := func(role string, v float64) api.MetricSeries {
+		s := testMetricSeries(f(v))
+		s.Labels = map[string]string{"role": role}
+		return s
+	}
+
+	// Each of svc-b's series is compared against svc-a's series with the same
+	// role, and svc-c's standby has no counterpart.
+	comparison := buildMetricComparison("cpu", []string{"svc-a", "svc-b", "svc-c"}, [][]api.MetricSeries{
+		{series("primary", 100), series("replica", 10)},
+		{series("replica", 15), series("primary", 150)},
+		{series("standby", 1)},
+	})
+
+	want := map[string]struct {
+		baseline *int
+		delta    *float64
+	}{
+		`svc-a{role="primary"}`: {nil, nil},
+		`svc-a{role="replica"}`: {nil, nil},
+		`svc-b{role="primary"}`: {util.Ptr(0), f(50)},
+		`svc-b{role="replica"}`: {util.Ptr(1), f(5)},
+		`svc-c`:                 {nil, nil},
+	}
+	for i, col := range comparison.Columns {
+		w, ok := want[col.name]
+		if !ok {
+			t.Errorf("unexpected column %s", col.name)
+			continue
+		}
+		if (col.Baseline == nil) != (w.baseline == nil) || (col.Baseline != nil && *col.Baseline != *w.baseline) {
+			t.Errorf("%s baseline = %v, want %v", col.name, col.Baseline, w.baseline)
+		}
+		if got := comparison.Rows[0].Deltas[i]; (got == nil) != (w.delta == nil) || (got != nil && *got != *w.delta) {
+			t.Errorf("%s delta = %v, want %v", col.name, got, w.delta)
+		}
+	}
+}
+
+func TestServiceMetricsCompare_EmptyBaselineWarns(t *testing.T) {
+	// svc-a has no data in the window.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		series := []api.MetricSeries{}
+		if !strings.Contains(r.URL.Path, "svc-a") {
+			series = append(series, testMetricSeries(util.Ptr(100.0)))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(series)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+
+	cmd := buildServiceMetricsCompareCmd(newTestApp(t, client, "proj1"))
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"svc-a", "svc-b", "--metric", "cpu", "--from", "2026-05-13T00:00:00Z", "--to", "2026-05-13T01:00:00Z"})
+	if err := cmd.ExecuteContext(t.Context()); err != nil {
+		t.Fatalf("compare error = %v", err)
+	}
+	if !strings.Contains(stderr.String(), "baseline service svc-a returned no data") {
+		t.Errorf("stderr = %q, want a warning about the empty baseline", stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "100.000") {
+		t.Errorf("table should still show svc-b's data:\n%s", stdout.String())
+	}
+}
+
+func TestRenderMetricComparisonTable(t *testing.T) {
+	f := util.Ptr[float64]
+	comparison := buildMetricComparison("cpu", []string{"prod", "fork"}, [][]api.MetricSeries{
+		{testMetricSeries(f(100), f(200))},
+		{testMetricSeries(f(150), f(300))},
+	})
+
+	var buf bytes.Buffer
+	if err := renderMetricComparisonTable(&buf, comparison); err != nil {
+		t.Fatalf("renderMetricComparisonTable() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"Δ FORK", "+50.000 (+50.0%)", "+100.000 (+50.0%)", "+75.000 (+50.0%)", "AVG"} {
+		if !strings.Contains(strings.ToUpper(out), strings.ToUpper(want)) {
+			t.Errorf("table missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderMetricComparisonChart(t *testing.T) {
+	f := util.Ptr[float64]
+	comparison := buildMetricComparison("cpu", []string{"prod", "fork"}, [][]api.MetricSeries{
+		{testMetricSeries(f(0), f(100))},
+		{testMetricSeries(f(100), f(100))},
+	})
+
+	var buf bytes.Buffer
+	renderMetricComparisonChart(&buf, comparison, 40)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "cpu (scale 0 – 100)" {
+		t.Fatalf("chart =\n%s", buf.String())
+	}
+	// Both series share one scale, so fork is drawn at full height throughout.
+	if !strings.HasPrefix(lines[1], "prod  ▁") || !strings.HasPrefix(lines[2], "fork  ██") {
+		t.Errorf("chart =\n%s", buf.String())
+	}
+	if !strings.HasSuffix(lines[2], "avg 100 +50.000 (+100.0%)") {
+		t.Errorf("fork line should end with its average delta: %q", lines[2])
+	}
+}
+
+func TestRenderMetricComparisonChart_NoData(t *testing.T) {
+	comparison := buildMetricComparison("cpu", []string{"prod", "fork"}, [][]api.MetricSeries{
+		{testMetricSeries(nil, nil)},
+		{testMetricSeries(nil)},
+	})
+
+	var buf bytes.Buffer
+	renderMetricComparisonChart(&buf, comparison, 40)
+	if got := buf.String(); got != "No metric data returned for the requested window.\n" {
+		t.Errorf("chart =\n%s", got)
+	}
+}
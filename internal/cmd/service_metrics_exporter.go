@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+)
+
+// defaultExporterMetrics are exported when no --metric flag is given.
+var defaultExporterMetrics = []string{
+	common.MetricCPUUsage,
+	common.MetricMemoryUsage,
+	common.MetricDiskUsage,
+	common.MetricConnections,
+}
+
+// buildServiceMetricsExporterCmd serves the latest metric values for
+// Prometheus to scrape
+func buildServiceMetricsExporterCmd(app *common.App) *cobra.Command {
+	var listen string
+	var metrics []string
+	var interval time.Duration
+	var window time.Duration
+	var role string
+	var filters []string
+
+	cmd := &cobra.Command{
+		Use:   "exporter [service-id...]",
+		Short: "Serve service metrics for Prometheus to scrape",
+		Long: `Serve the latest values of service metrics in the Prometheus text exposition
+format at /metrics.
+
+The exporter polls the metrics API every --interval and serves the most recent
+data point of each labeled series within the last --window. Every sample gets a
+service_id label, so a single exporter can cover several services. If a poll
+fails, the values from the last successful poll keep being served.
+
+Defaults to the configured service when no service IDs are given.
+
+Examples:
+  # Export CPU, memory, storage and connection metrics for the default service
+  tiger service metrics exporter
+
+  # Export specific metrics for two services on a custom address
+  tiger service metrics exporter svc-12345 svc-67890 --listen 127.0.0.1:9200 \
+    --metric timescale_cloud_system_cpu_usage_millicores \
+    --metric timescale_cloud_system_memory_usage_bytes
+
+  # Matching Prometheus scrape config
+  scrape_configs:
+    - job_name: tiger
+      static_configs:
+        - targets: ["localhost:9187"]`,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < time.Minute {
+				return fmt.Errorf("--interval must be at least 1m (metric buckets are at least 60s)")
+			}
+			if window < interval {
+				return fmt.Errorf("--window must be at least as long as --interval")
+			}
+
+			labelFilters, err := parseMetricFilters(role, filters)
+			if err != nil {
+				return err
+			}
+
+			cfg, client, projectID, err := app.GetAll()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			serviceIDs := args
+			if len(serviceIDs) == 0 {
+				serviceID, err := getServiceID(cfg, args)
+				if err != nil {
+					return err
+				}
+				serviceIDs = []string{serviceID}
+			}
+
+			if len(metrics) == 0 {
+				metrics = defaultExporterMetrics
+			}
+
+			cmd.SilenceUsage = true
+
+			listener, err := net.Listen("tcp", listen)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", listen, err)
+			}
+
+			exporter := &metricsExporter{
+				client:     client,
+				projectID:  projectID,
+				serviceIDs: serviceIDs,
+				metrics:    metrics,
+				filters:    labelFilters,
+				window:     window,
+			}
+			cmd.PrintErrf("📈 Serving metrics for %d service(s) at http://%s/metrics (Ctrl+C to stop)\n", len(serviceIDs), listener.Addr())
+			return exporter.run(cmd.Context(), listener, interval, func(err error) {
+				cmd.PrintErrf("⚠️  Warning: %v\n", err)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&listen, "listen", ":9187", "Address to serve /metrics on")
+	cmd.Flags().StringSliceVar(&metrics, "metric", nil, "Metric series name to export (repeatable; defaults to CPU, memory, storage and connections)")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "How often to poll the metrics API")
+	cmd.Flags().DurationVar(&window, "window", 10*time.Minute, "How far back to look for the latest data point of each series")
+	cmd.Flags().StringVar(&role, "role", "", "Filter to a specific instance role (PRIMARY or REPLICA)")
+	cmd.Flags().StringSliceVar(&filters, "filter", nil, "Arbitrary label filter as name=value (repeatable)")
+
+	return cmd
+}
+
+// metricsExporter polls the metrics API and serves the latest value of each
+// series in the Prometheus text exposition format.
+type metricsExporter struct {
+	client     api.ClientWithResponsesInterface
+	projectID  string
+	serviceIDs []string
+	metrics    []string
+	filters    []api.MetricLabelFilter
+	window     time.Duration
+
+	mu      sync.RWMutex
+	samples map[exporterKey][]promSample
+}
+
+// exporterKey identifies the samples of one metric for one service, so a
+// failed poll of one pair doesn't discard the others.
+type exporterKey struct {
+	serviceID string
+	metric    string
+}
+
+// run serves /metrics on listener and polls every interval until ctx is
+// canceled. Poll failures are reported to warn rather than stopping the
+// exporter.
+func (e *metricsExporter) run(ctx context.Context, listener net.Listener, interval time.Duration, warn func(error)) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", e)
+	server := &http.Server{Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for _, err := range e.poll(ctx, time.Now()) {
+			warn(err)
+		}
+
+		select {
+		case err := <-errCh:
+			return fmt.Errorf("metrics server error: %w", err)
+		case <-ctx.Done():
+			if err := server.Shutdown(context.Background()); err != nil {
+				return fmt.Errorf("failed to shut down metrics server: %w", err)
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches every metric for every service for the window ending at now,
+// concurrently, and replaces the served samples of each pair that succeeded.
+func (e *metricsExporter) poll(ctx context.Context, now time.Time) []error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	results := make(map[exporterKey][]promSample)
+
+	for _, serviceID := range e.serviceIDs {
+		for _, metric := range e.metrics {
+			wg.Go(func() {
+				req := api.MetricsSeriesRequest{
+					Name: metric,
+					From: now.Add(-e.window),
+					To:   now,
+				}
+				if len(e.filters) > 0 {
+					req.Filters = &e.filters
+				}
+				series, err := common.FetchMetricSeries(ctx, e.client, e.projectID, serviceID, req)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs = append(errs, fmt.Errorf("failed to poll %s for service %s: %w", metric, serviceID, err))
+					return
+				}
+				results[exporterKey{serviceID, metric}] = latestPromSamples(metric, series, map[string]string{"service_id": serviceID})
+			})
+		}
+	}
+	wg.Wait()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.samples == nil {
+		e.samples = make(map[exporterKey][]promSample)
+	}
+	for key, samples := range results {
+		e.samples[key] = samples
+	}
+	return errs
+}
+
+// ServeHTTP writes the latest polled samples.
+func (e *metricsExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.RLock()
+	var samples []promSample
+	for _, serviceID := range e.serviceIDs {
+		for _, metric := range e.metrics {
+			samples = append(samples, e.samples[exporterKey{serviceID, metric}]...)
+		}
+	}
+	e.mu.RUnlock()
+
+	var buf bytes.Buffer
+	if err := writePromSamples(&buf, samples); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
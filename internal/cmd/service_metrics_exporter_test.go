@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// metricsStubClient builds an API client backed by a stub metrics series
+// endpoint. Each response carries a primary series whose latest value is the
+// request count so far, followed by a gap. While failing is set, requests
+// return a 500.
+func metricsStubClient(t *testing.T, failing *atomic.Bool) *api.ClientWithResponses {
+	t.Helper()
+
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/metrics/series") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "boom"})
+			return
+		}
+
+		var req api.MetricsSeriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n := float64(requests.Add(1))
+		_ = json.NewEncoder(w).Encode([]api.MetricSeries{{
+			Labels: map[string]string{"role": "primary"},
+			Data: []api.MetricDataPoint{
+				{Time: req.To.Add(-2 * time.Minute), Value: util.Ptr(n)},
+				{Time: req.To.Add(-time.Minute)},
+			},
+		}})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+	return client
+}
+
+func scrapeExporter(t *testing.T, e *metricsExporter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics status = %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("GET /metrics Content-Type = %q", ct)
+	}
+	return rec.Body.String()
+}
+
+func TestMetricsExporter_Poll(t *testing.T) {
+	var failing atomic.Bool
+	e := &metricsExporter{
+		client:     metricsStubClient(t, &failing),
+		projectID:  "proj1",
+		serviceIDs: []string{"svc-a", "svc-b"},
+		metrics:    []string{"cpu"},
+		window:     10 * time.Minute,
+	}
+	now := time.Date(2026, 5, 13, 10, 0, 0, 0, time.UTC)
+
+	if body := scrapeExporter(t, e); body != "" {
+		t.Errorf("scrape before the first poll = %q, want empty", body)
+	}
+
+	if errs := e.poll(t.Context(), now); len(errs) != 0 {
+		t.Fatalf("poll() errors = %v", errs)
+	}
+	body := scrapeExporter(t, e)
+	if !strings.HasPrefix(body, "# TYPE cpu gauge\n") || strings.Count(body, "# TYPE") != 1 {
+		t.Errorf("scrape should start with a single TYPE line:\n%s", body)
+	}
+	for _, svc := range []string{"svc-a", "svc-b"} {
+		if !strings.Contains(body, `cpu{role="primary",service_id="`+svc+`"} `) {
+			t.Errorf("scrape missing sample for %s:\n%s", svc, body)
+		}
+	}
+	if strings.Count(body, "\n") != 3 {
+		t.Errorf("scrape should have one sample per service (gaps skipped):\n%s", body)
+	}
+
+	// A failed poll reports errors and keeps serving the previous values.
+	failing.Store(true)
+	if errs := e.poll(t.Context(), now.Add(time.Minute)); len(errs) != 2 {
+		t.Errorf("failed poll() errors = %v, want 2", errs)
+	}
+	if got := scrapeExporter(t, e); got != body {
+		t.Errorf("scrape after a failed poll =\n%s\nwant previous values\n%s", got, body)
+	}
+
+	failing.Store(false)
+	if errs := e.poll(t.Context(), now.Add(2*time.Minute)); len(errs) != 0 {
+		t.Fatalf("poll() errors = %v", errs)
+	}
+	if got := scrapeExporter(t, e); got == body {
+		t.Errorf("scrape after a new poll should serve new values:\n%s", got)
+	}
+}
+
+func TestServiceMetricsExporter_Validation(t *testing.T) {
+	app := newTestApp(t, nil, "proj1")
+
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{[]string{"--interval", "10s"}, "--interval must be at least 1m"},
+		{[]string{"--interval", "5m", "--window", "1m"}, "--window must be at least as long as --interval"},
+		{[]string{"--filter", "bogus"}, "--filter must be name=value"},
+	}
+	for _, tt := range tests {
+		cmd := buildServiceMetricsExporterCmd(app)
+		cmd.SetArgs(tt.args)
+		cmd.SetOut(new(strings.Builder))
+		cmd.SetErr(new(strings.Builder))
+		err := cmd.ExecuteContext(t.Context())
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("args %v: error = %v, want %q", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMetricsExporter_Run(t *testing.T) {
+	var failing atomic.Bool
+	e := &metricsExporter{
+		client:     metricsStubClient(t, &failing),
+		projectID:  "proj1",
+		serviceIDs: []string{"svc-a"},
+		metrics:    []string{"cpu"},
+		window:     10 * time.Minute,
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- e.run(ctx, listener, time.Hour, func(err error) { t.Errorf("unexpected poll error: %v", err) })
+	}()
+
+	// The first poll happens right away; wait for it to be served.
+	url := "http://" + listener.Addr().String() + "/metrics"
+	var body string
+	for range 50 {
+		resp, err := http.Get(url)
+		if err == nil {
+			data, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if body = string(data); body != "" {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if !strings.Contains(body, `cpu{role="primary",service_id="svc-a"} 1`) {
+		t.Errorf("GET /metrics =\n%s", body)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("run() error = %v", err)
+	}
+}
@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"sort"
 	"strings"
 	"time"
@@ -25,6 +24,8 @@ func buildServiceMetricsSeriesCmd(app *common.App) *cobra.Command {
 	var filters []string
 	var bucketSeconds int
 	var fn string
+	var chart bool
+	var chartStyle string
 
 	cmd := &cobra.Command{
 		Use:   "series [service-id]",
@@ -52,7 +53,23 @@ Examples:
   # Filter by an arbitrary label
   tiger service metrics series --metric some_metric_name \
     --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z \
-    --filter ordinal=0`,
+    --filter ordinal=0
+
+  # Chart CPU usage in the terminal, one braille line chart per series
+  tiger service metrics series --metric timescale_cloud_system_cpu_usage_millicores \
+    --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z --chart
+
+  # Compact sparklines instead of line charts
+  tiger service metrics series --metric timescale_cloud_system_memory_usage_bytes \
+    --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z --chart --chart-style sparkline
+
+  # Export data points as CSV, one label per column
+  tiger service metrics series --metric timescale_cloud_system_cpu_usage_millicores \
+    --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z -o csv > cpu.csv
+
+  # Export data points in the Prometheus text exposition format, with timestamps
+  tiger service metrics series --metric timescale_cloud_system_cpu_usage_millicores \
+    --from 2026-05-13T00:00:00Z --to 2026-05-13T01:00:00Z -o prom`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fromTime, err := time.Parse(time.RFC3339, from)
@@ -64,6 +81,15 @@ Examples:
 				return fmt.Errorf("--to must be RFC3339 (e.g., 2026-05-13T01:00:00Z): %w", err)
 			}
 
+			if chart {
+				if cmd.Flags().Changed("output") {
+					return fmt.Errorf("--chart cannot be combined with --output")
+				}
+				if chartStyle != chartStyleLine && chartStyle != chartStyleSparkline {
+					return fmt.Errorf("invalid --chart-style: %s (must be %s or %s)", chartStyle, chartStyleLine, chartStyleSparkline)
+				}
+			}
+
 			labelFilters, err := parseMetricFilters(role, filters)
 			if err != nil {
 				return err
@@ -102,20 +128,22 @@ Examples:
 			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
 			defer cancel()
 
-			resp, err := client.GetServiceMetricsSeriesWithResponse(ctx, projectID, serviceID, body)
+			series, err := common.FetchMetricSeries(ctx, client, projectID, serviceID, body)
 			if err != nil {
-				return fmt.Errorf("failed to fetch metric series: %w", err)
-			}
-
-			if resp.StatusCode() != http.StatusOK {
-				return common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+				return err
 			}
 
-			if resp.JSON200 == nil {
-				return fmt.Errorf("empty response from API")
+			if chart {
+				out := cmd.OutOrStdout()
+				if len(series) == 0 {
+					cmd.Println("No metric data returned for the requested window.")
+					return nil
+				}
+				renderMetricSeriesCharts(out, series, chartStyle, chartWidth(out))
+				return nil
 			}
 
-			return renderMetricSeries(cmd, cfg.Output, *resp.JSON200)
+			return renderMetricSeries(cmd, cfg.Output, serviceID, metric, series)
 		},
 	}
 
@@ -126,7 +154,9 @@ Examples:
 	cmd.Flags().StringSliceVar(&filters, "filter", nil, "Arbitrary label filter as name=value (repeatable)")
 	cmd.Flags().IntVar(&bucketSeconds, "bucket-seconds", 0, "Aggregation bucket size in seconds (optional; server auto-selects based on the time window when omitted, minimum 60s)")
 	cmd.Flags().StringVar(&fn, "fn", "", "Aggregation function applied per bucket. One of: RATE, INCREASE, SUM, AVG, MIN, MAX, COUNT, P50, P90, P99, LAST. Rejected on the timescale_cloud_* resource/qps/connections/jobs metrics; omit to let the server pick the default")
-	cmd.Flags().VarP(new(outputFlag), "output", "o", "Output format (json, yaml, table)")
+	cmd.Flags().VarP(new(outputWithMetricsFlag), "output", "o", "Output format (json, yaml, csv, prom, table)")
+	cmd.Flags().BoolVar(&chart, "chart", false, "Draw a terminal chart per series with min/avg/p95/max annotations instead of a table")
+	cmd.Flags().StringVar(&chartStyle, "chart-style", chartStyleLine, "Chart style for --chart (line, sparkline)")
 
 	cmd.MarkFlagRequired("metric")
 	cmd.MarkFlagRequired("from")
@@ -135,7 +165,7 @@ Examples:
 	return cmd
 }
 
-// parseMetricFilters merges --role and --filter into the preview label filter
+// parseMetricFilters merges --role and --filter into the label filter
 // list. Server-side label values are lowercased on response, so we lowercase
 // role values here too for symmetry.
 func parseMetricFilters(role string, filters []string) ([]api.MetricLabelFilter, error) {
@@ -171,7 +201,7 @@ func labelString(labels map[string]string) string {
 	return "{" + strings.Join(parts, ",") + "}"
 }
 
-func renderMetricSeries(cmd *cobra.Command, output string, series []api.MetricSeries) error {
+func renderMetricSeries(cmd *cobra.Command, output, serviceID, metric string, series []api.MetricSeries) error {
 	out := cmd.OutOrStdout()
 
 	switch strings.ToLower(output) {
@@ -179,6 +209,10 @@ func renderMetricSeries(cmd *cobra.Command, output string, series []api.MetricSe
 		return util.SerializeToJSON(out, series)
 	case "yaml":
 		return util.SerializeToYAML(out, series)
+	case metricsOutputCSV:
+		return writeMetricSeriesCSV(out, metric, series)
+	case metricsOutputProm:
+		return writePromSamples(out, promSamplesFromSeries(metric, series, map[string]string{"service_id": serviceID}))
 	default:
 		if len(series) == 0 {
 			cmd.Println("No metric data returned for the requested window.")
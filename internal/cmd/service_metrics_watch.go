@@ -0,0 +1,641 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// metricTotals maps usage metrics to the allocation metric that percentage
+// thresholds are measured against.
+var metricTotals = map[string]string{
+	common.MetricCPUUsage:    common.MetricCPUTotal,
+	common.MetricMemoryUsage: common.MetricMemoryTotal,
+}
+
+// Alert statuses reported to --exec and --webhook.
+const (
+	alertFiring   = "firing"
+	alertResolved = "resolved"
+)
+
+// buildServiceMetricsWatchCmd polls a metric and notifies when it crosses a
+// threshold
+func buildServiceMetricsWatchCmd(app *common.App) *cobra.Command {
+	var metric string
+	var above string
+	var below string
+	var clearAt string
+	var sustain time.Duration
+	var interval time.Duration
+	var totalMetric string
+	var role string
+	var filters []string
+	var execCmd string
+	var webhook string
+	var once bool
+	var stateFile string
+
+	cmd := &cobra.Command{
+		Use:   "watch [service-id]",
+		Short: "Alert when a metric crosses a threshold",
+		Long: `Poll a metric and notify when it crosses a threshold.
+
+An alert fires once every data point of a series over the last --for is past
+the threshold, and resolves once every data point over the last --for is back
+past the clear level. The clear level defaults to 5% inside the threshold, so
+a value hovering right at the threshold doesn't flap. Each labeled series (e.g.
+each replica) is alerted on separately.
+
+Thresholds are plain numbers in the metric's unit, optionally with a k, M, G or
+T suffix, or percentages (e.g. 80%). Percentages are measured against the
+matching allocation metric, which is known for CPU and memory usage; use
+--total-metric for others.
+
+On firing and resolving, the alert is printed and, if configured:
+  --exec     runs the command through the shell with the alert as JSON on
+             stdin and in TIGER_ALERT_* environment variables
+  --webhook  POSTs the alert as JSON to the URL
+A failed notification is retried by the next check, for the notifiers that
+failed only.
+
+Use --once with --state-file to evaluate once per invocation, e.g. from cron.
+
+Examples:
+  # Alert when CPU stays above 80% of the allocation for 5 minutes
+  tiger service metrics watch --metric timescale_cloud_system_cpu_usage_millicores \
+    --above 80% --for 5m --exec ./notify.sh
+
+  # Post to a webhook when storage exceeds 400 GB
+  tiger service metrics watch --metric timescale_cloud_system_disk_usage_bytes \
+    --above 400G --webhook https://hooks.example.com/tiger
+
+  # Evaluate once from cron, remembering which alerts are firing between runs
+  tiger service metrics watch --metric timescale_cloud_system_memory_usage_bytes \
+    --above 90% --once --state-file ~/.tiger-memory-alert.json --exec ./notify.sh`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: serviceIDCompletion(app),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rule, err := parseAlertRule(metric, above, below, clearAt, sustain)
+			if err != nil {
+				return err
+			}
+			if rule.percent {
+				if totalMetric == "" {
+					totalMetric = metricTotals[metric]
+				}
+				if totalMetric == "" {
+					return fmt.Errorf("percentage thresholds for %s require --total-metric", metric)
+				}
+			}
+			if interval < time.Minute {
+				return fmt.Errorf("--interval must be at least 1m (metric buckets are at least 60s)")
+			}
+			if stateFile != "" && !once {
+				return fmt.Errorf("--state-file can only be used with --once")
+			}
+
+			labelFilters, err := parseMetricFilters(role, filters)
+			if err != nil {
+				return err
+			}
+
+			cfg, client, projectID, err := app.GetAll()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			serviceID, err := getServiceID(cfg, args)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			w := &metricWatcher{
+				client:      client,
+				projectID:   projectID,
+				serviceID:   serviceID,
+				rule:        rule,
+				totalMetric: totalMetric,
+				filters:     labelFilters,
+				firing:      make(map[string]bool),
+				pending:     make(map[string]pendingAlert),
+				output:      cmd.ErrOrStderr(),
+				notifiers:   alertNotifiers(execCmd, webhook, cmd.ErrOrStderr()),
+			}
+
+			if once {
+				if stateFile != "" {
+					if w.firing, w.pending, err = loadAlertState(stateFile); err != nil {
+						return err
+					}
+				}
+				// Save the state even if the check failed, so the next run
+				// retries only the notifications that failed
+				err := w.check(cmd.Context(), time.Now())
+				if stateFile != "" {
+					err = errors.Join(err, saveAlertState(stateFile, w.firing, w.pending))
+				}
+				return err
+			}
+
+			cmd.PrintErrf("👀 Watching %s on service %s (%s), checking every %s. Press Ctrl+C to stop.\n", metric, serviceID, rule, interval)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				if err := w.check(cmd.Context(), time.Now()); err != nil {
+					if cmd.Context().Err() != nil {
+						return nil
+					}
+					cmd.PrintErrf("⚠️  Warning: %v\n", err)
+				}
+				select {
+				case <-cmd.Context().Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&metric, "metric", "", "Metric series name")
+	cmd.Flags().StringVar(&above, "above", "", "Fire when the metric is above this value (e.g. 80%, 400G)")
+	cmd.Flags().StringVar(&below, "below", "", "Fire when the metric is below this value")
+	cmd.Flags().StringVar(&clearAt, "clear", "", "Resolve once the metric is back past this value (defaults to 5% inside the threshold)")
+	cmd.Flags().DurationVar(&sustain, "for", 5*time.Minute, "How long the threshold must be crossed (or cleared) before firing (or resolving)")
+	cmd.Flags().DurationVar(&interval, "interval", time.Minute, "How often to poll the metrics API")
+	cmd.Flags().StringVar(&totalMetric, "total-metric", "", "Metric that percentage thresholds are measured against")
+	cmd.Flags().StringVar(&role, "role", "", "Filter to a specific instance role (PRIMARY or REPLICA)")
+	cmd.Flags().StringSliceVar(&filters, "filter", nil, "Arbitrary label filter as name=value (repeatable)")
+	cmd.Flags().StringVar(&execCmd, "exec", "", "Command to run when an alert fires or resolves")
+	cmd.Flags().StringVar(&webhook, "webhook", "", "URL to POST a JSON alert to when an alert fires or resolves")
+	cmd.Flags().BoolVar(&once, "once", false, "Evaluate once and exit instead of polling")
+	cmd.Flags().StringVar(&stateFile, "state-file", "", "File that keeps firing alerts between --once runs")
+
+	cmd.MarkFlagRequired("metric")
+	cmd.MarkFlagsMutuallyExclusive("above", "below")
+	cmd.MarkFlagsOneRequired("above", "below")
+
+	return cmd
+}
+
+// alertRule is a parsed threshold condition.
+type alertRule struct {
+	metric    string
+	above     bool // fire above the threshold; otherwise below
+	threshold float64
+	clear     float64
+	percent   bool // threshold and clear are percentages of the total metric
+	sustain   time.Duration
+}
+
+func (r alertRule) String() string {
+	op, unit := "below", ""
+	if r.above {
+		op = "above"
+	}
+	if r.percent {
+		unit = "%"
+	}
+	return fmt.Sprintf("%s %s%s for %s", op, formatMetricValue(r.threshold), unit, r.sustain)
+}
+
+// parseAlertRule validates the threshold flags. Exactly one of above and below
+// is set (enforced by cobra).
+func parseAlertRule(metric, above, below, clearAt string, sustain time.Duration) (alertRule, error) {
+	rule := alertRule{metric: metric, above: above != "", sustain: sustain}
+	if sustain < time.Minute {
+		return rule, fmt.Errorf("--for must be at least 1m (metric buckets are at least 60s)")
+	}
+
+	flag, value := "--below", below
+	if rule.above {
+		flag, value = "--above", above
+	}
+	var err error
+	if rule.threshold, rule.percent, err = parseThreshold(value); err != nil {
+		return rule, fmt.Errorf("invalid %s: %w", flag, err)
+	}
+
+	if clearAt == "" {
+		rule.clear = rule.threshold * 1.05
+		if rule.above {
+			rule.clear = rule.threshold * 0.95
+		}
+		return rule, nil
+	}
+	clearPercent := false
+	if rule.clear, clearPercent, err = parseThreshold(clearAt); err != nil {
+		return rule, fmt.Errorf("invalid --clear: %w", err)
+	}
+	if clearPercent != rule.percent {
+		return rule, fmt.Errorf("--clear and %s must both be percentages or both be absolute values", flag)
+	}
+	if (rule.above && rule.clear > rule.threshold) || (!rule.above && rule.clear < rule.threshold) {
+		return rule, fmt.Errorf("--clear must not be past the %s threshold", flag)
+	}
+	return rule, nil
+}
+
+// parseThreshold parses a number with an optional SI suffix (k, M, G, T) or a
+// percentage.
+func parseThreshold(s string) (value float64, percent bool, err error) {
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(s, "%"):
+		percent = true
+		s = strings.TrimSuffix(s, "%")
+	case strings.HasSuffix(s, "k"):
+		multiplier, s = 1e3, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "M"):
+		multiplier, s = 1e6, strings.TrimSuffix(s, "M")
+	case strings.HasSuffix(s, "G"):
+		multiplier, s = 1e9, strings.TrimSuffix(s, "G")
+	case strings.HasSuffix(s, "T"):
+		multiplier, s = 1e12, strings.TrimSuffix(s, "T")
+	}
+	value, err = strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("expected a number, optionally with a k/M/G/T suffix or a %% sign")
+	}
+	return value * multiplier, percent, nil
+}
+
+// breached reports whether v is past the firing threshold.
+func (r alertRule) breached(v float64) bool {
+	if r.above {
+		return v > r.threshold
+	}
+	return v < r.threshold
+}
+
+// cleared reports whether v is back past the clear level.
+func (r alertRule) cleared(v float64) bool {
+	if r.above {
+		return v <= r.clear
+	}
+	return v >= r.clear
+}
+
+// sustained reports whether every non-null point in the last r.sustain of
+// data (ending at the latest non-null point) satisfies cond, along with that
+// latest value. It's false when the data doesn't yet span r.sustain.
+func (r alertRule) sustained(points []api.MetricDataPoint, cond func(float64) bool) (bool, float64) {
+	last := -1
+	for i := len(points) - 1; i >= 0; i-- {
+		if points[i].Value != nil {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return false, 0
+	}
+
+	start := points[last].Time.Add(-r.sustain)
+	if points[0].Time.After(start) {
+		return false, *points[last].Value
+	}
+	for _, p := range points[:last+1] {
+		if p.Value != nil && !p.Time.Before(start) && !cond(*p.Value) {
+			return false, *points[last].Value
+		}
+	}
+	return true, *points[last].Value
+}
+
+// alertEvent is the JSON document sent to --exec and --webhook.
+type alertEvent struct {
+	Status    string            `json:"status"`
+	ServiceID string            `json:"service_id"`
+	Metric    string            `json:"metric"`
+	Labels    map[string]string `json:"labels"`
+	Condition string            `json:"condition"`
+	Value     float64           `json:"value"`
+	Threshold float64           `json:"threshold"`
+	Unit      string            `json:"unit,omitempty"`
+	Time      time.Time         `json:"time"`
+}
+
+// alertNotifier delivers an alert event.
+type alertNotifier func(ctx context.Context, event alertEvent) error
+
+// pendingAlert is an alert event that some notifiers failed to deliver.
+type pendingAlert struct {
+	Event alertEvent `json:"event"`
+	// Notifiers are the names of the notifiers still to deliver it.
+	Notifiers []string `json:"notifiers"`
+}
+
+// metricWatcher evaluates an alert rule against one service's series and
+// tracks which series are firing.
+type metricWatcher struct {
+	client      api.ClientWithResponsesInterface
+	projectID   string
+	serviceID   string
+	rule        alertRule
+	totalMetric string
+	filters     []api.MetricLabelFilter
+
+	// firing and pending are keyed by the series' label string.
+	firing  map[string]bool
+	pending map[string]pendingAlert
+	output  io.Writer
+	// notifiers are keyed by name ("exec" or "webhook"), which is how
+	// pending alerts refer to them.
+	notifiers map[string]alertNotifier
+}
+
+// check retries the notifications that failed on earlier checks, then fetches
+// the data needed to evaluate the rule at now and notifies on every firing or
+// resolving series.
+func (w *metricWatcher) check(ctx context.Context, now time.Time) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(w.pending)) {
+		p := w.pending[key]
+		if err := w.deliver(ctx, key, p.Event, p.Notifiers); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	series, err := w.fetch(ctx, w.rule.metric, now)
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	if w.rule.percent {
+		totals, err := w.fetch(ctx, w.totalMetric, now)
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		series = percentOfTotal(series, totals)
+	}
+
+	for _, s := range series {
+		key := labelString(s.Labels)
+		var transitioned bool
+		var value float64
+		if w.firing[key] {
+			transitioned, value = w.rule.sustained(s.Data, w.rule.cleared)
+		} else {
+			transitioned, value = w.rule.sustained(s.Data, w.rule.breached)
+		}
+		if !transitioned {
+			continue
+		}
+
+		status := alertFiring
+		if w.firing[key] {
+			status = alertResolved
+			delete(w.firing, key)
+		} else {
+			w.firing[key] = true
+		}
+		if err := w.notify(ctx, key, status, s.Labels, value, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *metricWatcher) fetch(ctx context.Context, metric string, now time.Time) ([]api.MetricSeries, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Look back a few extra minutes, since the most recent buckets are often
+	// still empty while data is ingested.
+	req := api.MetricsSeriesRequest{
+		Name: metric,
+		From: now.Add(-w.rule.sustain - 5*time.Minute),
+		To:   now,
+	}
+	if len(w.filters) > 0 {
+		req.Filters = &w.filters
+	}
+	return common.FetchMetricSeries(ctx, w.client, w.projectID, w.serviceID, req)
+}
+
+// notify reports a series firing or resolving and sends it to every notifier.
+func (w *metricWatcher) notify(ctx context.Context, key, status string, labels map[string]string, value float64, now time.Time) error {
+	event := alertEvent{
+		Status:    status,
+		ServiceID: w.serviceID,
+		Metric:    w.rule.metric,
+		Labels:    labels,
+		Condition: w.rule.String(),
+		Value:     value,
+		Threshold: w.rule.threshold,
+		Time:      now.UTC(),
+	}
+	if w.rule.percent {
+		event.Unit = "%"
+	}
+
+	icon := "🔥"
+	if status == alertResolved {
+		icon = "✅"
+	}
+	fmt.Fprintf(w.output, "%s %s: %s%s on service %s is %s%s (%s)\n", icon, strings.ToUpper(status),
+		w.rule.metric, labelString(labels), w.serviceID, formatMetricValue(value), event.Unit, event.Condition)
+
+	return w.deliver(ctx, key, event, slices.Sorted(maps.Keys(w.notifiers)))
+}
+
+// deliver sends the series' event to the named notifiers, recording the ones
+// that fail as pending so only they are retried by the next check. It replaces
+// any event still pending for the series, since a newer transition supersedes
+// it. Notifiers that are no longer configured are dropped.
+func (w *metricWatcher) deliver(ctx context.Context, key string, event alertEvent, names []string) error {
+	var failed []string
+	var errs []error
+	for _, name := range names {
+		notify, ok := w.notifiers[name]
+		if !ok {
+			continue
+		}
+		if err := notify(ctx, event); err != nil {
+			failed = append(failed, name)
+			errs = append(errs, err)
+		}
+	}
+	if len(failed) > 0 {
+		w.pending[key] = pendingAlert{Event: event, Notifiers: failed}
+	} else {
+		delete(w.pending, key)
+	}
+	return errors.Join(errs...)
+}
+
+// percentOfTotal converts usage series to percentages of the total series
+// with the same labels, matching data points by bucket time. Points without a
+// matching nonzero total become gaps.
+func percentOfTotal(usage, totals []api.MetricSeries) []api.MetricSeries {
+	totalsByLabels := make(map[string]map[time.Time]float64, len(totals))
+	for _, s := range totals {
+		byTime := make(map[time.Time]float64, len(s.Data))
+		for _, p := range s.Data {
+			if p.Value != nil {
+				byTime[p.Time] = *p.Value
+			}
+		}
+		totalsByLabels[labelString(s.Labels)] = byTime
+	}
+
+	out := make([]api.MetricSeries, len(usage))
+	for i, s := range usage {
+		byTime := totalsByLabels[labelString(s.Labels)]
+		data := make([]api.MetricDataPoint, len(s.Data))
+		for j, p := range s.Data {
+			data[j] = api.MetricDataPoint{Time: p.Time}
+			if total := byTime[p.Time]; p.Value != nil && total != 0 {
+				data[j].Value = util.Ptr(*p.Value / total * 100)
+			}
+		}
+		out[i] = api.MetricSeries{Labels: s.Labels, Data: data}
+	}
+	return out
+}
+
+func alertNotifiers(execCmd, webhook string, output io.Writer) map[string]alertNotifier {
+	notifiers := make(map[string]alertNotifier)
+	if execCmd != "" {
+		notifiers["exec"] = execAlertNotifier(execCmd, output)
+	}
+	if webhook != "" {
+		notifiers["webhook"] = webhookAlertNotifier(http.DefaultClient, webhook)
+	}
+	return notifiers
+}
+
+// execAlertNotifier runs command through the shell with the event as JSON on
+// stdin and its fields in TIGER_ALERT_* environment variables. The command's
+// output goes to output.
+func execAlertNotifier(command string, output io.Writer) alertNotifier {
+	return func(ctx context.Context, event alertEvent) error {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode alert: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		var c *exec.Cmd
+		if runtime.GOOS == "windows" {
+			c = exec.CommandContext(ctx, "cmd", "/C", command)
+		} else {
+			c = exec.CommandContext(ctx, "sh", "-c", command)
+		}
+		c.Stdin = bytes.NewReader(body)
+		c.Stdout = output
+		c.Stderr = output
+		c.Env = append(os.Environ(),
+			"TIGER_ALERT_STATUS="+event.Status,
+			"TIGER_ALERT_SERVICE_ID="+event.ServiceID,
+			"TIGER_ALERT_METRIC="+event.Metric,
+			"TIGER_ALERT_LABELS="+labelString(event.Labels),
+			"TIGER_ALERT_CONDITION="+event.Condition,
+			"TIGER_ALERT_VALUE="+strconv.FormatFloat(event.Value, 'f', -1, 64),
+			"TIGER_ALERT_THRESHOLD="+strconv.FormatFloat(event.Threshold, 'f', -1, 64),
+			"TIGER_ALERT_UNIT="+event.Unit,
+		)
+		if err := c.Run(); err != nil {
+			return fmt.Errorf("alert command failed: %w", err)
+		}
+		return nil
+	}
+}
+
+// webhookAlertNotifier POSTs the event as JSON to url.
+func webhookAlertNotifier(client *http.Client, url string) alertNotifier {
+	return func(ctx context.Context, event alertEvent) error {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode alert: %w", err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to create webhook request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to post alert webhook: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("alert webhook returned status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// alertState is the state file kept between --once runs.
+type alertState struct {
+	Firing  []string       `json:"firing"`
+	Pending []pendingAlert `json:"pending,omitempty"`
+}
+
+// loadAlertState reads the firing series and pending notifications saved by a
+// previous --once run. A missing file means nothing is firing.
+func loadAlertState(path string) (map[string]bool, map[string]pendingAlert, error) {
+	firing := make(map[string]bool)
+	pending := make(map[string]pendingAlert)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return firing, pending, nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state alertState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	for _, key := range state.Firing {
+		firing[key] = true
+	}
+	for _, p := range state.Pending {
+		pending[labelString(p.Event.Labels)] = p
+	}
+	return firing, pending, nil
+}
+
+func saveAlertState(path string, firing map[string]bool, pending map[string]pendingAlert) error {
+	state := alertState{Firing: slices.Sorted(maps.Keys(firing))}
+	for _, key := range slices.Sorted(maps.Keys(pending)) {
+		state.Pending = append(state.Pending, pending[key])
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func TestParseAlertRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		above    string
+		below    string
+		clear    string
+		sustain  time.Duration
+		wantRule alertRule
+		wantErr  string
+	}{
+		{
+			name:     "percentage with default clear",
+			above:    "80%",
+			sustain:  5 * time.Minute,
+			wantRule: alertRule{above: true, threshold: 80, clear: 76, percent: true, sustain: 5 * time.Minute},
+		},
+		{
+			name:     "SI suffix with explicit clear",
+			above:    "400G",
+			clear:    "350G",
+			sustain:  time.Minute,
+			wantRule: alertRule{above: true, threshold: 400e9, clear: 350e9, sustain: time.Minute},
+		},
+		{
+			name:     "below",
+			below:    "10",
+			sustain:  time.Minute,
+			wantRule: alertRule{threshold: 10, clear: 10.5, sustain: time.Minute},
+		},
+		{name: "short duration", above: "1", sustain: 30 * time.Second, wantErr: "--for must be at least 1m"},
+		{name: "bad number", above: "lots", sustain: time.Minute, wantErr: "invalid --above"},
+		{name: "mixed units", above: "80%", clear: "70", sustain: time.Minute, wantErr: "both be percentages"},
+		{name: "clear past threshold", below: "10", clear: "5", sustain: time.Minute, wantErr: "--clear must not be past"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseAlertRule("m", tt.above, tt.below, tt.clear, tt.sustain)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseAlertRule() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAlertRule() error = %v", err)
+			}
+			tt.wantRule.metric = "m"
+			if rule != tt.wantRule {
+				t.Errorf("parseAlertRule() = %+v, want %+v", rule, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestAlertRuleSustained(t *testing.T) {
+	rule := alertRule{above: true, threshold: 80, clear: 76, sustain: 2 * time.Minute}
+	f := util.Ptr[float64]
+
+	tests := []struct {
+		name   string
+		values []*float64
+		want   bool
+	}{
+		{"breached for the whole duration", []*float64{f(10), f(81), f(90), f(85)}, true},
+		{"trailing gaps are ignored", []*float64{f(81), f(90), f(85), nil}, true},
+		{"dip inside the duration", []*float64{f(90), f(79), f(90), f(85)}, false},
+		{"not enough data yet", []*float64{f(90), f(85)}, false},
+		{"no data", []*float64{nil, nil}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := rule.sustained(testMetricSeries(tt.values...).Data, rule.breached); got != tt.want {
+				t.Errorf("sustained() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// watchStubClient serves the values in *cpu as CPU usage and a constant 2000m
+// CPU total, as one-minute buckets ending at the request's end time.
+func watchStubClient(t *testing.T, cpu *[]float64) *api.ClientWithResponses {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.MetricsSeriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		values := *cpu
+		if req.Name == common.MetricCPUTotal {
+			values = []float64{2000, 2000, 2000, 2000, 2000, 2000}
+		}
+		series := api.MetricSeries{Labels: map[string]string{"role": "primary"}}
+		for i, v := range values {
+			ts := req.To.Truncate(time.Minute).Add(time.Duration(i-len(values)+1) * time.Minute)
+			series.Data = append(series.Data, api.MetricDataPoint{Time: ts, Value: util.Ptr(v)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]api.MetricSeries{series})
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+	return client
+}
+
+func TestMetricWatcher_FireAndResolve(t *testing.T) {
+	var events []alertEvent
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event alertEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("webhook got invalid JSON: %v", err)
+		}
+		events = append(events, event)
+	}))
+	defer hook.Close()
+
+	var cpu []float64
+	var out bytes.Buffer
+	w := &metricWatcher{
+		client:      watchStubClient(t, &cpu),
+		projectID:   "proj1",
+		serviceID:   "svc-12345",
+		rule:        alertRule{metric: common.MetricCPUUsage, above: true, threshold: 80, clear: 76, percent: true, sustain: 2 * time.Minute},
+		totalMetric: common.MetricCPUTotal,
+		firing:      make(map[string]bool),
+		pending:     make(map[string]pendingAlert),
+		output:      &out,
+		notifiers:   map[string]alertNotifier{"webhook": webhookAlertNotifier(hook.Client(), hook.URL)},
+	}
+	now := time.Date(2026, 5, 13, 10, 0, 30, 0, time.UTC)
+
+	steps := []struct {
+		cpu        []float64
+		wantEvents int
+	}{
+		{[]float64{1000, 1700, 1700}, 0},       // 50%, then 85%: not sustained yet
+		{[]float64{1700, 1700, 1700}, 1},       // 85% for 2m: fires
+		{[]float64{1700, 1700, 1540}, 1},       // 77%: inside the hysteresis band
+		{[]float64{1500, 1500, 1500, 1500}, 2}, // 75% for 2m: resolves
+		{[]float64{1500, 1500, 1500, 1500}, 2}, // stays resolved
+	}
+	for i, step := range steps {
+		cpu = step.cpu
+		if err := w.check(t.Context(), now.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("step %d: check() error = %v", i, err)
+		}
+		if len(events) != step.wantEvents {
+			t.Fatalf("step %d: got %d events, want %d: %+v", i, len(events), step.wantEvents, events)
+		}
+	}
+
+	fired, resolved := events[0], events[1]
+	if fired.Status != alertFiring || fired.Value != 85 || fired.Unit != "%" || fired.Labels["role"] != "primary" || fired.ServiceID != "svc-12345" {
+		t.Errorf("firing event = %+v", fired)
+	}
+	if resolved.Status != alertResolved || resolved.Value != 75 {
+		t.Errorf("resolved event = %+v", resolved)
+	}
+	if !strings.Contains(out.String(), "🔥 FIRING") || !strings.Contains(out.String(), "✅ RESOLVED") {
+		t.Errorf("output =\n%s", out.String())
+	}
+}
+
+func TestExecAlertNotifier(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "notify.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat > \"$1\"\necho \"$TIGER_ALERT_STATUS $TIGER_ALERT_VALUE\"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stdinFile := filepath.Join(dir, "stdin.json")
+
+	var out bytes.Buffer
+	notify := execAlertNotifier(script+" "+stdinFile, &out)
+	if err := notify(t.Context(), alertEvent{Status: alertFiring, Metric: "m", Value: 85.5}); err != nil {
+		t.Fatalf("notify() error = %v", err)
+	}
+
+	if got := out.String(); got != "firing 85.5\n" {
+		t.Errorf("script output = %q", got)
+	}
+	data, err := os.ReadFile(stdinFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var event alertEvent
+	if err := json.Unmarshal(data, &event); err != nil || event.Metric != "m" {
+		t.Errorf("script stdin = %s (err %v)", data, err)
+	}
+
+	if err := execAlertNotifier("exit 3", &out)(t.Context(), alertEvent{}); err == nil {
+		t.Error("expected an error for a failing command")
+	}
+}
+
+func TestAlertState_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	firing, pending, err := loadAlertState(path)
+	if err != nil || len(firing) != 0 || len(pending) != 0 {
+		t.Fatalf("loadAlertState() of a missing file = %v, %v, %v", firing, pending, err)
+	}
+
+	replica := pendingAlert{
+		Event:     alertEvent{Status: alertFiring, Labels: map[string]string{"role": "replica"}, Value: 85},
+		Notifiers: []string{"webhook"},
+	}
+	if err := saveAlertState(path, map[string]bool{`{role="primary"}`: true}, map[string]pendingAlert{`{role="replica"}`: replica}); err != nil {
+		t.Fatal(err)
+	}
+	firing, pending, err = loadAlertState(path)
+	if err != nil || !firing[`{role="primary"}`] || len(firing) != 1 {
+		t.Errorf("loadAlertState() firing = %v, %v", firing, err)
+	}
+	if got := pending[`{role="replica"}`]; len(pending) != 1 || got.Event.Value != 85 || !slices.Equal(got.Notifiers, replica.Notifiers) {
+		t.Errorf("loadAlertState() pending = %+v", pending)
+	}
+}
+
+func TestServiceMetricsWatch_OnceRetriesFailedNotifications(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test command requires a POSIX shell")
+	}
+
+	// Both instances are at 85% CPU.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req api.MetricsSeriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		value := 1700.0
+		if req.Name == common.MetricCPUTotal {
+			value = 2000
+		}
+		var series []api.MetricSeries
+		for _, role := range []string{"primary", "replica"} {
+			s := api.MetricSeries{Labels: map[string]string{"role": role}}
+			for i := range 3 {
+				ts := req.To.Truncate(time.Minute).Add(time.Duration(i-2) * time.Minute)
+				s.Data = append(s.Data, api.MetricDataPoint{Time: ts, Value: util.Ptr(value)})
+			}
+			series = append(series, s)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(series)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+
+	// The webhook rejects the replica's alert until replicaOK is set.
+	var events []alertEvent
+	replicaOK := false
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event alertEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("webhook got invalid JSON: %v", err)
+		}
+		if event.Labels["role"] == "replica" && !replicaOK {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		events = append(events, event)
+	}))
+	t.Cleanup(hook.Close)
+
+	// The exec notifier logs the labels of every alert it's run for.
+	dir := t.TempDir()
+	execLog := filepath.Join(dir, "exec.log")
+	stateFile := filepath.Join(dir, "state.json")
+	run := func() error {
+		cmd := buildServiceMetricsWatchCmd(newTestApp(t, client, "proj1"))
+		cmd.SetOut(new(bytes.Buffer))
+		cmd.SetErr(new(bytes.Buffer))
+		cmd.SetArgs([]string{"svc-12345", "--metric", common.MetricCPUUsage, "--above", "80%", "--for", "2m",
+			"--once", "--state-file", stateFile, "--webhook", hook.URL,
+			"--exec", `echo "$TIGER_ALERT_LABELS" >> ` + execLog})
+		return cmd.ExecuteContext(t.Context())
+	}
+
+	// The replica's failed webhook is reported and saved as pending.
+	if err := run(); err == nil {
+		t.Fatal("expected an error for the failed notification")
+	}
+	firing, pending, err := loadAlertState(stateFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firing) != 2 {
+		t.Errorf("state after the first run = %v, want both firing", firing)
+	}
+	if p := pending[`{role="replica"}`]; len(pending) != 1 || !slices.Equal(p.Notifiers, []string{"webhook"}) {
+		t.Errorf("pending after the first run = %+v, want the replica's webhook", pending)
+	}
+
+	// The next run retries only the replica's webhook, without running
+	// --exec for it again.
+	replicaOK = true
+	if err := run(); err != nil {
+		t.Fatalf("second run error = %v", err)
+	}
+	if len(events) != 2 || events[0].Labels["role"] != "primary" || events[1].Labels["role"] != "replica" {
+		t.Errorf("events = %+v, want one primary and one replica alert", events)
+	}
+	data, err := os.ReadFile(execLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != "{role=\"primary\"}\n{role=\"replica\"}\n" {
+		t.Errorf("exec ran for:\n%s\nwant the primary and the replica once each", got)
+	}
+	if firing, pending, _ = loadAlertState(stateFile); len(firing) != 2 || len(pending) != 0 {
+		t.Errorf("state after the second run = %v, %+v, want both firing and nothing pending", firing, pending)
+	}
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// buildServiceRecommendSizeCmd creates the recommend-size subcommand
+func buildServiceRecommendSizeCmd(app *common.App) *cobra.Command {
+	var window time.Duration
+	var resize bool
+	var confirm bool
+	var noWait bool
+	var waitTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "recommend-size [service-id]",
+		Short: "Recommend a CPU/memory size from historical usage",
+		Long: `Analyze a service's historical CPU and memory usage and recommend an allowed
+CPU/memory configuration, with a justification.
+
+The recommendation is based on the primary's p95 and peak usage over --window:
+  - upsize when p95 CPU is above 80% or p95 memory is above 85% of the
+    current allocation, to the smallest size that keeps p95 CPU under 70% and
+    p95 memory under 85%
+  - downsize to the smallest size that keeps p95 usage under those targets,
+    but only if peak usage also stays under 90% of it
+  - otherwise keep the current size
+
+Use --resize to apply the recommendation after confirming it.
+
+Examples:
+  # Recommend a size for the default service from the last 7 days of usage
+  tiger service recommend-size
+
+  # Analyze the last 30 days
+  tiger service recommend-size svc-12345 --window 720h
+
+  # Apply the recommendation after a confirmation prompt
+  tiger service recommend-size svc-12345 --resize
+
+  # Get the recommendation as JSON
+  tiger service recommend-size svc-12345 --output json`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: serviceIDCompletion(app),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if window < time.Hour {
+				return fmt.Errorf("--window must be at least 1h")
+			}
+
+			cfg, client, projectID, err := app.GetAll()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			if resize {
+				if err := common.CheckReadOnly(cfg); err != nil {
+					cmd.SilenceUsage = true
+					return err
+				}
+			}
+
+			serviceID, err := getServiceID(cfg, args)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			rec, err := common.RecommendServiceSize(ctx, common.RecommendServiceSizeArgs{
+				Client:    client,
+				ProjectID: projectID,
+				ServiceID: serviceID,
+				Window:    window,
+			})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			switch strings.ToLower(cfg.Output) {
+			case "json":
+				err = util.SerializeToJSON(out, rec)
+			case "yaml":
+				err = util.SerializeToYAML(out, rec)
+			default:
+				outputSizeRecommendation(cmd, rec)
+			}
+			if err != nil || !resize {
+				return err
+			}
+
+			if rec.Action == common.SizeActionKeep {
+				cmd.PrintErrln("💡 No resize needed.")
+				return nil
+			}
+
+			recommended := rec.Recommended()
+			if !confirm {
+				if !util.IsTerminal(cmd.InOrStdin()) || !util.IsTerminal(cmd.ErrOrStderr()) {
+					return fmt.Errorf("TTY not detected - cannot prompt for confirmation. Use --confirm to skip the prompt")
+				}
+				cmd.PrintErrf("Resize service '%s' to %s? Changing resources affects billing. [y/N] ", serviceID, recommended.String())
+				answer, err := util.ReadLine(cmd.Context(), cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("failed to read confirmation: %w", err)
+				}
+				if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+					cmd.PrintErrln("❌ Resize cancelled.")
+					return nil
+				}
+			}
+
+			return resizeService(cmd, client, projectID, serviceID, &recommended, noWait, waitTimeout)
+		},
+	}
+
+	cmd.Flags().DurationVar(&window, "window", 7*24*time.Hour, "How much usage history to analyze")
+	cmd.Flags().BoolVar(&resize, "resize", false, "Resize the service to the recommended size after confirmation")
+	cmd.Flags().BoolVar(&confirm, "confirm", false, "Skip the --resize confirmation prompt (AI agents must confirm with user first)")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false, "Don't wait for the resize operation to complete")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait for the resize to complete")
+	cmd.Flags().VarP(new(outputFlag), "output", "o", "Output format (json, yaml, table)")
+
+	return cmd
+}
+
+// outputSizeRecommendation prints a recommendation with its justification.
+func outputSizeRecommendation(cmd *cobra.Command, rec *common.SizeRecommendation) {
+	current, recommended := rec.Current(), rec.Recommended()
+	switch rec.Action {
+	case common.SizeActionUpsize:
+		cmd.Printf("⬆️  Upsize recommended: %s → %s\n", current.String(), recommended.String())
+	case common.SizeActionDownsize:
+		cmd.Printf("⬇️  Downsize recommended: %s → %s\n", current.String(), recommended.String())
+	default:
+		cmd.Printf("✅ Keep the current size: %s\n", current.String())
+	}
+
+	cmd.Printf("\nBased on the last %s of usage (%d CPU and %d memory samples):\n", rec.Window, rec.CPU.Samples, rec.Memory.Samples)
+	for _, reason := range rec.Justification {
+		cmd.Printf("  • %s\n", reason)
+	}
+}
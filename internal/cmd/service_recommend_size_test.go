@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// recommendSizeTestApp serves a 2 CPU/8 GB service whose primary uses
+// cpuMillis of CPU and memoryGBs of memory, and records resize requests.
+func recommendSizeTestApp(t *testing.T, cpuMillis, memoryGBs float64, resizes *[]api.ResizeInput) *common.App {
+	t.Helper()
+
+	service := api.Service{
+		ServiceID: "svc-12345",
+		Status:    "READY",
+		Resources: []api.Resource{{Spec: &api.ResourceSpec{CPUMillis: util.Ptr(2000), MemoryGbs: util.Ptr(8)}}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/metrics/series"):
+			var req api.MetricsSeriesRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			value := cpuMillis
+			if req.Name == common.MetricMemoryUsage {
+				value = memoryGBs * (1 << 30)
+			}
+			_ = json.NewEncoder(w).Encode([]api.MetricSeries{{
+				Labels: map[string]string{"role": "primary"},
+				Data:   []api.MetricDataPoint{{Time: req.From, Value: util.Ptr(value)}, {Time: req.To, Value: util.Ptr(value)}},
+			}})
+		case strings.HasSuffix(r.URL.Path, "/resize"):
+			var req api.ResizeInput
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			*resizes = append(*resizes, req)
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(service)
+		default:
+			_ = json.NewEncoder(w).Encode(service)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+	return newTestApp(t, client, "proj1")
+}
+
+func runRecommendSize(t *testing.T, app *common.App, args ...string) (string, error) {
+	t.Helper()
+	cmd := buildServiceRecommendSizeCmd(app)
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(t.Context())
+	return buf.String(), err
+}
+
+func TestServiceRecommendSize_Upsize(t *testing.T) {
+	var resizes []api.ResizeInput
+	app := recommendSizeTestApp(t, 1900, 4, &resizes)
+
+	out, err := runRecommendSize(t, app, "svc-12345")
+	if err != nil {
+		t.Fatalf("recommend-size error = %v\n%s", err, out)
+	}
+	for _, want := range []string{"Upsize recommended: 2 CPU/8 GB → 4 CPU/16 GB", "Based on the last 7d of usage", "CPU p95 is 1900m (95% of 2000m)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if len(resizes) != 0 {
+		t.Errorf("recommend-size without --resize resized the service: %+v", resizes)
+	}
+}
+
+func TestServiceRecommendSize_Resize(t *testing.T) {
+	var resizes []api.ResizeInput
+	app := recommendSizeTestApp(t, 100, 1, &resizes)
+
+	out, err := runRecommendSize(t, app, "svc-12345", "--resize", "--confirm", "--no-wait")
+	if err != nil {
+		t.Fatalf("recommend-size --resize error = %v\n%s", err, out)
+	}
+	if len(resizes) != 1 || resizes[0].CPUMillis != "500" || resizes[0].MemoryGbs != "2" {
+		t.Errorf("resize requests = %+v, want one to 500m/2 GB", resizes)
+	}
+	if !strings.Contains(out, "Downsize recommended: 2 CPU/8 GB → 0.5 CPU/2 GB") || !strings.Contains(out, "Resize request accepted") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestServiceRecommendSize_ResizeRequiresTTY(t *testing.T) {
+	stubIsTerminal(t, false)
+
+	var resizes []api.ResizeInput
+	app := recommendSizeTestApp(t, 1900, 4, &resizes)
+
+	_, err := runRecommendSize(t, app, "svc-12345", "--resize", "--window", (24 * time.Hour).String())
+	if err == nil || !strings.Contains(err.Error(), "Use --confirm") {
+		t.Errorf("expected TTY error, got %v", err)
+	}
+	if len(resizes) != 0 {
+		t.Errorf("service was resized without confirmation: %+v", resizes)
+	}
+}
@@ -89,73 +89,79 @@ Note: You can specify both CPU and memory together, or specify only one (the oth
 
 			cmd.SilenceUsage = true
 
-			// Display resize information
-			cmd.PrintErrf("📐 Resizing service '%s' to %s...\n", serviceID, cpuMemoryCfg)
+			return resizeService(cmd, client, projectID, serviceID, cpuMemoryCfg, resizeNoWait, resizeWaitTimeout)
+		},
+	}
 
-			// Prepare resize request
-			resizeReq := api.ResizeInput{
-				CPUMillis: *cpuMemoryCfg.CPUMillisString(),
-				MemoryGbs: *cpuMemoryCfg.MemoryGBsString(),
-			}
+	// Add flags
+	cmd.Flags().StringVar(&resizeCPU, "cpu", "", "CPU allocation in millicores")
+	cmd.Flags().StringVar(&resizeMemory, "memory", "", "Memory allocation in gigabytes")
+	cmd.Flags().BoolVar(&resizeNoWait, "no-wait", false, "Don't wait for resize operation to complete")
+	cmd.Flags().DurationVar(&resizeWaitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait for operation to complete")
 
-			// Make API call to resize service
-			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
-			defer cancel()
+	return cmd
+}
 
-			resp, err := client.ResizeServiceWithResponse(ctx, projectID, serviceID, resizeReq)
-			if err != nil {
-				return fmt.Errorf("failed to resize service: %w", err)
-			}
+// resizeService requests the resize and, unless noWait is set, waits for the
+// service to become ready again.
+func resizeService(cmd *cobra.Command, client api.ClientWithResponsesInterface, projectID, serviceID string, cpuMemoryCfg *common.CPUMemoryConfig, noWait bool, waitTimeout time.Duration) error {
+	// Display resize information
+	cmd.PrintErrf("📐 Resizing service '%s' to %s...\n", serviceID, cpuMemoryCfg)
 
-			// Handle API response
-			if resp.StatusCode() != http.StatusAccepted {
-				return common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
-			}
+	// Prepare resize request
+	resizeReq := api.ResizeInput{
+		CPUMillis: *cpuMemoryCfg.CPUMillisString(),
+		MemoryGbs: *cpuMemoryCfg.MemoryGBsString(),
+	}
 
-			if resp.JSON202 == nil {
-				return fmt.Errorf("empty response from API")
-			}
-			service := *resp.JSON202
+	// Make API call to resize service
+	ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+	defer cancel()
 
-			cmd.PrintErrf("✅ Resize request accepted for service '%s'!\n", serviceID)
+	resp, err := client.ResizeServiceWithResponse(ctx, projectID, serviceID, resizeReq)
+	if err != nil {
+		return fmt.Errorf("failed to resize service: %w", err)
+	}
 
-			// If not waiting, return early
-			if resizeNoWait {
-				cmd.PrintErrln("💡 Use 'tiger service get' to check service status.")
-				return nil
-			}
+	// Handle API response
+	if resp.StatusCode() != http.StatusAccepted {
+		return common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
 
-			// Wait for resize to complete
-			cmd.PrintErrf("⏳ Waiting for resize to complete (timeout: %v)...\n", resizeWaitTimeout)
-			if err := common.WaitForService(cmd.Context(), common.WaitForServiceArgs{
-				Client:    client,
-				ProjectID: projectID,
-				ServiceID: serviceID,
-				Handler: &common.StatusWaitHandler{
-					TargetStatus: "READY",
-					Service:      &service,
-				},
-				Input:      cmd.InOrStdin(),
-				Output:     cmd.ErrOrStderr(),
-				Timeout:    resizeWaitTimeout,
-				TimeoutMsg: "service may still be resizing",
-			}); err != nil {
-				// Return error for sake of exit code, but silence since we already output it
-				cmd.PrintErrf("❌ Error: %s\n", err)
-				cmd.SilenceErrors = true
-				return err
-			}
+	if resp.JSON202 == nil {
+		return fmt.Errorf("empty response from API")
+	}
+	service := *resp.JSON202
 
-			cmd.PrintErrf("🎉 Service '%s' has been successfully resized to %s!\n", serviceID, cpuMemoryCfg)
-			return nil
-		},
+	cmd.PrintErrf("✅ Resize request accepted for service '%s'!\n", serviceID)
+
+	// If not waiting, return early
+	if noWait {
+		cmd.PrintErrln("💡 Use 'tiger service get' to check service status.")
+		return nil
 	}
 
-	// Add flags
-	cmd.Flags().StringVar(&resizeCPU, "cpu", "", "CPU allocation in millicores")
-	cmd.Flags().StringVar(&resizeMemory, "memory", "", "Memory allocation in gigabytes")
-	cmd.Flags().BoolVar(&resizeNoWait, "no-wait", false, "Don't wait for resize operation to complete")
-	cmd.Flags().DurationVar(&resizeWaitTimeout, "wait-timeout", 10*time.Minute, "Maximum time to wait for operation to complete")
+	// Wait for resize to complete
+	cmd.PrintErrf("⏳ Waiting for resize to complete (timeout: %v)...\n", waitTimeout)
+	if err := common.WaitForService(cmd.Context(), common.WaitForServiceArgs{
+		Client:    client,
+		ProjectID: projectID,
+		ServiceID: serviceID,
+		Handler: &common.StatusWaitHandler{
+			TargetStatus: "READY",
+			Service:      &service,
+		},
+		Input:      cmd.InOrStdin(),
+		Output:     cmd.ErrOrStderr(),
+		Timeout:    waitTimeout,
+		TimeoutMsg: "service may still be resizing",
+	}); err != nil {
+		// Return error for sake of exit code, but silence since we already output it
+		cmd.PrintErrf("❌ Error: %s\n", err)
+		cmd.SilenceErrors = true
+		return err
+	}
 
-	return cmd
+	cmd.PrintErrf("🎉 Service '%s' has been successfully resized to %s!\n", serviceID, cpuMemoryCfg)
+	return nil
 }
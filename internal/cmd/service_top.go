@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// topMetrics are the metric series charted by `tiger service top`, in display
+// order.
+var topMetrics = []struct {
+	name  string
+	title string
+}{
+	{common.MetricCPUUsage, "CPU usage (millicores)"},
+	{common.MetricMemoryUsage, "Memory usage (bytes)"},
+	{common.MetricDiskUsage, "Storage usage (bytes)"},
+	{common.MetricConnections, "Connections"},
+}
+
+// buildServiceTopCmd creates the top command, a live metrics dashboard
+func buildServiceTopCmd(app *common.App) *cobra.Command {
+	var interval time.Duration
+	var window time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "top [service-id...]",
+		Short: "Live metrics dashboard for services",
+		Long: `Show a live dashboard of CPU, memory, storage and connection metrics.
+
+The dashboard refreshes at a fixed interval and charts each metric per node
+and replica over a trailing window, alongside the service's current resource
+usage. Use the arrow keys to switch between services.
+
+With no arguments, every service in the project can be switched between,
+starting with the default service. Pass one or more service IDs to limit the
+dashboard to those services.
+
+Examples:
+  # Watch all services, starting with the default service
+  tiger service top
+
+  # Watch two specific services
+  tiger service top svc-12345 svc-67890
+
+  # Refresh every 30 seconds and chart the last 6 hours
+  tiger service top --interval 30s --window 6h`,
+		ValidArgsFunction: serviceIDCompletion(app),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval < time.Second {
+				return fmt.Errorf("--interval must be at least 1s")
+			}
+			if window < time.Minute {
+				return fmt.Errorf("--window must be at least 1m")
+			}
+
+			cfg, client, projectID, err := app.GetAll()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			if !util.IsTerminal(cmd.InOrStdin()) || !util.IsTerminal(cmd.OutOrStdout()) {
+				return fmt.Errorf("TTY not detected - 'tiger service top' needs an interactive terminal. Use 'tiger service metrics series' instead")
+			}
+
+			targets := args
+			if len(targets) == 0 {
+				targets, err = listServiceIDs(cmd.Context(), client, projectID)
+				if err != nil {
+					return err
+				}
+				if len(targets) == 0 {
+					return fmt.Errorf("no services found in project")
+				}
+			}
+
+			model := newServiceTopModel(cmd.Context(), targets, interval, func(ctx context.Context, serviceID string) serviceTopSnapshot {
+				return fetchServiceTopSnapshot(ctx, client, projectID, serviceID, window, time.Now())
+			})
+			if i := slices.Index(targets, cfg.ServiceID); i >= 0 {
+				model.cursor = i
+			}
+
+			program := tea.NewProgram(model,
+				tea.WithInput(cmd.InOrStdin()),
+				tea.WithOutput(cmd.OutOrStdout()),
+				tea.WithContext(cmd.Context()),
+				tea.WithoutSignalHandler())
+			if _, err := program.Run(); err != nil {
+				return fmt.Errorf("failed to run dashboard: %w", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 10*time.Second, "How often to refresh the dashboard")
+	cmd.Flags().DurationVar(&window, "window", time.Hour, "Trailing time window charted for each metric")
+
+	return cmd
+}
+
+// listServiceIDs returns the IDs of every service in the project.
+func listServiceIDs(ctx context.Context, client api.ClientWithResponsesInterface, projectID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := client.GetServicesWithResponse(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	ids := make([]string, len(*resp.JSON200))
+	for i, service := range *resp.JSON200 {
+		ids[i] = service.ServiceID
+	}
+	return ids, nil
+}
+
+// serviceTopSnapshot is one refresh of the dashboard for a single service.
+type serviceTopSnapshot struct {
+	service *api.Service
+	err     error // failure to fetch the service itself
+
+	// series and seriesErrs are keyed by metric name. A metric that fails to
+	// load doesn't fail the whole snapshot.
+	series     map[string][]api.MetricSeries
+	seriesErrs map[string]error
+
+	fetched time.Time
+}
+
+// fetchServiceTopSnapshot fetches the service and every topMetrics series for
+// the window ending at now, concurrently.
+func fetchServiceTopSnapshot(ctx context.Context, client api.ClientWithResponsesInterface, projectID, serviceID string, window time.Duration, now time.Time) serviceTopSnapshot {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	snapshot := serviceTopSnapshot{
+		series:     make(map[string][]api.MetricSeries),
+		seriesErrs: make(map[string]error),
+		fetched:    now,
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	wg.Go(func() {
+		resp, err := client.GetServiceWithResponse(ctx, projectID, serviceID)
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err != nil:
+			snapshot.err = fmt.Errorf("failed to get service: %w", err)
+		case resp.StatusCode() != http.StatusOK:
+			snapshot.err = common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+		case resp.JSON200 == nil:
+			snapshot.err = fmt.Errorf("empty response from API")
+		default:
+			snapshot.service = resp.JSON200
+		}
+	})
+
+	for _, metric := range topMetrics {
+		wg.Go(func() {
+			series, err := common.FetchMetricSeries(ctx, client, projectID, serviceID, api.MetricsSeriesRequest{
+				Name: metric.name,
+				From: now.Add(-window),
+				To:   now,
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				snapshot.seriesErrs[metric.name] = err
+				return
+			}
+			snapshot.series[metric.name] = series
+		})
+	}
+
+	wg.Wait()
+	return snapshot
+}
+
+// Message types for the [tea.Model].
+type (
+	// topTickMsg triggers a refresh. seq ties it to the refresh cycle that
+	// scheduled it, so switching services doesn't leave a second cycle running.
+	topTickMsg struct{ seq int }
+
+	topSnapshotMsg struct {
+		seq      int
+		snapshot serviceTopSnapshot
+	}
+)
+
+type serviceTopModel struct {
+	ctx      context.Context
+	targets  []string // service IDs that can be switched between
+	cursor   int
+	interval time.Duration
+	fetch    func(ctx context.Context, serviceID string) serviceTopSnapshot
+
+	// seq increments whenever a new refresh cycle starts (on a service switch
+	// or manual refresh); ticks and snapshots from older cycles are dropped.
+	seq      int
+	snapshot *serviceTopSnapshot
+	loading  bool
+	width    int
+}
+
+func newServiceTopModel(ctx context.Context, targets []string, interval time.Duration, fetch func(context.Context, string) serviceTopSnapshot) serviceTopModel {
+	return serviceTopModel{
+		ctx:      ctx,
+		targets:  targets,
+		interval: interval,
+		fetch:    fetch,
+		width:    defaultChartWidth,
+	}
+}
+
+func (m serviceTopModel) Init() tea.Cmd {
+	return m.refresh()
+}
+
+// refresh fetches a snapshot of the current service for the current cycle.
+func (m serviceTopModel) refresh() tea.Cmd {
+	seq, serviceID := m.seq, m.targets[m.cursor]
+	return func() tea.Msg {
+		return topSnapshotMsg{seq: seq, snapshot: m.fetch(m.ctx, serviceID)}
+	}
+}
+
+// restart starts a new refresh cycle, e.g. after switching services.
+func (m serviceTopModel) restart() (tea.Model, tea.Cmd) {
+	m.seq++
+	m.loading = true
+	return m, m.refresh()
+}
+
+func (m serviceTopModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case topTickMsg:
+		if msg.seq == m.seq {
+			return m, m.refresh()
+		}
+	case topSnapshotMsg:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		m.snapshot = &msg.snapshot
+		m.loading = false
+		seq := m.seq
+		return m, tea.Tick(m.interval, func(time.Time) tea.Msg {
+			return topTickMsg{seq: seq}
+		})
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "left", "up", "h", "k":
+			if len(m.targets) > 1 {
+				m.cursor = (m.cursor - 1 + len(m.targets)) % len(m.targets)
+				m.snapshot = nil
+				return m.restart()
+			}
+		case "right", "down", "l", "j":
+			if len(m.targets) > 1 {
+				m.cursor = (m.cursor + 1) % len(m.targets)
+				m.snapshot = nil
+				return m.restart()
+			}
+		case "r":
+			return m.restart()
+		}
+	}
+	return m, nil
+}
+
+func (m serviceTopModel) View() tea.View {
+	v := tea.NewView(m.render())
+	v.AltScreen = true
+	v.WindowTitle = "tiger service top"
+	return v
+}
+
+func (m serviceTopModel) render() string {
+	var b strings.Builder
+	serviceID := m.targets[m.cursor]
+
+	// Header: service identity and position in the service list.
+	title := serviceID
+	if m.snapshot != nil && m.snapshot.service != nil {
+		svc := m.snapshot.service
+		title = fmt.Sprintf("%s (%s) · %s", svc.Name, svc.ServiceID, svc.Status)
+	}
+	header := "Tiger top · " + title
+	position := fmt.Sprintf("%d/%d", m.cursor+1, len(m.targets))
+	padding := max(m.width-utf8.RuneCountInString(header)-len(position), 1)
+	fmt.Fprintf(&b, "%s%s%s\n", header, strings.Repeat(" ", padding), position)
+
+	switch {
+	case m.snapshot == nil:
+		b.WriteString("\nLoading...\n")
+	case m.snapshot.err != nil:
+		fmt.Fprintf(&b, "\nError: %v\n", m.snapshot.err)
+	default:
+		b.WriteString(renderTopUsage(m.snapshot.service))
+		status := "updated " + m.snapshot.fetched.Local().Format("15:04:05")
+		if m.loading {
+			status = "refreshing..."
+		}
+		fmt.Fprintf(&b, "%s\n", status)
+		for _, metric := range topMetrics {
+			b.WriteString("\n" + metric.title + "\n")
+			b.WriteString(m.renderTopMetric(metric.name))
+		}
+	}
+
+	b.WriteString("\n←/→ switch service · r refresh · q quit")
+	return b.String()
+}
+
+// renderTopUsage renders the service's current resource usage against its
+// allocation, from the Service.Metrics snapshot the API keeps.
+func renderTopUsage(svc *api.Service) string {
+	var cpuAlloc, memAlloc string
+	if len(svc.Resources) > 0 && svc.Resources[0].Spec != nil {
+		spec := svc.Resources[0].Spec
+		if spec.CPUMillis != nil {
+			cpuAlloc = fmt.Sprintf(" / %dm", *spec.CPUMillis)
+		}
+		if spec.MemoryGbs != nil {
+			memAlloc = fmt.Sprintf(" / %d GB", *spec.MemoryGbs)
+		}
+	}
+
+	na := "n/a"
+	cpu, mem, storage := na, na, na
+	if metrics := svc.Metrics; metrics != nil {
+		if metrics.MilliCPU != nil {
+			cpu = fmt.Sprintf("%dm", *metrics.MilliCPU)
+		}
+		if metrics.MemoryMb != nil {
+			mem = fmt.Sprintf("%.1f GB", float64(*metrics.MemoryMb)/1024)
+		}
+		if metrics.StorageMb != nil {
+			storage = fmt.Sprintf("%.1f GB", float64(*metrics.StorageMb)/1024)
+		}
+	}
+	return fmt.Sprintf("CPU %s%s   Memory %s%s   Storage %s\n", cpu, cpuAlloc, mem, memAlloc, storage)
+}
+
+// renderTopMetric renders one sparkline row per node/replica series of metric.
+func (m serviceTopModel) renderTopMetric(metric string) string {
+	if err := m.snapshot.seriesErrs[metric]; err != nil {
+		return fmt.Sprintf("  unavailable: %v\n", err)
+	}
+	series := m.snapshot.series[metric]
+	if len(series) == 0 {
+		return "  (no data)\n"
+	}
+
+	labels := make([]string, len(series))
+	labelWidth := 0
+	for i, s := range series {
+		labels[i] = labelString(s.Labels)
+		labelWidth = max(labelWidth, len(labels[i]))
+	}
+
+	var b strings.Builder
+	for i, s := range series {
+		summary := common.SummarizeMetricData(s.Data)
+		if summary.Count == 0 {
+			fmt.Fprintf(&b, "  %-*s  (no data)\n", labelWidth, labels[i])
+			continue
+		}
+		stats := fmt.Sprintf("now %-6s avg %-6s max %-6s",
+			formatMetricValue(summary.Last), formatMetricValue(summary.Avg), formatMetricValue(summary.Max))
+		sparkWidth := max(m.width-labelWidth-len(stats)-6, 10)
+		spark := renderSparkline(metricValues(s.Data), sparkWidth, summary.Min, summary.Max)
+		fmt.Fprintf(&b, "  %-*s  %s  %s\n", labelWidth, labels[i], spark, stats)
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func testTopSnapshot(serviceID string) serviceTopSnapshot {
+	return serviceTopSnapshot{
+		service: &api.Service{
+			ServiceID: serviceID,
+			Name:      "db-" + serviceID,
+			Status:    "READY",
+			Resources: []api.Resource{
+				{Spec: &api.ResourceSpec{CPUMillis: util.Ptr(2000), MemoryGbs: util.Ptr(8)}},
+			},
+			Metrics: &api.ServiceMetrics{MilliCPU: util.Ptr(812), MemoryMb: util.Ptr(3072), StorageMb: util.Ptr(1024)},
+		},
+		series: map[string][]api.MetricSeries{
+			common.MetricCPUUsage: {testMetricSeries(util.Ptr(100.0), util.Ptr(812.0))},
+		},
+		seriesErrs: map[string]error{
+			common.MetricConnections: errors.New("boom"),
+		},
+		fetched: time.Date(2026, 5, 13, 10, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestServiceTopModel_SwitchService(t *testing.T) {
+	fetch := func(_ context.Context, serviceID string) serviceTopSnapshot {
+		return testTopSnapshot(serviceID)
+	}
+	var m tea.Model = newServiceTopModel(t.Context(), []string{"svc-a", "svc-b", "svc-c"}, time.Second, fetch)
+
+	// Switching wraps around in both directions and starts a new refresh cycle.
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	top := m.(serviceTopModel)
+	if top.cursor != 2 || top.seq != 1 || cmd == nil {
+		t.Fatalf("after left: cursor = %d, seq = %d, cmd = %v; want 2, 1, non-nil", top.cursor, top.seq, cmd)
+	}
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if top = m.(serviceTopModel); top.cursor != 0 || top.seq != 2 {
+		t.Fatalf("after right: cursor = %d, seq = %d; want 0, 2", top.cursor, top.seq)
+	}
+
+	// A snapshot from an earlier cycle is dropped.
+	m, _ = m.Update(topSnapshotMsg{seq: 1, snapshot: testTopSnapshot("svc-c")})
+	if top = m.(serviceTopModel); top.snapshot != nil {
+		t.Fatalf("stale snapshot was applied: %+v", top.snapshot)
+	}
+
+	// The current cycle's snapshot is applied and schedules the next tick.
+	m, cmd = m.Update(topSnapshotMsg{seq: 2, snapshot: testTopSnapshot("svc-a")})
+	if top = m.(serviceTopModel); top.snapshot == nil || top.loading || cmd == nil {
+		t.Fatalf("current snapshot not applied: snapshot = %v, loading = %v, cmd = %v", top.snapshot, top.loading, cmd)
+	}
+
+	// Ticks from an earlier cycle don't trigger a refresh.
+	if _, cmd := m.Update(topTickMsg{seq: 1}); cmd != nil {
+		t.Error("stale tick triggered a refresh")
+	}
+	if _, cmd := m.Update(topTickMsg{seq: 2}); cmd == nil {
+		t.Error("current tick did not trigger a refresh")
+	}
+}
+
+func TestServiceTopModel_Render(t *testing.T) {
+	m := newServiceTopModel(t.Context(), []string{"svc-a", "svc-b"}, time.Second, nil)
+	m.width = 100
+
+	if out := m.render(); !strings.Contains(out, "Loading...") || !strings.Contains(out, "1/2") {
+		t.Errorf("render() before the first snapshot =\n%s", out)
+	}
+
+	snapshot := testTopSnapshot("svc-a")
+	m.snapshot = &snapshot
+	out := m.render()
+	for _, want := range []string{
+		"db-svc-a (svc-a) · READY",
+		"CPU 812m / 2000m   Memory 3.0 GB / 8 GB   Storage 1.0 GB",
+		`{role="primary"}`,
+		"now 812",
+		"unavailable: boom",
+		"(no data)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render() missing %q:\n%s", want, out)
+		}
+	}
+
+	// The header is padded so the position sits at the right edge.
+	header := strings.SplitN(out, "\n", 2)[0]
+	if !strings.HasSuffix(header, "1/2") || len([]rune(header)) != 100 {
+		t.Errorf("header = %q (%d runes), want 100 runes ending in 1/2", header, len([]rune(header)))
+	}
+
+	snapshot.err = errors.New("service not found")
+	if out := m.render(); !strings.Contains(out, "Error: service not found") {
+		t.Errorf("render() with error =\n%s", out)
+	}
+}
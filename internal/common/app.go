@@ -87,6 +87,24 @@ func (a *App) newAPIClient(ctx context.Context, cfg *config.Config) (api.ClientW
 	return client, projectID, nil
 }
 
+// ForClient returns a new, loaded App that resolves the config the same way
+// as a, but always uses the given API client and project ID instead of the
+// stored credentials. The MCP HTTP transport uses it to run each session with
+// the API key that session authenticated with.
+func (a *App) ForClient(ctx context.Context, client api.ClientWithResponsesInterface, projectID string) (*App, error) {
+	app := &App{
+		Experimental: a.Experimental,
+		flags:        a.flags,
+		clientFactory: func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+			return client, projectID, nil
+		},
+	}
+	if _, _, _, err := app.Load(ctx); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
 // SetClient stores an existing API client and project ID. Use it when a valid
 // client already exists (e.g. after `tiger auth login` builds one to validate
 // credentials) so later readers — analytics in particular — see the new
@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 
@@ -152,18 +153,31 @@ func buildConnectionDetails(cfg *config.Config, direct *api.Endpoint, pooler *ap
 
 // String creates a PostgreSQL connection string from service details
 func (d *ConnectionDetails) String() string {
+	return ConnectionURL(d.Role, d.Password, d.Host, d.Port, d.Database, d.readOnly)
+}
+
+// ReadOnly reports whether the details open a connection in Tiger Cloud's
+// immutable read-only mode.
+func (d *ConnectionDetails) ReadOnly() bool {
+	return d.readOnly
+}
+
+// ConnectionURL builds a PostgreSQL connection URL. The password is omitted
+// when empty, and readOnly adds the option that activates Tiger Cloud's
+// immutable read-only connection mode.
+func ConnectionURL(role, password, host string, port int, database string, readOnly bool) string {
 	query := "sslmode=require"
-	if d.readOnly {
+	if readOnly {
 		query += "&" + readOnlyConnectionOption
 	}
 
 	// url.User* percent-encodes the role/password so URL-special characters (e.g.
 	// in a manually entered password) don't break connection-string parsing.
-	userinfo := url.User(d.Role)
-	if d.Password != "" {
-		userinfo = url.UserPassword(d.Role, d.Password)
+	userinfo := url.User(role)
+	if password != "" {
+		userinfo = url.UserPassword(role, password)
 	}
-	return fmt.Sprintf("postgresql://%s@%s:%d/%s?%s", userinfo, d.Host, d.Port, d.Database, query)
+	return fmt.Sprintf("postgresql://%s@%s:%d/%s?%s", userinfo, host, port, database, query)
 }
 
 // GetPassword fetches the password for the specified service from the
@@ -181,6 +195,11 @@ func GetPassword(cfg *config.Config, service api.Service, role string) (string,
 			return "", fmt.Errorf("no password found in keyring for this service")
 		case *PgpassStorage:
 			return "", fmt.Errorf("no password found in ~/.pgpass for this service")
+		case *ExecStorage:
+			if errors.Is(err, config.ErrCredentialNotFound) {
+				return "", fmt.Errorf("no password found by the credential helper for this service")
+			}
+			return "", fmt.Errorf("failed to retrieve password: %w", err)
 		default:
 			return "", fmt.Errorf("failed to retrieve password: %w", err)
 		}
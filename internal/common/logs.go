@@ -2,6 +2,7 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
@@ -23,13 +24,86 @@ type FetchServiceLogsArgs struct {
 	Node *int
 }
 
+// errTailReached stops StreamServiceLogs once FetchServiceLogs has collected
+// enough entries.
+var errTailReached = errors.New("tail reached")
+
 // FetchServiceLogs fetches service logs with cursor-based pagination up to the specified
 // tail limit. Returns entries in ascending order by timestamp (oldest first, newest last).
 func FetchServiceLogs(ctx context.Context, args FetchServiceLogsArgs) ([]api.ServiceLogEntry, error) {
+	var entries []api.ServiceLogEntry
+	err := StreamServiceLogs(ctx, StreamServiceLogsArgs{
+		Client:    args.Client,
+		ProjectID: args.ProjectID,
+		ServiceID: args.ServiceID,
+		Since:     args.Since,
+		Until:     args.Until,
+		Node:      args.Node,
+	}, func(page ServiceLogsPage) error {
+		entries = append(entries, page.Entries...)
+		// Stop when we have enough logs.
+		if len(entries) >= args.Tail {
+			return errTailReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errTailReached) {
+		return nil, err
+	}
+
+	// Trim to the requested tail count.
+	if len(entries) > args.Tail {
+		entries = entries[:args.Tail]
+	}
+
+	// Reverse: the API returns logs newest-first; terminal output is oldest-first.
+	slices.Reverse(entries)
+
+	return entries, nil
+}
+
+type StreamServiceLogsArgs struct {
+	Client    api.ClientWithResponsesInterface
+	ProjectID string
+	ServiceID string
+	Since     *time.Time
+
+	// Until fixes the upper time bound shared by every page. If nil, it
+	// defaults to the current time. Callers that resume an interrupted stream
+	// must pass the same Until the original stream used, or the cursor will
+	// point into a different window.
+	Until *time.Time
+
+	// Node selects a specific service node to fetch logs from, for services
+	// with HA replicas. If nil, the backend returns logs for the primary.
+	Node *int
+
+	// Cursor resumes paging from the NextCursor of an earlier page. If nil,
+	// paging starts with the newest entries in the window.
+	Cursor *string
+}
+
+// ServiceLogsPage is one page of log entries, in the order the API returns
+// them (newest first).
+type ServiceLogsPage struct {
+	Entries []api.ServiceLogEntry
+
+	// NextCursor is the cursor for the page after this one, or nil if this is
+	// the last page. Pass it as [StreamServiceLogsArgs.Cursor] to resume.
+	NextCursor *string
+}
+
+// StreamServiceLogs pages through every log entry in the requested window,
+// calling fn with each page as it arrives rather than buffering the whole
+// window in memory. There is no limit on the number of entries or pages; the
+// caller bounds the stream with Since/Until, by canceling ctx, or by returning
+// an error from fn, which stops paging and is returned unchanged.
+func StreamServiceLogs(ctx context.Context, args StreamServiceLogsArgs, fn func(ServiceLogsPage) error) error {
 	params := &api.GetServiceLogsParams{
-		Node:  args.Node,
-		Since: args.Since,
-		Until: args.Until,
+		Node:   args.Node,
+		Since:  args.Since,
+		Until:  args.Until,
+		Cursor: args.Cursor,
 	}
 
 	// Fix the upper time bound so that all paginated requests share the same
@@ -40,40 +114,36 @@ func FetchServiceLogs(ctx context.Context, args FetchServiceLogsArgs) ([]api.Ser
 		params.Until = &now
 	}
 
-	var entries []api.ServiceLogEntry
 	for {
 		resp, err := args.Client.GetServiceLogsWithResponse(ctx, args.ProjectID, args.ServiceID, params)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch logs: %w", err)
+			return fmt.Errorf("failed to fetch logs: %w", err)
 		}
 
 		if resp.StatusCode() != http.StatusOK {
-			return nil, ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+			return ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
 		}
 
 		if resp.JSON200 == nil {
-			return nil, fmt.Errorf("unexpected empty response")
+			return fmt.Errorf("unexpected empty response")
 		}
 
+		page := ServiceLogsPage{
+			NextCursor: resp.JSON200.LastCursor,
+		}
 		if resp.JSON200.Entries != nil {
-			entries = append(entries, *resp.JSON200.Entries...)
+			page.Entries = *resp.JSON200.Entries
 		}
 
-		// Stop when we have enough logs or the server signals no further pages.
-		if len(entries) >= args.Tail || resp.JSON200.LastCursor == nil {
-			break
+		if err := fn(page); err != nil {
+			return err
 		}
 
-		params.Cursor = resp.JSON200.LastCursor
-	}
+		// Stop when the server signals no further pages.
+		if page.NextCursor == nil {
+			return nil
+		}
 
-	// Trim to the requested tail count.
-	if len(entries) > args.Tail {
-		entries = entries[:args.Tail]
+		params.Cursor = page.NextCursor
 	}
-
-	// Reverse: the API returns logs newest-first; terminal output is oldest-first.
-	slices.Reverse(entries)
-
-	return entries, nil
 }
@@ -0,0 +1,407 @@
+package common
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+// Log export formats accepted by `tiger service logs --export`.
+const (
+	LogExportFormatNDJSON   = "ndjson"
+	LogExportFormatCSV      = "csv"
+	LogExportFormatOTLPJSON = "otlp-json"
+)
+
+// ValidLogExportFormats returns the formats supported by [ExportServiceLogs].
+func ValidLogExportFormats() []string {
+	return []string{LogExportFormatNDJSON, LogExportFormatCSV, LogExportFormatOTLPJSON}
+}
+
+type ExportServiceLogsArgs struct {
+	Client    api.ClientWithResponsesInterface
+	ProjectID string
+	ServiceID string
+	Since     *time.Time
+	Until     *time.Time
+	Node      *int
+
+	// Path is the export file. A checkpoint is kept next to it (see
+	// [LogExportCheckpointPath]) until the export completes.
+	Path   string
+	Format string
+
+	// Resume continues an interrupted export from its checkpoint instead of
+	// starting over. The checkpoint's window and node take precedence over
+	// Since/Until/Node.
+	Resume bool
+
+	// Progress, if set, is called after each page is written with the total
+	// number of entries exported so far.
+	Progress func(exported int)
+}
+
+// logExportCheckpoint records how far an export got, so an interrupted export
+// can pick up from the last page that was fully written.
+type logExportCheckpoint struct {
+	ServiceID string     `json:"service_id"`
+	Format    string     `json:"format"`
+	Node      *int       `json:"node,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	Until     time.Time  `json:"until"`
+	Cursor    *string    `json:"cursor,omitempty"`
+
+	// Offset is the size of the export file after the last complete page.
+	// Anything past it was written by a page the checkpoint doesn't cover,
+	// and is truncated on resume so no entry is written twice.
+	Offset   int64 `json:"offset"`
+	Exported int   `json:"exported"`
+}
+
+// LogExportCheckpointPath returns the path of the checkpoint file kept
+// alongside an in-progress export.
+func LogExportCheckpointPath(path string) string {
+	return path + ".checkpoint"
+}
+
+// ExportServiceLogs streams every log entry in the requested window to a file,
+// one page at a time, and returns the number of entries exported. Entries are
+// written in the order the API returns them (newest first).
+//
+// After each page is flushed to disk, the cursor for the next page is saved
+// to a checkpoint file. If the export is interrupted, calling it again with
+// Resume set continues from that cursor. The checkpoint is removed once the
+// export completes.
+func ExportServiceLogs(ctx context.Context, args ExportServiceLogsArgs) (int, error) {
+	var cp logExportCheckpoint
+	var file *os.File
+	if args.Resume {
+		var err error
+		cp, err = readLogExportCheckpoint(args.Path)
+		if err != nil {
+			return 0, err
+		}
+		if cp.ServiceID != args.ServiceID || cp.Format != args.Format {
+			return 0, fmt.Errorf("checkpoint %s is for service %s in %s format, not service %s in %s format",
+				LogExportCheckpointPath(args.Path), cp.ServiceID, cp.Format, args.ServiceID, args.Format)
+		}
+
+		file, err = os.OpenFile(args.Path, os.O_WRONLY, 0)
+		if err != nil {
+			return 0, fmt.Errorf("failed to open export file: %w", err)
+		}
+		// Drop anything written after the last checkpoint, then append.
+		if err := file.Truncate(cp.Offset); err != nil {
+			file.Close()
+			return 0, fmt.Errorf("failed to truncate export file: %w", err)
+		}
+		if _, err := file.Seek(cp.Offset, io.SeekStart); err != nil {
+			file.Close()
+			return 0, fmt.Errorf("failed to seek export file: %w", err)
+		}
+	} else {
+		cp = logExportCheckpoint{
+			ServiceID: args.ServiceID,
+			Format:    args.Format,
+			Node:      args.Node,
+			Since:     args.Since,
+			Until:     time.Now().UTC(),
+		}
+		if args.Until != nil {
+			cp.Until = *args.Until
+		}
+
+		var err error
+		file, err = os.OpenFile(args.Path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create export file: %w", err)
+		}
+	}
+	defer file.Close()
+
+	encoder, err := newLogExportEncoder(args.Format, file, args.ProjectID, args.ServiceID)
+	if err != nil {
+		return 0, err
+	}
+
+	if !args.Resume {
+		if err := encoder.WriteHeader(); err != nil {
+			return 0, fmt.Errorf("failed to write export file: %w", err)
+		}
+		if cp.Offset, err = file.Seek(0, io.SeekCurrent); err != nil {
+			return 0, fmt.Errorf("failed to write export file: %w", err)
+		}
+		// Save a checkpoint before the first page, so even an export that
+		// fails immediately can be resumed with the same window.
+		if err := writeLogExportCheckpoint(args.Path, cp); err != nil {
+			return 0, err
+		}
+	}
+
+	err = StreamServiceLogs(ctx, StreamServiceLogsArgs{
+		Client:    args.Client,
+		ProjectID: args.ProjectID,
+		ServiceID: args.ServiceID,
+		Since:     cp.Since,
+		Until:     &cp.Until,
+		Node:      cp.Node,
+		Cursor:    cp.Cursor,
+	}, func(page ServiceLogsPage) error {
+		if err := encoder.WritePage(page.Entries); err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+		if err := file.Sync(); err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+
+		offset, err := file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return fmt.Errorf("failed to write export file: %w", err)
+		}
+		cp.Offset = offset
+		cp.Cursor = page.NextCursor
+		cp.Exported += len(page.Entries)
+
+		if args.Progress != nil {
+			args.Progress(cp.Exported)
+		}
+
+		// The last page needs no checkpoint; it's removed below.
+		if page.NextCursor == nil {
+			return nil
+		}
+		return writeLogExportCheckpoint(args.Path, cp)
+	})
+	if err != nil {
+		return cp.Exported, err
+	}
+
+	if err := os.Remove(LogExportCheckpointPath(args.Path)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return cp.Exported, fmt.Errorf("failed to remove export checkpoint: %w", err)
+	}
+	return cp.Exported, nil
+}
+
+func readLogExportCheckpoint(path string) (logExportCheckpoint, error) {
+	var cp logExportCheckpoint
+	data, err := os.ReadFile(LogExportCheckpointPath(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, fmt.Errorf("no interrupted export to resume for %s", path)
+	} else if err != nil {
+		return cp, fmt.Errorf("failed to read export checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return cp, fmt.Errorf("failed to parse export checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// writeLogExportCheckpoint replaces the checkpoint atomically, so a crash
+// mid-write leaves the previous checkpoint intact.
+func writeLogExportCheckpoint(path string, cp logExportCheckpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("failed to encode export checkpoint: %w", err)
+	}
+	cpPath := LogExportCheckpointPath(path)
+	tmpPath := cpPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write export checkpoint: %w", err)
+	}
+	if err := os.Rename(tmpPath, cpPath); err != nil {
+		return fmt.Errorf("failed to write export checkpoint: %w", err)
+	}
+	return nil
+}
+
+// logExportEncoder writes log entries to an export file in a specific format.
+type logExportEncoder interface {
+	// WriteHeader writes anything that precedes the first entry. It is only
+	// called for a new file, not when resuming.
+	WriteHeader() error
+
+	// WritePage writes one page of entries.
+	WritePage(entries []api.ServiceLogEntry) error
+}
+
+func newLogExportEncoder(format string, w io.Writer, projectID, serviceID string) (logExportEncoder, error) {
+	switch format {
+	case LogExportFormatNDJSON:
+		return &ndjsonLogEncoder{enc: json.NewEncoder(w)}, nil
+	case LogExportFormatCSV:
+		return &csvLogEncoder{w: csv.NewWriter(w)}, nil
+	case LogExportFormatOTLPJSON:
+		return &otlpLogEncoder{enc: json.NewEncoder(w), projectID: projectID, serviceID: serviceID}, nil
+	default:
+		return nil, fmt.Errorf("invalid export format: %s (must be one of: %s)", format, strings.Join(ValidLogExportFormats(), ", "))
+	}
+}
+
+// ndjsonLogEncoder writes one JSON object per entry per line.
+type ndjsonLogEncoder struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonLogEncoder) WriteHeader() error {
+	return nil
+}
+
+func (e *ndjsonLogEncoder) WritePage(entries []api.ServiceLogEntry) error {
+	for _, entry := range entries {
+		if err := e.enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvLogEncoder writes a timestamp,severity,message row per entry.
+type csvLogEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvLogEncoder) WriteHeader() error {
+	e.w.Write([]string{"timestamp", "severity", "message"})
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvLogEncoder) WritePage(entries []api.ServiceLogEntry) error {
+	for _, entry := range entries {
+		e.w.Write([]string{formatLogTimestamp(entry.Timestamp), entry.Severity, entry.Message})
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatLogTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// otlpLogEncoder writes each page as an OTLP/JSON ExportLogsServiceRequest on
+// its own line — the layout the OpenTelemetry Collector's file exporter writes
+// and its otlpjsonfile receiver reads.
+type otlpLogEncoder struct {
+	enc       *json.Encoder
+	projectID string
+	serviceID string
+}
+
+// OTLP/JSON types. Only the fields we populate are declared; see
+// https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding.
+type otlpLogsData struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpLogRecord struct {
+	// 64-bit integers are encoded as decimal strings in OTLP/JSON.
+	TimeUnixNano   string       `json:"timeUnixNano,omitempty"`
+	SeverityNumber int          `json:"severityNumber,omitempty"`
+	SeverityText   string       `json:"severityText,omitempty"`
+	Body           otlpAnyValue `json:"body"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func (e *otlpLogEncoder) WriteHeader() error {
+	return nil
+}
+
+func (e *otlpLogEncoder) WritePage(entries []api.ServiceLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	records := make([]otlpLogRecord, len(entries))
+	for i, entry := range entries {
+		records[i] = otlpLogRecord{
+			SeverityNumber: otlpSeverityNumber(entry.Severity),
+			SeverityText:   entry.Severity,
+			Body:           otlpAnyValue{StringValue: entry.Message},
+		}
+		if !entry.Timestamp.IsZero() {
+			records[i].TimeUnixNano = strconv.FormatInt(entry.Timestamp.UnixNano(), 10)
+		}
+	}
+
+	return e.enc.Encode(otlpLogsData{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{
+					{Key: "service.name", Value: otlpAnyValue{StringValue: e.serviceID}},
+					{Key: "tiger.project_id", Value: otlpAnyValue{StringValue: e.projectID}},
+					{Key: "tiger.service_id", Value: otlpAnyValue{StringValue: e.serviceID}},
+				},
+			},
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: "tiger-cli", Version: config.Version},
+				LogRecords: records,
+			}},
+		}},
+	})
+}
+
+// otlpSeverityNumber maps a PostgreSQL severity level onto the OpenTelemetry
+// SeverityNumber scale. Unknown levels map to 0 (UNSPECIFIED).
+//
+// PostgreSQL severity levels: https://www.postgresql.org/docs/current/runtime-config-logging.html#RUNTIME-CONFIG-SEVERITY-LEVELS
+func otlpSeverityNumber(severity string) int {
+	switch strings.ToUpper(severity) {
+	case "DEBUG", "DEBUG1", "DEBUG2", "DEBUG3", "DEBUG4", "DEBUG5":
+		return 5 // DEBUG
+	case "LOG", "INFO":
+		return 9 // INFO
+	case "NOTICE":
+		return 10 // INFO2
+	case "WARNING":
+		return 13 // WARN
+	case "ERROR":
+		return 17 // ERROR
+	case "FATAL":
+		return 21 // FATAL
+	case "PANIC":
+		return 24 // FATAL4
+	default:
+		return 0
+	}
+}
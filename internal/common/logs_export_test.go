@@ -0,0 +1,264 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// logPagesTestClient builds an API client backed by an httptest server that
+// serves the getServiceLogs endpoint one page at a time. Page i is served for
+// cursor "c<i>" (page 0 for no cursor), and every page but the last returns a
+// cursor for the next. failOn, if non-empty, makes the request for that cursor
+// fail once with a 500.
+func logPagesTestClient(t *testing.T, pages [][]api.ServiceLogEntry, failOn string) *api.ClientWithResponses {
+	t.Helper()
+
+	failed := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		cursor := r.URL.Query().Get("cursor")
+		if cursor != "" && cursor == failOn && !failed {
+			failed = true
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "boom"})
+			return
+		}
+
+		i := 0
+		if cursor != "" {
+			i = int(cursor[1] - '0')
+		}
+		body := api.ServiceLogs{Entries: &pages[i]}
+		if i < len(pages)-1 {
+			body.LastCursor = util.Ptr("c" + string(rune('0'+i+1)))
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+	return client
+}
+
+func testLogPages() [][]api.ServiceLogEntry {
+	ts := func(sec int) time.Time { return time.Date(2024, 1, 15, 10, 0, sec, 0, time.UTC) }
+	return [][]api.ServiceLogEntry{
+		{
+			{Timestamp: ts(5), Severity: "ERROR", Message: "ERROR:  relation \"foo\" does not exist"},
+			{Timestamp: ts(4), Severity: "LOG", Message: "LOG:  checkpoint complete"},
+		},
+		{
+			{Timestamp: ts(3), Severity: "WARNING", Message: "WARNING:  a, \"quoted\" message"},
+		},
+		{
+			{Timestamp: ts(2), Severity: "LOG", Message: "LOG:  database system is ready"},
+		},
+	}
+}
+
+func TestFetchServiceLogs_TailAcrossPages(t *testing.T) {
+	client := logPagesTestClient(t, testLogPages(), "")
+
+	entries, err := FetchServiceLogs(t.Context(), FetchServiceLogsArgs{
+		Client:    client,
+		ProjectID: "proj1",
+		ServiceID: "svc1234567",
+		Tail:      3,
+	})
+	if err != nil {
+		t.Fatalf("FetchServiceLogs() error = %v", err)
+	}
+
+	// Oldest first, trimmed to the tail count.
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Severity)
+	}
+	if want := "WARNING,LOG,ERROR"; strings.Join(got, ",") != want {
+		t.Errorf("FetchServiceLogs() severities = %v, want %s", got, want)
+	}
+}
+
+func TestExportServiceLogs_Formats(t *testing.T) {
+	tests := []struct {
+		format string
+		check  func(t *testing.T, data string)
+	}{
+		{
+			format: LogExportFormatNDJSON,
+			check: func(t *testing.T, data string) {
+				lines := strings.Split(strings.TrimSpace(data), "\n")
+				if len(lines) != 4 {
+					t.Fatalf("got %d lines, want 4:\n%s", len(lines), data)
+				}
+				var entry api.ServiceLogEntry
+				if err := json.Unmarshal([]byte(lines[2]), &entry); err != nil {
+					t.Fatalf("invalid NDJSON line %q: %v", lines[2], err)
+				}
+				if entry.Severity != "WARNING" {
+					t.Errorf("line 3 severity = %q, want WARNING", entry.Severity)
+				}
+			},
+		},
+		{
+			format: LogExportFormatCSV,
+			check: func(t *testing.T, data string) {
+				want := "timestamp,severity,message\n" +
+					"2024-01-15T10:00:05Z,ERROR,\"ERROR:  relation \"\"foo\"\" does not exist\"\n" +
+					"2024-01-15T10:00:04Z,LOG,LOG:  checkpoint complete\n" +
+					"2024-01-15T10:00:03Z,WARNING,\"WARNING:  a, \"\"quoted\"\" message\"\n" +
+					"2024-01-15T10:00:02Z,LOG,LOG:  database system is ready\n"
+				if data != want {
+					t.Errorf("CSV output =\n%s\nwant\n%s", data, want)
+				}
+			},
+		},
+		{
+			format: LogExportFormatOTLPJSON,
+			check: func(t *testing.T, data string) {
+				// One ExportLogsServiceRequest per page.
+				lines := strings.Split(strings.TrimSpace(data), "\n")
+				if len(lines) != 3 {
+					t.Fatalf("got %d lines, want 3:\n%s", len(lines), data)
+				}
+				var req otlpLogsData
+				if err := json.Unmarshal([]byte(lines[0]), &req); err != nil {
+					t.Fatalf("invalid OTLP/JSON line: %v", err)
+				}
+				records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+				if len(records) != 2 {
+					t.Fatalf("got %d log records, want 2", len(records))
+				}
+				if records[0].SeverityNumber != 17 || records[0].SeverityText != "ERROR" {
+					t.Errorf("record severity = %d %q, want 17 ERROR", records[0].SeverityNumber, records[0].SeverityText)
+				}
+				if records[0].TimeUnixNano != "1705312805000000000" {
+					t.Errorf("record timeUnixNano = %q", records[0].TimeUnixNano)
+				}
+				if attr := req.ResourceLogs[0].Resource.Attributes[0]; attr.Key != "service.name" || attr.Value.StringValue != "svc1234567" {
+					t.Errorf("first resource attribute = %+v", attr)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "logs")
+			client := logPagesTestClient(t, testLogPages(), "")
+
+			exported, err := ExportServiceLogs(t.Context(), ExportServiceLogsArgs{
+				Client:    client,
+				ProjectID: "proj1",
+				ServiceID: "svc1234567",
+				Path:      path,
+				Format:    tt.format,
+			})
+			if err != nil {
+				t.Fatalf("ExportServiceLogs() error = %v", err)
+			}
+			if exported != 4 {
+				t.Errorf("ExportServiceLogs() exported = %d, want 4", exported)
+			}
+			if _, err := os.Stat(LogExportCheckpointPath(path)); !os.IsNotExist(err) {
+				t.Errorf("checkpoint should be removed after a complete export, stat err = %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read export: %v", err)
+			}
+			tt.check(t, string(data))
+		})
+	}
+}
+
+func TestExportServiceLogs_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.csv")
+	client := logPagesTestClient(t, testLogPages(), "c2")
+	args := ExportServiceLogsArgs{
+		Client:    client,
+		ProjectID: "proj1",
+		ServiceID: "svc1234567",
+		Path:      path,
+		Format:    LogExportFormatCSV,
+	}
+
+	exported, err := ExportServiceLogs(t.Context(), args)
+	if err == nil {
+		t.Fatal("expected the first export to fail on the third page")
+	}
+	if exported != 3 {
+		t.Errorf("interrupted export exported = %d, want 3", exported)
+	}
+	if _, err := os.Stat(LogExportCheckpointPath(path)); err != nil {
+		t.Fatalf("expected a checkpoint after an interrupted export: %v", err)
+	}
+
+	// Simulate a partial write after the checkpoint, which resume must discard.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("2024-01-15T10:00:02Z,LOG,partial")
+	f.Close()
+
+	args.Resume = true
+	exported, err = ExportServiceLogs(t.Context(), args)
+	if err != nil {
+		t.Fatalf("resumed ExportServiceLogs() error = %v", err)
+	}
+	if exported != 4 {
+		t.Errorf("resumed export exported = %d, want 4", exported)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(string(data), "\n") != 5 || strings.Contains(string(data), "partial") {
+		t.Errorf("resumed export has unexpected contents:\n%s", data)
+	}
+	if strings.Count(string(data), "timestamp,severity,message") != 1 {
+		t.Errorf("resumed export should have exactly one header:\n%s", data)
+	}
+}
+
+func TestExportServiceLogs_ResumeMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs")
+
+	_, err := ExportServiceLogs(t.Context(), ExportServiceLogsArgs{
+		ServiceID: "svc1234567",
+		Path:      path,
+		Format:    LogExportFormatNDJSON,
+		Resume:    true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "no interrupted export") {
+		t.Errorf("expected missing checkpoint error, got %v", err)
+	}
+
+	if err := writeLogExportCheckpoint(path, logExportCheckpoint{ServiceID: "other12345", Format: LogExportFormatNDJSON}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ExportServiceLogs(t.Context(), ExportServiceLogsArgs{
+		ServiceID: "svc1234567",
+		Path:      path,
+		Format:    LogExportFormatNDJSON,
+		Resume:    true,
+	})
+	if err == nil || !strings.Contains(err.Error(), "other12345") {
+		t.Errorf("expected checkpoint mismatch error, got %v", err)
+	}
+}
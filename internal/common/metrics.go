@@ -0,0 +1,98 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+
+	"github.com/timescale/tiger-cli/internal/api"
+)
+
+// Well-known metric series names. Use the available-series endpoint for the
+// full list.
+const (
+	MetricCPUUsage    = "timescale_cloud_system_cpu_usage_millicores"
+	MetricCPUTotal    = "timescale_cloud_system_cpu_total_millicores"
+	MetricMemoryUsage = "timescale_cloud_system_memory_usage_bytes"
+	MetricMemoryTotal = "timescale_cloud_system_memory_total_bytes"
+	MetricDiskUsage   = "timescale_cloud_system_disk_usage_bytes"
+	MetricConnections = "timescale_cloud_database_num_connections"
+)
+
+// FetchMetricSeries fetches the labeled series matching req. An empty result
+// is returned as a non-nil empty slice.
+func FetchMetricSeries(ctx context.Context, client api.ClientWithResponsesInterface, projectID, serviceID string, req api.MetricsSeriesRequest) ([]api.MetricSeries, error) {
+	resp, err := client.GetServiceMetricsSeriesWithResponse(ctx, projectID, serviceID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metric series: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+
+	if resp.JSON200 == nil || *resp.JSON200 == nil {
+		return []api.MetricSeries{}, nil
+	}
+	return *resp.JSON200, nil
+}
+
+// MetricSummary summarizes the data points of one metric series. Gap buckets
+// (null values) are counted but otherwise ignored; the value fields are zero
+// when the series has no non-null points.
+type MetricSummary struct {
+	Count int     `json:"count"`
+	Gaps  int     `json:"gaps"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Avg   float64 `json:"avg"`
+	P95   float64 `json:"p95"`
+	Last  float64 `json:"last"`
+}
+
+// SummarizeMetricData computes min/max/avg/p95 and the most recent value over
+// the non-null points of a series.
+func SummarizeMetricData(points []api.MetricDataPoint) MetricSummary {
+	var summary MetricSummary
+	values := make([]float64, 0, len(points))
+	for _, p := range points {
+		if p.Value == nil {
+			summary.Gaps++
+			continue
+		}
+		values = append(values, *p.Value)
+		summary.Last = *p.Value
+	}
+	if len(values) == 0 {
+		return summary
+	}
+
+	summary.Count = len(values)
+	summary.Min = slices.Min(values)
+	summary.Max = slices.Max(values)
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	summary.Avg = sum / float64(len(values))
+
+	slices.Sort(values)
+	summary.P95 = Percentile(values, 95)
+
+	return summary
+}
+
+// Percentile returns the p-th percentile (0-100) of sorted using the
+// nearest-rank method. sorted must be in ascending order; it returns 0 if
+// sorted is empty.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = max(1, min(rank, len(sorted)))
+	return sorted[rank-1]
+}
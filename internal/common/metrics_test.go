@@ -0,0 +1,55 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func TestSummarizeMetricData(t *testing.T) {
+	var points []api.MetricDataPoint
+	start := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 20; i++ {
+		points = append(points, api.MetricDataPoint{Time: start.Add(time.Duration(i) * time.Minute), Value: util.Ptr(float64(i))})
+	}
+	// A trailing gap bucket shouldn't affect the values, including Last.
+	points = append(points, api.MetricDataPoint{Time: start.Add(21 * time.Minute)})
+
+	got := SummarizeMetricData(points)
+	want := MetricSummary{Count: 20, Gaps: 1, Min: 1, Max: 20, Avg: 10.5, P95: 19, Last: 20}
+	if got != want {
+		t.Errorf("SummarizeMetricData() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSummarizeMetricData_AllGaps(t *testing.T) {
+	got := SummarizeMetricData([]api.MetricDataPoint{{}, {}})
+	if want := (MetricSummary{Gaps: 2}); got != want {
+		t.Errorf("SummarizeMetricData() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{"Empty", nil, 95, 0},
+		{"Single value", []float64{7}, 95, 7},
+		{"Median", []float64{1, 2, 3, 4}, 50, 2},
+		{"P95 of 10", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 95, 10},
+		{"P0 clamps to min", []float64{1, 2, 3}, 0, 1},
+		{"P100", []float64{1, 2, 3}, 100, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Percentile(tt.sorted, tt.p); got != tt.want {
+				t.Errorf("Percentile(%v, %v) = %v, want %v", tt.sorted, tt.p, got, tt.want)
+			}
+		})
+	}
+}
@@ -49,7 +49,7 @@ func sanitizeErrorMessage(err error, password string) string {
 // PasswordStorageResult contains the result of password storage operations
 type PasswordStorageResult struct {
 	Success bool   `json:"success"`
-	Method  string `json:"method"`  // "keyring", "pgpass", or "none"
+	Method  string `json:"method"`  // "keyring", "pgpass", "exec", or "none"
 	Message string `json:"message"` // Human-readable message
 }
 
@@ -306,6 +306,56 @@ func (p *PgpassStorage) GetStorageResult(err error, password string) PasswordSto
 	}
 }
 
+// ExecStorage implements password storage using an external credential
+// helper command (see config.CredentialHelper), e.g. one backed by 1Password,
+// Vault, or pass
+type ExecStorage struct {
+	Helper config.CredentialHelper
+}
+
+func (e *ExecStorage) Save(service api.Service, password string, role string) error {
+	account, err := buildPasswordKeyringUsername(service, role)
+	if err != nil {
+		return err
+	}
+
+	return e.Helper.Store(account, password)
+}
+
+func (e *ExecStorage) Get(service api.Service, role string) (string, error) {
+	account, err := buildPasswordKeyringUsername(service, role)
+	if err != nil {
+		return "", err
+	}
+
+	return e.Helper.Get(account)
+}
+
+func (e *ExecStorage) Remove(service api.Service, role string) error {
+	account, err := buildPasswordKeyringUsername(service, role)
+	if err != nil {
+		return err
+	}
+
+	return e.Helper.Erase(account)
+}
+
+func (e *ExecStorage) GetStorageResult(err error, password string) PasswordStorageResult {
+	if err != nil {
+		sanitizedErr := sanitizeErrorMessage(err, password)
+		return PasswordStorageResult{
+			Success: false,
+			Method:  "exec",
+			Message: fmt.Sprintf("Failed to save password with credential helper: %s", sanitizedErr),
+		}
+	}
+	return PasswordStorageResult{
+		Success: true,
+		Method:  "exec",
+		Message: "Password saved with credential helper for automatic authentication",
+	}
+}
+
 // NoStorage implements no password storage (passwords are not saved)
 type NoStorage struct{}
 
@@ -336,6 +386,8 @@ func GetPasswordStorage(cfg *config.Config) PasswordStorage {
 		return &KeyringStorage{}
 	case "pgpass":
 		return &PgpassStorage{}
+	case "exec":
+		return &ExecStorage{Helper: config.CredentialHelper{Command: cfg.CredentialHelper}}
 	case "none":
 		return &NoStorage{}
 	default:
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -300,6 +301,7 @@ func TestGetPasswordStorage(t *testing.T) {
 		{"keyring", "keyring", "*common.KeyringStorage"},
 		{"pgpass", "pgpass", "*common.PgpassStorage"},
 		{"none", "none", "*common.NoStorage"},
+		{"exec", "exec", "*common.ExecStorage"},
 		{"default", "", "*common.KeyringStorage"},        // Default case
 		{"invalid", "invalid", "*common.KeyringStorage"}, // Falls back to default
 	}
@@ -933,3 +935,58 @@ func TestSanitizeErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestExecStorage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake credential helper requires a POSIX shell")
+	}
+	config.SetTestServiceName(t)
+
+	// The fake helper keeps secrets in files named after the account
+	dir := t.TempDir()
+	script := filepath.Join(dir, "helper.sh")
+	helperScript := `#!/bin/sh
+while IFS='=' read -r key value; do
+	[ -z "$key" ] && break
+	case "$key" in account) account=$value ;; secret) secret=$value ;; esac
+done
+case "$1" in
+get) [ -f "$0.$account" ] && printf 'secret=%s\n' "$(cat "$0.$account")" ;;
+store) printf '%s' "$secret" > "$0.$account" ;;
+erase) rm -f "$0.$account" ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(script, []byte(helperScript), 0o755); err != nil {
+		t.Fatalf("Failed to write fake credential helper: %v", err)
+	}
+
+	cfg := &config.Config{PasswordStorage: "exec", CredentialHelper: script}
+	service := createTestService("svc-exec")
+
+	result, err := SavePasswordWithResult(cfg, service, "s3cret", "tsdbadmin")
+	if err != nil {
+		t.Fatalf("SavePasswordWithResult() failed: %v", err)
+	}
+	if !result.Success || result.Method != "exec" {
+		t.Errorf("Unexpected storage result: %+v", result)
+	}
+	if _, err := os.Stat(script + ".password-test-project-123-svc-exec-tsdbadmin"); err != nil {
+		t.Errorf("Expected the helper to store the password: %v", err)
+	}
+
+	password, err := GetPassword(cfg, service, "tsdbadmin")
+	if err != nil {
+		t.Fatalf("GetPassword() failed: %v", err)
+	}
+	if password != "s3cret" {
+		t.Errorf("Expected password s3cret, got %q", password)
+	}
+
+	if err := GetPasswordStorage(cfg).Remove(service, "tsdbadmin"); err != nil {
+		t.Fatalf("Remove() failed: %v", err)
+	}
+	if _, err := GetPassword(cfg, service, "tsdbadmin"); err == nil || !strings.Contains(err.Error(), "no password found by the credential helper") {
+		t.Errorf("Expected a not-found error after removal, got %v", err)
+	}
+}
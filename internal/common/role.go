@@ -0,0 +1,257 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateRoleOptions configures a role created by CreateRole.
+type CreateRoleOptions struct {
+	Name     string
+	Password string
+	// ReadOnly enables permanent read-only enforcement via the
+	// tsdb_admin.read_only_role setting.
+	ReadOnly bool
+	// StatementTimeout, when non-zero, is set as the role's statement_timeout.
+	StatementTimeout time.Duration
+	// FromRoles are existing roles the new role becomes a member of, so it
+	// inherits their grants.
+	FromRoles []string
+}
+
+// CreateRole creates a new login role with all of the given options in a
+// single transaction. It is the shared implementation behind `tiger db create
+// role` and the db_create_role MCP tool.
+func CreateRole(ctx context.Context, conn *pgx.Conn, opts CreateRoleOptions) error {
+	// Begin transaction for atomic operation
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Check if tsdbadmin is in the fromRoles list
+	hasTsdbadmin := false
+	var otherRoles []string
+	for _, role := range opts.FromRoles {
+		if role == "tsdbadmin" {
+			hasTsdbadmin = true
+		} else {
+			otherRoles = append(otherRoles, role)
+		}
+	}
+
+	// If tsdbadmin is requested, use special TimescaleDB Cloud functions
+	if hasTsdbadmin {
+		// Enforce read-only requirement when inheriting from tsdbadmin
+		if !opts.ReadOnly {
+			return fmt.Errorf("roles inheriting from tsdbadmin must be read-only")
+		}
+
+		// Cannot set statement_timeout on roles created with create_bare_readonly_role
+		// due to permission restrictions on altering special roles
+		if opts.StatementTimeout > 0 {
+			return fmt.Errorf("statement timeout cannot be set on roles inheriting from tsdbadmin (permission denied to alter special roles)")
+		}
+
+		// Use timescale_functions.create_bare_readonly_role to create the role
+		// This function creates a read-only role that can inherit tsdbadmin privileges
+		if _, err := tx.Exec(ctx, "SELECT timescale_functions.create_bare_readonly_role($1, $2)",
+			opts.Name, opts.Password); err != nil {
+			return fmt.Errorf("failed to create role with create_bare_readonly_role: %w", err)
+		}
+
+		// Grant tsdbadmin privileges using the special function
+		if _, err := tx.Exec(ctx, "SELECT timescale_functions.grant_tsdbadmin_to_role($1)",
+			opts.Name); err != nil {
+			return fmt.Errorf("failed to grant tsdbadmin privileges: %w", err)
+		}
+
+		// Grant any other roles (besides tsdbadmin) if specified
+		// This is necessary because the special functions don't support IN ROLE clause
+		for _, role := range otherRoles {
+			grantSQL := fmt.Sprintf("GRANT %s TO %s",
+				pgx.Identifier{role}.Sanitize(),
+				pgx.Identifier{opts.Name}.Sanitize())
+			if _, err := tx.Exec(ctx, grantSQL); err != nil {
+				return fmt.Errorf("failed to grant role %s: %w", role, err)
+			}
+		}
+	} else {
+		// Use standard CREATE ROLE for non-tsdbadmin cases
+		// Fail if password contains a single quote (we don't support escaping)
+		if strings.Contains(opts.Password, "'") {
+			return fmt.Errorf("password cannot contain single quotes")
+		}
+		// Wrap password in single quotes for SQL literal
+		quotedPassword := "'" + opts.Password + "'"
+		// IN ROLE clause handles all role grants, so no need for separate GRANT statements
+		createSQL := buildCreateRoleSQL(opts.Name, quotedPassword, opts.FromRoles)
+		if _, err := tx.Exec(ctx, createSQL); err != nil {
+			return fmt.Errorf("failed to create role: %w", err)
+		}
+
+		// Configure read-only mode if requested
+		if opts.ReadOnly {
+			alterSQL := buildReadOnlyAlterSQL(opts.Name)
+			if _, err := tx.Exec(ctx, alterSQL); err != nil {
+				return fmt.Errorf("failed to configure read-only mode: %w", err)
+			}
+		}
+	}
+
+	// Set statement timeout if requested
+	if opts.StatementTimeout > 0 {
+		alterSQL := buildStatementTimeoutAlterSQL(opts.Name, opts.StatementTimeout)
+		if _, err := tx.Exec(ctx, alterSQL); err != nil {
+			return fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// buildCreateRoleSQL generates the CREATE ROLE SQL statement with LOGIN, PASSWORD, and optional IN ROLE clause
+func buildCreateRoleSQL(roleName string, quotedPassword string, fromRoles []string) string {
+	sanitizedRoleName := pgx.Identifier{roleName}.Sanitize()
+	createSQL := fmt.Sprintf("CREATE ROLE %s WITH LOGIN PASSWORD %s", sanitizedRoleName, quotedPassword)
+
+	// Add IN ROLE clause if fromRoles is specified
+	// IN ROLE adds the new role as a member of existing roles (equivalent to GRANT existing_role TO new_role)
+	if len(fromRoles) > 0 {
+		var sanitizedRoles []string
+		for _, role := range fromRoles {
+			sanitizedRoles = append(sanitizedRoles, pgx.Identifier{role}.Sanitize())
+		}
+		createSQL += " IN ROLE " + strings.Join(sanitizedRoles, ", ")
+	}
+
+	return createSQL
+}
+
+// buildReadOnlyAlterSQL generates the ALTER ROLE SQL statement for read-only enforcement
+func buildReadOnlyAlterSQL(roleName string) string {
+	sanitizedRoleName := pgx.Identifier{roleName}.Sanitize()
+	return fmt.Sprintf("ALTER ROLE %s SET tsdb_admin.read_only_role = true", sanitizedRoleName)
+}
+
+// buildStatementTimeoutAlterSQL generates the ALTER ROLE SQL statement for statement timeout configuration
+func buildStatementTimeoutAlterSQL(roleName string, timeout time.Duration) string {
+	sanitizedRoleName := pgx.Identifier{roleName}.Sanitize()
+	timeoutMs := timeout.Milliseconds()
+	return fmt.Sprintf("ALTER ROLE %s SET statement_timeout = %d", sanitizedRoleName, timeoutMs)
+}
+
+// RoleInfo describes a database role, its attributes, and its memberships.
+type RoleInfo struct {
+	Name             string   `json:"name"`
+	CanLogin         bool     `json:"can_login"`
+	Superuser        bool     `json:"superuser,omitempty"`
+	CreateRole       bool     `json:"create_role,omitempty"`
+	CreateDB         bool     `json:"create_db,omitempty"`
+	Inherit          bool     `json:"inherit"`
+	ConnectionLimit  int      `json:"connection_limit"` // -1 means no limit
+	ValidUntil       string   `json:"valid_until,omitempty"`
+	ReadOnly         bool     `json:"read_only,omitempty"`
+	StatementTimeout string   `json:"statement_timeout,omitempty"`
+	MemberOf         []string `json:"member_of"`
+}
+
+// listRolesSQL reads roles from pg_roles. rolconfig holds the role-level
+// settings made with ALTER ROLE ... SET, which is where read-only enforcement
+// and statement timeouts live.
+const listRolesSQL = `
+SELECT r.rolname,
+       r.rolcanlogin,
+       r.rolsuper,
+       r.rolcreaterole,
+       r.rolcreatedb,
+       r.rolinherit,
+       r.rolconnlimit,
+       COALESCE(r.rolvaliduntil::text, ''),
+       COALESCE(r.rolconfig, '{}'),
+       ARRAY(SELECT g.rolname
+             FROM pg_auth_members m
+             JOIN pg_roles g ON g.oid = m.roleid
+             WHERE m.member = r.oid
+             ORDER BY g.rolname)
+FROM pg_roles r
+WHERE $1 OR r.rolname !~ '^pg_'
+ORDER BY r.rolname`
+
+// ListRoles returns the roles in the database, ordered by name. Postgres's
+// predefined pg_* roles are left out unless includeSystem is set.
+func ListRoles(ctx context.Context, conn *pgx.Conn, includeSystem bool) ([]RoleInfo, error) {
+	rows, err := conn.Query(ctx, listRolesSQL, includeSystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []RoleInfo{}
+	for rows.Next() {
+		var role RoleInfo
+		var settings []string
+		if err := rows.Scan(&role.Name, &role.CanLogin, &role.Superuser, &role.CreateRole, &role.CreateDB,
+			&role.Inherit, &role.ConnectionLimit, &role.ValidUntil, &settings, &role.MemberOf); err != nil {
+			return nil, fmt.Errorf("failed to read role: %w", err)
+		}
+		applyRoleSettings(&role, settings)
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+// applyRoleSettings fills in the RoleInfo fields derived from a role's
+// rolconfig entries, which have the form "name=value".
+func applyRoleSettings(role *RoleInfo, settings []string) {
+	for _, setting := range settings {
+		name, value, _ := strings.Cut(setting, "=")
+		switch name {
+		case "tsdb_admin.read_only_role":
+			role.ReadOnly = value == "true" || value == "on"
+		case "statement_timeout":
+			role.StatementTimeout = formatStatementTimeout(value)
+		}
+	}
+}
+
+// formatStatementTimeout renders a statement_timeout setting as a duration.
+// Bare numbers are milliseconds (what buildStatementTimeoutAlterSQL writes);
+// anything else, like "30s", is returned as-is.
+func formatStatementTimeout(value string) string {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return (time.Duration(ms) * time.Millisecond).String()
+	}
+	return value
+}
+
+// ErrProtectedRole is returned by DropRole for roles the service depends on.
+var ErrProtectedRole = errors.New("role is managed by Tiger Cloud and can't be dropped")
+
+// DropRole drops a role. Postgres refuses if the role still owns objects or
+// holds privileges; the error then says which, and the caller must reassign
+// or revoke them first.
+func DropRole(ctx context.Context, conn *pgx.Conn, roleName string) error {
+	if roleName == "tsdbadmin" || roleName == "postgres" || strings.HasPrefix(roleName, "pg_") {
+		return fmt.Errorf("%s: %w", roleName, ErrProtectedRole)
+	}
+	if _, err := conn.Exec(ctx, "DROP ROLE "+pgx.Identifier{roleName}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to drop role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+)
+
+// Size recommendation actions.
+const (
+	SizeActionUpsize   = "upsize"
+	SizeActionDownsize = "downsize"
+	SizeActionKeep     = "keep"
+)
+
+const (
+	// sizeTargetCPUUtilization and sizeTargetMemoryUtilization are the p95
+	// utilizations a recommended size aims to stay under, leaving headroom for
+	// growth and spikes. Memory runs closer to its allocation by design, since
+	// Postgres uses free memory for caching.
+	sizeTargetCPUUtilization    = 0.70
+	sizeTargetMemoryUtilization = 0.85
+
+	// sizeUpsizeCPUUtilization and sizeUpsizeMemoryUtilization are the p95
+	// utilizations above which a service is considered undersized. Neither is
+	// below its target, so an undersized service always has a larger target
+	// size unless it's already at the largest one.
+	sizeUpsizeCPUUtilization    = 0.80
+	sizeUpsizeMemoryUtilization = sizeTargetMemoryUtilization
+
+	// sizeMaxUtilization caps the peak utilization a downsize may cause, so a
+	// rare spike that p95 hides still fits.
+	sizeMaxUtilization = 0.90
+
+	bytesPerGB = 1 << 30
+)
+
+// SizeRecommendation is the result of analyzing a service's historical CPU and
+// memory usage against the allowed resize configurations.
+type SizeRecommendation struct {
+	ServiceID            string        `json:"service_id"`
+	Action               string        `json:"action"`
+	CurrentCPUMillis     int           `json:"current_cpu_millis"`
+	CurrentMemoryGBs     int           `json:"current_memory_gbs"`
+	RecommendedCPUMillis int           `json:"recommended_cpu_millis"`
+	RecommendedMemoryGBs int           `json:"recommended_memory_gbs"`
+	Window               string        `json:"window"`
+	CPU                  ResourceUsage `json:"cpu"`
+	Memory               ResourceUsage `json:"memory"`
+	Justification        []string      `json:"justification"`
+}
+
+// ResourceUsage summarizes the usage of one resource over the analysis window.
+// CPU values are in millicores and memory values in bytes; utilizations are
+// fractions of the current allocation.
+type ResourceUsage struct {
+	Samples        int     `json:"samples"`
+	Avg            float64 `json:"avg"`
+	P95            float64 `json:"p95"`
+	Max            float64 `json:"max"`
+	P95Utilization float64 `json:"p95_utilization"`
+	MaxUtilization float64 `json:"max_utilization"`
+}
+
+// Current returns the service's current configuration.
+func (r *SizeRecommendation) Current() CPUMemoryConfig {
+	return CPUMemoryConfig{CPUMillis: r.CurrentCPUMillis, MemoryGBs: r.CurrentMemoryGBs}
+}
+
+// Recommended returns the recommended configuration.
+func (r *SizeRecommendation) Recommended() CPUMemoryConfig {
+	return CPUMemoryConfig{CPUMillis: r.RecommendedCPUMillis, MemoryGBs: r.RecommendedMemoryGBs}
+}
+
+type RecommendServiceSizeArgs struct {
+	Client    api.ClientWithResponsesInterface
+	ProjectID string
+	ServiceID string
+	Window    time.Duration // How far back to analyze usage
+	Now       time.Time     // End of the analysis window (defaults to time.Now())
+}
+
+// RecommendServiceSize fetches a service's allocation and its primary's CPU
+// and memory usage over the window, and recommends the allowed configuration
+// that fits it.
+func RecommendServiceSize(ctx context.Context, args RecommendServiceSizeArgs) (*SizeRecommendation, error) {
+	now := args.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	resp, err := args.Client.GetServiceWithResponse(ctx, args.ProjectID, args.ServiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service details: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("empty response from API")
+	}
+	service := resp.JSON200
+
+	if len(service.Resources) == 0 || service.Resources[0].Spec == nil ||
+		service.Resources[0].Spec.CPUMillis == nil || service.Resources[0].Spec.MemoryGbs == nil {
+		return nil, fmt.Errorf("service '%s' runs on shared resources and can't be resized", args.ServiceID)
+	}
+	current := CPUMemoryConfig{
+		CPUMillis: *service.Resources[0].Spec.CPUMillis,
+		MemoryGBs: *service.Resources[0].Spec.MemoryGbs,
+	}
+
+	// Replicas mirror the primary's size, so the primary's load decides it.
+	filters := []api.MetricLabelFilter{{Key: "role", Value: "primary"}}
+	usage := func(metric string) (MetricSummary, error) {
+		series, err := FetchMetricSeries(ctx, args.Client, args.ProjectID, args.ServiceID, api.MetricsSeriesRequest{
+			Name:    metric,
+			From:    now.Add(-args.Window),
+			To:      now,
+			Filters: &filters,
+		})
+		if err != nil {
+			return MetricSummary{}, err
+		}
+		var points []api.MetricDataPoint
+		for _, s := range series {
+			points = append(points, s.Data...)
+		}
+		return SummarizeMetricData(points), nil
+	}
+
+	cpu, err := usage(MetricCPUUsage)
+	if err != nil {
+		return nil, err
+	}
+	memory, err := usage(MetricMemoryUsage)
+	if err != nil {
+		return nil, err
+	}
+	if cpu.Count == 0 || memory.Count == 0 {
+		return nil, fmt.Errorf("no CPU or memory usage data for service '%s' in the last %s", args.ServiceID, FormatWindow(args.Window))
+	}
+
+	rec := RecommendSize(current, cpu, memory, GetAllowedResizeCPUMemoryConfigs())
+	rec.ServiceID = args.ServiceID
+	rec.Window = FormatWindow(args.Window)
+	return rec, nil
+}
+
+// RecommendSize picks the smallest allowed configuration whose allocation
+// keeps p95 CPU and memory usage under their target utilizations. A downsize
+// is only recommended if peak usage also fits the smaller size; otherwise the
+// current size is kept. cpu is in millicores and memory in bytes.
+func RecommendSize(current CPUMemoryConfig, cpu, memory MetricSummary, allowed CPUMemoryConfigs) *SizeRecommendation {
+	rec := &SizeRecommendation{
+		CurrentCPUMillis: current.CPUMillis,
+		CurrentMemoryGBs: current.MemoryGBs,
+		CPU:              resourceUsage(cpu, float64(current.CPUMillis)),
+		Memory:           resourceUsage(memory, float64(current.MemoryGBs)*bytesPerGB),
+	}
+
+	configs := slices.Clone(allowed)
+	slices.SortFunc(configs, func(a, b CPUMemoryConfig) int { return a.CPUMillis - b.CPUMillis })
+
+	fits := func(c CPUMemoryConfig, cpuValue, cpuLimit, memValue, memLimit float64) bool {
+		return cpuValue <= float64(c.CPUMillis)*cpuLimit && memValue <= float64(c.MemoryGBs)*bytesPerGB*memLimit
+	}
+
+	// The smallest configuration that fits p95 usage at the target
+	// utilizations, or the largest one if nothing does.
+	target := configs[len(configs)-1]
+	for _, c := range configs {
+		if fits(c, cpu.P95, sizeTargetCPUUtilization, memory.P95, sizeTargetMemoryUtilization) {
+			target = c
+			break
+		}
+	}
+
+	var undersized []string
+	if rec.CPU.P95Utilization > sizeUpsizeCPUUtilization {
+		undersized = append(undersized, fmt.Sprintf("CPU p95 utilization is above %.0f%%, so the service is at risk of running out of CPU headroom", sizeUpsizeCPUUtilization*100))
+	}
+	if rec.Memory.P95Utilization > sizeUpsizeMemoryUtilization {
+		undersized = append(undersized, fmt.Sprintf("Memory p95 utilization is above %.0f%%, so the service is at risk of running out of memory headroom", sizeUpsizeMemoryUtilization*100))
+	}
+	rec.Justification = append(rec.Justification,
+		fmt.Sprintf("CPU p95 is %.0fm (%.0f%% of %dm), peak %.0fm", cpu.P95, rec.CPU.P95Utilization*100, current.CPUMillis, cpu.Max),
+		fmt.Sprintf("Memory p95 is %.1f GB (%.0f%% of %d GB), peak %.1f GB", memory.P95/bytesPerGB, rec.Memory.P95Utilization*100, current.MemoryGBs, memory.Max/bytesPerGB),
+	)
+
+	switch {
+	case len(undersized) > 0 && target.CPUMillis > current.CPUMillis:
+		rec.Action = SizeActionUpsize
+		rec.Justification = append(rec.Justification, undersized...)
+		rec.Justification = append(rec.Justification,
+			fmt.Sprintf("%s is the smallest allowed size that keeps p95 CPU under %.0f%% and p95 memory under %.0f%%", target.String(), sizeTargetCPUUtilization*100, sizeTargetMemoryUtilization*100),
+		)
+		if !fits(target, cpu.P95, sizeTargetCPUUtilization, memory.P95, sizeTargetMemoryUtilization) {
+			rec.Justification = append(rec.Justification, "Even the largest allowed size is above the target utilization; consider optimizing the workload or adding read replicas")
+		}
+	case len(undersized) > 0:
+		rec.Action = SizeActionKeep
+		target = current
+		rec.Justification = append(rec.Justification, "The service is already at the largest allowed size; consider optimizing the workload or adding read replicas")
+	case target.CPUMillis < current.CPUMillis && fits(target, cpu.Max, sizeMaxUtilization, memory.Max, sizeMaxUtilization):
+		rec.Action = SizeActionDownsize
+		rec.Justification = append(rec.Justification,
+			fmt.Sprintf("%s keeps p95 CPU under %.0f%% and p95 memory under %.0f%%, and peak usage under %.0f%%", target.String(), sizeTargetCPUUtilization*100, sizeTargetMemoryUtilization*100, sizeMaxUtilization*100),
+		)
+	default:
+		rec.Action = SizeActionKeep
+		if target.CPUMillis < current.CPUMillis {
+			rec.Justification = append(rec.Justification, fmt.Sprintf("%s would fit p95 usage, but peak usage would exceed %.0f%% of it", target.String(), sizeMaxUtilization*100))
+		} else {
+			rec.Justification = append(rec.Justification, "Usage is within the target range for the current size")
+		}
+		target = current
+	}
+
+	rec.RecommendedCPUMillis = target.CPUMillis
+	rec.RecommendedMemoryGBs = target.MemoryGBs
+	return rec
+}
+
+func resourceUsage(summary MetricSummary, allocation float64) ResourceUsage {
+	usage := ResourceUsage{Samples: summary.Count, Avg: summary.Avg, P95: summary.P95, Max: summary.Max}
+	if allocation > 0 {
+		usage.P95Utilization = summary.P95 / allocation
+		usage.MaxUtilization = summary.Max / allocation
+	}
+	return usage
+}
+
+// FormatWindow renders a lookback window compactly, using days when it's a
+// whole number of them (e.g. "7d" rather than "168h0m0s").
+func FormatWindow(d time.Duration) string {
+	if day := 24 * time.Hour; d >= day && d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
@@ -0,0 +1,176 @@
+package common
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+const testGB = 1 << 30
+
+func TestRecommendSize(t *testing.T) {
+	current := CPUMemoryConfig{CPUMillis: 2000, MemoryGBs: 8}
+	usage := func(p95, peak float64) MetricSummary {
+		return MetricSummary{Count: 100, Avg: p95 / 2, P95: p95, Max: peak}
+	}
+
+	tests := []struct {
+		name    string
+		current CPUMemoryConfig
+		cpu     MetricSummary
+		memory  MetricSummary
+		action  string
+		want    CPUMemoryConfig
+	}{
+		{
+			name:   "CPU bound",
+			cpu:    usage(1800, 2000),
+			memory: usage(4*testGB, 5*testGB),
+			action: SizeActionUpsize,
+			want:   CPUMemoryConfig{CPUMillis: 4000, MemoryGBs: 16},
+		},
+		{
+			name:   "memory bound",
+			cpu:    usage(500, 900),
+			memory: usage(7*testGB, 7.5*testGB),
+			action: SizeActionUpsize,
+			want:   CPUMemoryConfig{CPUMillis: 4000, MemoryGBs: 16},
+		},
+		{
+			name:   "memory between the upsize and target thresholds",
+			cpu:    usage(500, 900),
+			memory: usage(6.64*testGB, 7*testGB),
+			action: SizeActionKeep,
+			want:   current,
+		},
+		{
+			name:   "well sized",
+			cpu:    usage(1000, 1500),
+			memory: usage(5*testGB, 6*testGB),
+			action: SizeActionKeep,
+			want:   current,
+		},
+		{
+			name:   "oversized",
+			cpu:    usage(300, 600),
+			memory: usage(2*testGB, 3*testGB),
+			action: SizeActionDownsize,
+			want:   CPUMemoryConfig{CPUMillis: 1000, MemoryGBs: 4},
+		},
+		{
+			name:   "oversized but spiky",
+			cpu:    usage(300, 1900),
+			memory: usage(2*testGB, 3*testGB),
+			action: SizeActionKeep,
+			want:   current,
+		},
+		{
+			name:    "already the largest",
+			current: CPUMemoryConfig{CPUMillis: 32000, MemoryGBs: 128},
+			cpu:     usage(31000, 32000),
+			memory:  usage(64*testGB, 64*testGB),
+			action:  SizeActionKeep,
+			want:    CPUMemoryConfig{CPUMillis: 32000, MemoryGBs: 128},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur := current
+			if tt.current.CPUMillis != 0 {
+				cur = tt.current
+			}
+			rec := RecommendSize(cur, tt.cpu, tt.memory, GetAllowedResizeCPUMemoryConfigs())
+			if rec.Action != tt.action || rec.Recommended() != tt.want {
+				t.Errorf("RecommendSize() = %s %s, want %s %s\njustification: %q",
+					rec.Action, util.Ptr(rec.Recommended()), tt.action, &tt.want, rec.Justification)
+			}
+			if allowed := GetAllowedResizeCPUMemoryConfigs(); cur != allowed[len(allowed)-1] && strings.Contains(strings.Join(rec.Justification, "\n"), "already at the largest") {
+				t.Errorf("RecommendSize() says %s is the largest size: %q", &cur, rec.Justification)
+			}
+			if len(rec.Justification) < 3 {
+				t.Errorf("RecommendSize() justification too short: %q", rec.Justification)
+			}
+		})
+	}
+}
+
+func TestRecommendServiceSize(t *testing.T) {
+	var filters []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.HasSuffix(r.URL.Path, "/metrics/series") {
+			_ = json.NewEncoder(w).Encode(api.Service{
+				ServiceID: "svc1234567",
+				Resources: []api.Resource{{Spec: &api.ResourceSpec{CPUMillis: util.Ptr(4000), MemoryGbs: util.Ptr(16)}}},
+			})
+			return
+		}
+
+		var req api.MetricsSeriesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for _, f := range *req.Filters {
+			filters = append(filters, f.Key+"="+f.Value)
+		}
+		value := 500.0 // millicores
+		if req.Name == MetricMemoryUsage {
+			value = 3 * testGB
+		}
+		series := api.MetricSeries{Labels: map[string]string{"role": "primary"}}
+		for i := range 24 {
+			series.Data = append(series.Data, api.MetricDataPoint{Time: req.From.Add(time.Duration(i) * time.Hour), Value: util.Ptr(value)})
+		}
+		_ = json.NewEncoder(w).Encode([]api.MetricSeries{series})
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := RecommendServiceSize(t.Context(), RecommendServiceSizeArgs{
+		Client:    client,
+		ProjectID: "proj1",
+		ServiceID: "svc1234567",
+		Window:    7 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("RecommendServiceSize() error = %v", err)
+	}
+	if rec.Action != SizeActionDownsize || rec.RecommendedCPUMillis != 1000 || rec.RecommendedMemoryGBs != 4 {
+		t.Errorf("RecommendServiceSize() = %+v", rec)
+	}
+	if rec.Window != "7d" || rec.CPU.Samples != 24 || rec.CPU.P95Utilization != 0.125 {
+		t.Errorf("RecommendServiceSize() usage = window %s, cpu %+v", rec.Window, rec.CPU)
+	}
+	if strings.Join(filters, ",") != "role=primary,role=primary" {
+		t.Errorf("metric requests used filters %v, want the primary only", filters)
+	}
+}
+
+func TestRecommendServiceSize_SharedResources(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(api.Service{ServiceID: "svc1234567"})
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = RecommendServiceSize(t.Context(), RecommendServiceSizeArgs{Client: client, ProjectID: "proj1", ServiceID: "svc1234567", Window: time.Hour})
+	if err == nil || !strings.Contains(err.Error(), "shared resources") {
+		t.Errorf("expected shared resources error, got %v", err)
+	}
+}
@@ -40,6 +40,11 @@ type WaitForServiceArgs struct {
 	Output     io.Writer
 	Timeout    time.Duration
 	TimeoutMsg string
+
+	// Progress, if set, is called with every status message shown next to the
+	// spinner (once per poll), e.g. to relay it as an MCP progress
+	// notification.
+	Progress func(message string)
 }
 
 func WaitForService(ctx context.Context, args WaitForServiceArgs) error {
@@ -64,6 +69,16 @@ func WaitForService(ctx context.Context, args WaitForServiceArgs) error {
 	})
 	defer spinner.Stop()
 
+	update := func(message string) {
+		spinner.Update(message)
+		if args.Progress != nil {
+			args.Progress(message)
+		}
+	}
+	if args.Progress != nil {
+		args.Progress(args.Handler.Message())
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -78,18 +93,18 @@ func WaitForService(ctx context.Context, args WaitForServiceArgs) error {
 		case <-ticker.C:
 			resp, err := args.Client.GetServiceWithResponse(ctx, args.ProjectID, args.ServiceID)
 			if err != nil {
-				spinner.Update(fmt.Sprintf("Error checking service status: %s", err))
+				update(fmt.Sprintf("Error checking service status: %s", err))
 				continue
 			}
 
 			if done, err := args.Handler.Check(resp); done {
 				return err
 			} else if err != nil {
-				spinner.Update(fmt.Sprintf("Error checking service status: %s", err))
+				update(fmt.Sprintf("Error checking service status: %s", err))
 				continue
 			}
 
-			spinner.Update(args.Handler.Message())
+			update(args.Handler.Message())
 		}
 	}
 }
@@ -6,9 +6,11 @@ import (
 	"io/fs"
 	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -25,7 +27,9 @@ const (
 	DefaultDocsMCP         = true
 	DefaultDocsMCPURL      = "https://mcp.tigerdata.com/docs?disabled_skills=ghost-database"
 	DefaultGatewayURL      = "https://console.cloud.tigerdata.com/api"
+	DefaultMCPConfirm      = true
 	DefaultMCPMaxRows      = 100
+	DefaultMCPSQLGuard     = "confirm"
 	DefaultOutput          = "table"
 	DefaultPasswordStorage = "keyring"
 	DefaultReadOnly        = false
@@ -39,20 +43,29 @@ const (
 )
 
 var defaultValues = map[string]any{
-	"analytics":        DefaultAnalytics,
-	"api_url":          DefaultAPIURL,
-	"color":            DefaultColor,
-	"console_url":      DefaultConsoleURL,
-	"docs_mcp":         DefaultDocsMCP,
-	"docs_mcp_url":     DefaultDocsMCPURL,
-	"gateway_url":      DefaultGatewayURL,
-	"mcp_max_rows":     DefaultMCPMaxRows,
-	"output":           DefaultOutput,
-	"password_storage": DefaultPasswordStorage,
-	"read_only":        DefaultReadOnly,
-	"releases_url":     DefaultReleasesURL,
-	"service_id":       "",
-	"version_check":    DefaultVersionCheck,
+	"analytics":            DefaultAnalytics,
+	"api_url":              DefaultAPIURL,
+	"color":                DefaultColor,
+	"console_url":          DefaultConsoleURL,
+	"credential_helper":    "",
+	"docs_mcp":             DefaultDocsMCP,
+	"docs_mcp_url":         DefaultDocsMCPURL,
+	"gateway_url":          DefaultGatewayURL,
+	"mcp_allowed_services": []string{},
+	"mcp_allowed_tools":    []string{},
+	"mcp_audit_log":        "",
+	"mcp_confirm":          DefaultMCPConfirm,
+	"mcp_denied_tools":     []string{},
+	"mcp_max_rows":         DefaultMCPMaxRows,
+	"mcp_proxies":          []MCPProxy{},
+	"mcp_sql_guard":        DefaultMCPSQLGuard,
+	"output":               DefaultOutput,
+	"password_storage":     DefaultPasswordStorage,
+	"profile":              DefaultProfile,
+	"read_only":            DefaultReadOnly,
+	"releases_url":         DefaultReleasesURL,
+	"service_id":           "",
+	"version_check":        DefaultVersionCheck,
 }
 
 // flagBindings maps CLI flag names to the config keys they override. Flags
@@ -63,26 +76,36 @@ var flagBindings = map[string]string{
 	"color":            "color",
 	"output":           "output",
 	"password-storage": "password_storage",
+	"profile":          "profile",
 	"service-id":       "service_id",
 }
 
 // Config holds the effective configuration for a single command invocation,
 // resolved through viper's normal precedence (flag > env > file > default).
 type Config struct {
-	APIURL          string `mapstructure:"api_url"`
-	Analytics       bool   `mapstructure:"analytics"`
-	Color           bool   `mapstructure:"color"`
-	ConsoleURL      string `mapstructure:"console_url"`
-	DocsMCP         bool   `mapstructure:"docs_mcp"`
-	DocsMCPURL      string `mapstructure:"docs_mcp_url"`
-	GatewayURL      string `mapstructure:"gateway_url"`
-	MCPMaxRows      int    `mapstructure:"mcp_max_rows"`
-	Output          string `mapstructure:"output"`
-	PasswordStorage string `mapstructure:"password_storage"`
-	ReadOnly        bool   `mapstructure:"read_only"`
-	ReleasesURL     string `mapstructure:"releases_url"`
-	ServiceID       string `mapstructure:"service_id"`
-	VersionCheck    bool   `mapstructure:"version_check"`
+	APIURL             string     `mapstructure:"api_url"`
+	Analytics          bool       `mapstructure:"analytics"`
+	Color              bool       `mapstructure:"color"`
+	ConsoleURL         string     `mapstructure:"console_url"`
+	CredentialHelper   string     `mapstructure:"credential_helper"`
+	DocsMCP            bool       `mapstructure:"docs_mcp"`
+	DocsMCPURL         string     `mapstructure:"docs_mcp_url"`
+	GatewayURL         string     `mapstructure:"gateway_url"`
+	MCPAllowedServices []string   `mapstructure:"mcp_allowed_services"`
+	MCPAllowedTools    []string   `mapstructure:"mcp_allowed_tools"`
+	MCPAuditLog        string     `mapstructure:"mcp_audit_log"`
+	MCPConfirm         bool       `mapstructure:"mcp_confirm"`
+	MCPDeniedTools     []string   `mapstructure:"mcp_denied_tools"`
+	MCPMaxRows         int        `mapstructure:"mcp_max_rows"`
+	MCPProxies         []MCPProxy `mapstructure:"mcp_proxies"`
+	MCPSQLGuard        string     `mapstructure:"mcp_sql_guard"`
+	Output             string     `mapstructure:"output"`
+	PasswordStorage    string     `mapstructure:"password_storage"`
+	Profile            string     `mapstructure:"profile"`
+	ReadOnly           bool       `mapstructure:"read_only"`
+	ReleasesURL        string     `mapstructure:"releases_url"`
+	ServiceID          string     `mapstructure:"service_id"`
+	VersionCheck       bool       `mapstructure:"version_check"`
 
 	ConfigDir string         `mapstructure:"-"`
 	flags     *pflag.FlagSet `mapstructure:"-"`
@@ -91,21 +114,30 @@ type Config struct {
 // ConfigOutput is the shape `tiger config show` renders. Every field is a
 // pointer so unset values can be omitted when defaults are suppressed.
 type ConfigOutput struct {
-	APIURL          *string `mapstructure:"api_url" json:"api_url,omitempty"`
-	Analytics       *bool   `mapstructure:"analytics" json:"analytics,omitempty"`
-	Color           *bool   `mapstructure:"color" json:"color,omitempty"`
-	ConfigDir       *string `mapstructure:"-" json:"config_dir,omitempty"`
-	ConsoleURL      *string `mapstructure:"console_url" json:"console_url,omitempty"`
-	DocsMCP         *bool   `mapstructure:"docs_mcp" json:"docs_mcp,omitempty"`
-	DocsMCPURL      *string `mapstructure:"docs_mcp_url" json:"docs_mcp_url,omitempty"`
-	GatewayURL      *string `mapstructure:"gateway_url" json:"gateway_url,omitempty"`
-	MCPMaxRows      *int    `mapstructure:"mcp_max_rows" json:"mcp_max_rows,omitempty"`
-	Output          *string `mapstructure:"output" json:"output,omitempty"`
-	PasswordStorage *string `mapstructure:"password_storage" json:"password_storage,omitempty"`
-	ReadOnly        *bool   `mapstructure:"read_only" json:"read_only,omitempty"`
-	ReleasesURL     *string `mapstructure:"releases_url" json:"releases_url,omitempty"`
-	ServiceID       *string `mapstructure:"service_id" json:"service_id,omitempty"`
-	VersionCheck    *bool   `mapstructure:"version_check" json:"version_check,omitempty"`
+	APIURL             *string     `mapstructure:"api_url" json:"api_url,omitempty"`
+	Analytics          *bool       `mapstructure:"analytics" json:"analytics,omitempty"`
+	Color              *bool       `mapstructure:"color" json:"color,omitempty"`
+	ConfigDir          *string     `mapstructure:"-" json:"config_dir,omitempty"`
+	ConsoleURL         *string     `mapstructure:"console_url" json:"console_url,omitempty"`
+	CredentialHelper   *string     `mapstructure:"credential_helper" json:"credential_helper,omitempty"`
+	DocsMCP            *bool       `mapstructure:"docs_mcp" json:"docs_mcp,omitempty"`
+	DocsMCPURL         *string     `mapstructure:"docs_mcp_url" json:"docs_mcp_url,omitempty"`
+	GatewayURL         *string     `mapstructure:"gateway_url" json:"gateway_url,omitempty"`
+	MCPAllowedServices *[]string   `mapstructure:"mcp_allowed_services" json:"mcp_allowed_services,omitempty"`
+	MCPAllowedTools    *[]string   `mapstructure:"mcp_allowed_tools" json:"mcp_allowed_tools,omitempty"`
+	MCPAuditLog        *string     `mapstructure:"mcp_audit_log" json:"mcp_audit_log,omitempty"`
+	MCPConfirm         *bool       `mapstructure:"mcp_confirm" json:"mcp_confirm,omitempty"`
+	MCPDeniedTools     *[]string   `mapstructure:"mcp_denied_tools" json:"mcp_denied_tools,omitempty"`
+	MCPMaxRows         *int        `mapstructure:"mcp_max_rows" json:"mcp_max_rows,omitempty"`
+	MCPProxies         *[]MCPProxy `mapstructure:"mcp_proxies" json:"mcp_proxies,omitempty"`
+	MCPSQLGuard        *string     `mapstructure:"mcp_sql_guard" json:"mcp_sql_guard,omitempty"`
+	Output             *string     `mapstructure:"output" json:"output,omitempty"`
+	PasswordStorage    *string     `mapstructure:"password_storage" json:"password_storage,omitempty"`
+	Profile            *string     `mapstructure:"profile" json:"profile,omitempty"`
+	ReadOnly           *bool       `mapstructure:"read_only" json:"read_only,omitempty"`
+	ReleasesURL        *string     `mapstructure:"releases_url" json:"releases_url,omitempty"`
+	ServiceID          *string     `mapstructure:"service_id" json:"service_id,omitempty"`
+	VersionCheck       *bool       `mapstructure:"version_check" json:"version_check,omitempty"`
 }
 
 // Load creates a new Config instance. The provided flag set is used to resolve
@@ -125,8 +157,10 @@ func Load(flags *pflag.FlagSet) (*Config, error) {
 
 // LoadForOutput loads config values for display purposes using a fresh viper
 // instance, independent of CLI flags. This keeps `tiger config show -o json`
-// from reporting the flag's format as the configured `output` value.
-func LoadForOutput(configDir string, withEnv bool, noDefaults bool) (*ConfigOutput, error) {
+// from reporting the flag's format as the configured `output` value. The
+// given profile's overrides are merged over the base config file; an empty
+// profile uses the one selected in the file (or environment, with withEnv).
+func LoadForOutput(configDir, profile string, withEnv bool, noDefaults bool) (*ConfigOutput, error) {
 	v := viper.New()
 	v.SetConfigFile(GetConfigFile(configDir))
 
@@ -140,10 +174,21 @@ func LoadForOutput(configDir string, withEnv bool, noDefaults bool) (*ConfigOutp
 	if err := readInConfig(v); err != nil {
 		return nil, err
 	}
+	if profile == "" {
+		profile = v.GetString("profile")
+	}
+	if profile != "" {
+		if err := ValidateProfileName(profile); err != nil {
+			return nil, err
+		}
+		if err := mergeProfileConfig(v, configDir, profile); err != nil {
+			return nil, err
+		}
+	}
 	migrateVersionCheck(v)
 
 	cfg := &ConfigOutput{ConfigDir: &configDir}
-	if err := v.Unmarshal(cfg); err != nil {
+	if err := v.Unmarshal(cfg, viper.DecodeHook(decodeHook)); err != nil {
 		return nil, fmt.Errorf("error unmarshaling config for output: %w", err)
 	}
 
@@ -151,8 +196,10 @@ func LoadForOutput(configDir string, withEnv bool, noDefaults bool) (*ConfigOutp
 }
 
 // reload reads the config file and resolves effective values through viper's
-// normal precedence (flag > env > file > default). Called by Load for the
-// initial load, and by Set/Unset/Reset after writing the config file.
+// normal precedence (flag > env > file > default). The active profile is
+// resolved first, and its config overrides are merged over the base file.
+// Called by Load for the initial load, and by Set/Unset/Reset after writing
+// the config file.
 func (c *Config) reload() error {
 	v := viper.New()
 	v.SetConfigFile(c.GetConfigFile())
@@ -166,11 +213,21 @@ func (c *Config) reload() error {
 	if err := readInConfig(v); err != nil {
 		return err
 	}
+
+	// A profile's own config file can't change which profile is active
+	profile := v.GetString("profile")
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+	if err := mergeProfileConfig(v, c.ConfigDir, profile); err != nil {
+		return err
+	}
 	migrateVersionCheck(v)
 
-	if err := v.Unmarshal(c); err != nil {
+	if err := v.Unmarshal(c, viper.DecodeHook(decodeHook)); err != nil {
 		return fmt.Errorf("error unmarshaling config: %w", err)
 	}
+	c.Profile = profile
 	return nil
 }
 
@@ -181,8 +238,8 @@ func (c *Config) Set(key, value string) error {
 		return err
 	}
 
-	// Write to config file
-	configFile, err := c.EnsureConfigDir()
+	// Write to the active profile's config file
+	configFile, err := c.ensureConfigFileFor(key)
 	if err != nil {
 		return err
 	}
@@ -201,7 +258,7 @@ func (c *Config) Set(key, value string) error {
 }
 
 func (c *Config) Unset(key string) error {
-	configFile, err := c.EnsureConfigDir()
+	configFile, err := c.ensureConfigFileFor(key)
 	if err != nil {
 		return err
 	}
@@ -233,8 +290,10 @@ func (c *Config) Unset(key string) error {
 	return c.reload()
 }
 
+// Reset clears the active profile's config file. For the default profile this
+// is the base config file, which also clears the profile selection.
 func (c *Config) Reset() error {
-	configFile, err := c.EnsureConfigDir()
+	configFile, err := c.ensureConfigFileFor("")
 	if err != nil {
 		return err
 	}
@@ -259,6 +318,17 @@ func (c *Config) GetConfigFile() string {
 	return GetConfigFile(c.ConfigDir)
 }
 
+// ensureConfigFileFor returns the config file that writes to key should go
+// to, creating its directory if needed. Values are written to the active
+// profile's overrides file, except the profile selection itself, which always
+// lives in the base config file.
+func (c *Config) ensureConfigFileFor(key string) (string, error) {
+	if key == "profile" {
+		return c.EnsureConfigDir()
+	}
+	return ensureConfigDir(GetProfileDir(c.ConfigDir, c.Profile))
+}
+
 func ValidConfigOptions() []string {
 	return slices.Collect(maps.Keys(defaultValues))
 }
@@ -360,20 +430,34 @@ func migrateVersionCheck(v *viper.Viper) {
 // the converted value suitable for writing to the config file.
 func validateValue(key, value string) (any, error) {
 	switch key {
-	case "api_url", "console_url", "docs_mcp_url", "gateway_url", "releases_url", "service_id":
+	case "api_url", "console_url", "credential_helper", "docs_mcp_url", "gateway_url", "mcp_audit_log", "releases_url", "service_id":
 		return value, nil
-	case "analytics", "color", "docs_mcp", "read_only", "version_check":
+	case "analytics", "color", "docs_mcp", "mcp_confirm", "read_only", "version_check":
 		return parseBool(key, value)
+	case "mcp_allowed_services", "mcp_allowed_tools", "mcp_denied_tools":
+		return parsePatternList(key, value)
 	case "mcp_max_rows":
 		return parsePositiveInt(key, value)
+	case "mcp_proxies":
+		return parseMCPProxies(value)
 	case "output":
 		if err := ValidateOutputFormat(value); err != nil {
 			return nil, err
 		}
 		return value, nil
+	case "mcp_sql_guard":
+		if value != "confirm" && value != "reject" && value != "off" {
+			return nil, fmt.Errorf("invalid mcp_sql_guard value: %s (must be confirm, reject, or off)", value)
+		}
+		return value, nil
+	case "profile":
+		if err := ValidateProfileName(value); err != nil {
+			return nil, err
+		}
+		return value, nil
 	case "password_storage":
-		if value != "keyring" && value != "pgpass" && value != "none" {
-			return nil, fmt.Errorf("invalid password_storage value: %s (must be keyring, pgpass, or none)", value)
+		if value != "keyring" && value != "pgpass" && value != "exec" && value != "none" {
+			return nil, fmt.Errorf("invalid password_storage value: %s (must be keyring, pgpass, exec, or none)", value)
 		}
 		return value, nil
 	default:
@@ -400,6 +484,23 @@ func parsePositiveInt(key, value string) (int, error) {
 	return n, nil
 }
 
+// parsePatternList parses a comma-separated list of glob patterns (as matched
+// by path.Match), dropping empty entries. An empty value yields an empty list.
+func parsePatternList(key, value string) ([]string, error) {
+	patterns := []string{}
+	for pattern := range strings.SplitSeq(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern: %s (%w)", key, pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
 // UseTestConfig writes only the specified key-value pairs to the config file in
 // the given directory and returns a Config instance loaded from it.
 // This function is intended for testing purposes only, where you need to set up
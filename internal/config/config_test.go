@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -200,6 +201,39 @@ func TestLoad_FromEnvironmentVariables(t *testing.T) {
 	}
 }
 
+func TestLoad_MCPProxiesFromEnvironment(t *testing.T) {
+	tmpDir := setupTestConfig(t)
+	t.Setenv("TIGER_CONFIG_DIR", tmpDir)
+	t.Setenv("TIGER_MCP_PROXIES", `[{"url": "https://runbooks.example.com/mcp", "tool_prefix": "runbook"}]`)
+	// Plain lists still split on commas alongside the JSON hook
+	t.Setenv("TIGER_MCP_DENIED_TOOLS", "service_stop,service_resize")
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load(nil) failed: %v", err)
+	}
+
+	if len(cfg.MCPProxies) != 1 || cfg.MCPProxies[0].URL != "https://runbooks.example.com/mcp" || cfg.MCPProxies[0].ToolPrefix != "runbook" {
+		t.Errorf("MCPProxies = %+v", cfg.MCPProxies)
+	}
+	if !slices.Equal(cfg.MCPDeniedTools, []string{"service_stop", "service_resize"}) {
+		t.Errorf("MCPDeniedTools = %v", cfg.MCPDeniedTools)
+	}
+}
+
+func TestMCPProxyExpandedHeaders(t *testing.T) {
+	t.Setenv("RUNBOOK_TOKEN", "s3cret")
+	proxy := MCPProxy{Headers: map[string]string{"Authorization": "Bearer ${RUNBOOK_TOKEN}", "X-Team": "sre"}}
+
+	headers := proxy.ExpandedHeaders()
+	if headers["Authorization"] != "Bearer s3cret" || headers["X-Team"] != "sre" {
+		t.Errorf("ExpandedHeaders() = %v", headers)
+	}
+	if proxy.Headers["Authorization"] != "Bearer ${RUNBOOK_TOKEN}" {
+		t.Error("ExpandedHeaders() must not modify the configured headers")
+	}
+}
+
 func TestLoad_Precedence(t *testing.T) {
 	tmpDir := setupTestConfig(t)
 
@@ -409,6 +443,70 @@ func TestSet(t *testing.T) {
 			value:         "notanumber",
 			expectedError: true,
 		},
+		{
+			key:   "mcp_denied_tools",
+			value: "service_*, db_execute_query,",
+			checkFunc: func() bool {
+				return slices.Equal(cfg.MCPDeniedTools, []string{"service_*", "db_execute_query"})
+			},
+		},
+		{
+			key:   "mcp_allowed_services",
+			value: "",
+			checkFunc: func() bool {
+				return len(cfg.MCPAllowedServices) == 0
+			},
+		},
+		{
+			key:           "mcp_allowed_tools",
+			value:         "service_[",
+			expectedError: true,
+		},
+		{
+			key:   "mcp_sql_guard",
+			value: "reject",
+			checkFunc: func() bool {
+				return cfg.MCPSQLGuard == "reject"
+			},
+		},
+		{
+			key:           "mcp_sql_guard",
+			value:         "sometimes",
+			expectedError: true,
+		},
+		{
+			key:   "mcp_proxies",
+			value: `[{"url": "https://runbooks.example.com/mcp", "tool_prefix": "runbook", "headers": {"Authorization": "Bearer ${RUNBOOK_TOKEN}"}}, {"url": "http://localhost:9000/mcp", "tool_prefix": "local", "enabled": false}]`,
+			checkFunc: func() bool {
+				return len(cfg.MCPProxies) == 2 &&
+					cfg.MCPProxies[0].ToolPrefix == "runbook" &&
+					// viper lowercases map keys; header names are case-insensitive
+					cfg.MCPProxies[0].Headers["authorization"] == "Bearer ${RUNBOOK_TOKEN}" &&
+					cfg.MCPProxies[0].IsEnabled() &&
+					cfg.MCPProxies[1].URL == "http://localhost:9000/mcp" &&
+					!cfg.MCPProxies[1].IsEnabled()
+			},
+		},
+		{
+			key:           "mcp_proxies",
+			value:         `[{"url": "ftp://example.com", "tool_prefix": "x"}]`,
+			expectedError: true,
+		},
+		{
+			key:           "mcp_proxies",
+			value:         `[{"url": "https://example.com/mcp"}]`,
+			expectedError: true,
+		},
+		{
+			key:           "mcp_proxies",
+			value:         `[{"url": "https://a.example.com", "tool_prefix": "x"}, {"url": "https://b.example.com", "tool_prefix": "x"}]`,
+			expectedError: true,
+		},
+		{
+			key:           "mcp_proxies",
+			value:         "not json",
+			expectedError: true,
+		},
 		{
 			key:           "unknown_key",
 			value:         "value",
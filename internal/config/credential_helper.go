@@ -0,0 +1,108 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// credentialHelperTimeout bounds each helper invocation. It is generous since
+// helpers backed by password managers may wait for the user to unlock them.
+const credentialHelperTimeout = 2 * time.Minute
+
+// ErrCredentialNotFound is returned by CredentialHelper.Get when the helper
+// has no secret for the requested account.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialHelper stores secrets through an external command, in the spirit
+// of git credential helpers. The command is run through the shell with the
+// action (get, store, or erase) appended as its last argument, and is given
+// key=value lines on stdin, terminated by a blank line:
+//
+//	service=tiger-cli
+//	account=credentials
+//	secret=...
+//
+// The secret line is only sent to store. In response to get, the helper
+// prints a secret=... line on stdout; printing nothing means there is no
+// secret for the account. A non-zero exit status is treated as an error, and
+// the helper's stderr is passed through so it can prompt or report problems.
+type CredentialHelper struct {
+	Command string
+}
+
+// Get returns the secret stored for account, or ErrCredentialNotFound.
+func (h CredentialHelper) Get(account string) (string, error) {
+	output, err := h.run("get", account, "")
+	if err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSuffix(scanner.Text(), "\r"), "=")
+		if ok && key == "secret" && value != "" {
+			return value, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read credential helper output: %w", err)
+	}
+	return "", ErrCredentialNotFound
+}
+
+// Store saves secret for account, replacing any previous secret.
+func (h CredentialHelper) Store(account, secret string) error {
+	if strings.ContainsAny(secret, "\r\n") {
+		return errors.New("secrets containing newlines cannot be passed to a credential helper")
+	}
+	_, err := h.run("store", account, secret)
+	return err
+}
+
+// Erase removes the secret for account. Erasing a missing secret is not an
+// error, as long as the helper exits successfully.
+func (h CredentialHelper) Erase(account string) error {
+	_, err := h.run("erase", account, "")
+	return err
+}
+
+func (h CredentialHelper) run(action, account, secret string) ([]byte, error) {
+	if h.Command == "" {
+		return nil, errors.New("no credential helper configured (set credential_helper)")
+	}
+
+	var input strings.Builder
+	fmt.Fprintf(&input, "service=%s\n", GetServiceName())
+	fmt.Fprintf(&input, "account=%s\n", account)
+	if secret != "" {
+		fmt.Fprintf(&input, "secret=%s\n", secret)
+	}
+	input.WriteString("\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialHelperTimeout)
+	defer cancel()
+
+	command := h.Command + " " + action
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.Stdin = strings.NewReader(input.String())
+	c.Stderr = os.Stderr
+
+	output, err := c.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential helper %s failed: %w", action, err)
+	}
+	return output, nil
+}
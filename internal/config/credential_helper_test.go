@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeCredentialHelperScript stores each account's secret in a file next to
+// the script, implementing the get/store/erase protocol
+const fakeCredentialHelperScript = `#!/bin/sh
+store="$(dirname "$0")/store"
+mkdir -p "$store"
+while IFS='=' read -r key value; do
+	[ -z "$key" ] && break
+	case "$key" in
+	service) service=$value ;;
+	account) account=$value ;;
+	secret) secret=$value ;;
+	esac
+done
+[ -n "$service" ] || { echo "missing service" >&2; exit 1; }
+case "$1" in
+get) [ -f "$store/$account" ] && printf 'secret=%s\n' "$(cat "$store/$account")" ;;
+store) printf '%s' "$secret" > "$store/$account" ;;
+erase) rm -f "$store/$account" ;;
+*) exit 1 ;;
+esac
+exit 0
+`
+
+// writeFakeCredentialHelper writes the fake helper to a temp directory and
+// returns its command and store directory
+func writeFakeCredentialHelper(t *testing.T) (string, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake credential helper requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	script := filepath.Join(dir, "helper.sh")
+	if err := os.WriteFile(script, []byte(fakeCredentialHelperScript), 0o755); err != nil {
+		t.Fatalf("Failed to write fake credential helper: %v", err)
+	}
+	return script, filepath.Join(dir, "store")
+}
+
+func TestCredentialHelper(t *testing.T) {
+	SetTestServiceName(t)
+	command, store := writeFakeCredentialHelper(t)
+	helper := CredentialHelper{Command: command}
+
+	if _, err := helper.Get("account-1"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected ErrCredentialNotFound before storing, got %v", err)
+	}
+
+	if err := helper.Store("account-1", `{"api_key":"a=b:c"}`); err != nil {
+		t.Fatalf("Store() failed: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(store, "account-1")); err != nil || string(data) != `{"api_key":"a=b:c"}` {
+		t.Errorf("Expected the helper to store the secret, got %q (err: %v)", data, err)
+	}
+
+	secret, err := helper.Get("account-1")
+	if err != nil {
+		t.Fatalf("Get() failed: %v", err)
+	}
+	if secret != `{"api_key":"a=b:c"}` {
+		t.Errorf("Expected stored secret, got %q", secret)
+	}
+
+	if err := helper.Erase("account-1"); err != nil {
+		t.Fatalf("Erase() failed: %v", err)
+	}
+	if _, err := helper.Get("account-1"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected ErrCredentialNotFound after erasing, got %v", err)
+	}
+
+	if err := helper.Store("account-1", "multi\nline"); err == nil {
+		t.Error("Expected Store() to reject secrets containing newlines")
+	}
+}
+
+func TestCredentialHelper_Failure(t *testing.T) {
+	SetTestServiceName(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("test command requires a POSIX shell")
+	}
+
+	helper := CredentialHelper{Command: "exit 3;"}
+	if _, err := helper.Get("account-1"); err == nil || errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Expected a failing helper to return an error, got %v", err)
+	}
+	if err := (CredentialHelper{}).Store("account-1", "secret"); err == nil {
+		t.Error("Expected an error when no helper is configured")
+	}
+}
+
+func TestStoredCredentials_CredentialHelper(t *testing.T) {
+	tmpDir, cfg := setupCredentialTest(t)
+	command, store := writeFakeCredentialHelper(t)
+	cfg.CredentialHelper = command
+
+	if err := cfg.StoreCredentials("public:secret", "project123"); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(store, keyringUsername)); err != nil {
+		t.Errorf("Expected credentials to be stored by the helper: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "credentials")); !os.IsNotExist(err) {
+		t.Errorf("Expected no credentials file, got err: %v", err)
+	}
+
+	creds, err := cfg.GetStoredCredentials()
+	if err != nil {
+		t.Fatalf("Failed to get credentials: %v", err)
+	}
+	if creds.APIKey != "public:secret" || creds.ProjectID != "project123" {
+		t.Errorf("Unexpected credentials: %+v", creds)
+	}
+
+	// Profiles use their own helper account
+	if _, err := cfg.WithProfile("staging").GetStoredCredentials(); !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("Expected ErrNotLoggedIn for another profile, got %v", err)
+	}
+
+	if err := cfg.RemoveCredentials(); err != nil {
+		t.Fatalf("Failed to remove credentials: %v", err)
+	}
+	if _, err := cfg.GetStoredCredentials(); !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("Expected ErrNotLoggedIn after removal, got %v", err)
+	}
+}
@@ -65,7 +65,16 @@ func SetTestServiceName(t *testing.T) {
 }
 
 func (c *Config) credentialsFileName() string {
-	return fmt.Sprintf("%s/credentials", c.ConfigDir)
+	return filepath.Join(GetProfileDir(c.ConfigDir, c.Profile), "credentials")
+}
+
+// keyringUser returns the keyring username the active profile's credentials
+// are stored under. The default profile keeps the original username.
+func (c *Config) keyringUser() string {
+	if c.Profile == "" || c.Profile == DefaultProfile {
+		return keyringUsername
+	}
+	return keyringUsername + ":" + c.Profile
 }
 
 // StoreCredentials stores a PAT credential.
@@ -109,17 +118,33 @@ func (c *Config) storeCredentials(creds storedCredentials) error {
 		return fmt.Errorf("failed to marshal credentials: %w", err)
 	}
 
-	if err := storeToKeyring(string(credentialsJSON)); err == nil {
+	// Named profiles are listed from their directories, so make sure one
+	// exists even when the credentials end up in the keyring
+	if err := os.MkdirAll(GetProfileDir(c.ConfigDir, c.Profile), 0755); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+
+	// A configured credential helper replaces the keyring and file fallback
+	if c.CredentialHelper != "" {
+		return c.credentialHelper().Store(c.keyringUser(), string(credentialsJSON))
+	}
+
+	if err := c.storeToKeyring(string(credentialsJSON)); err == nil {
 		return nil
 	}
 	return c.storeToFile(string(credentialsJSON))
 }
 
-func storeToKeyring(credentials string) error {
-	return keyring.Set(GetServiceName(), keyringUsername, credentials)
+func (c *Config) credentialHelper() CredentialHelper {
+	return CredentialHelper{Command: c.CredentialHelper}
+}
+
+func (c *Config) storeToKeyring(credentials string) error {
+	return keyring.Set(GetServiceName(), c.keyringUser(), credentials)
 }
 
-// storeToFile stores credentials to ~/.config/tiger/credentials with restricted permissions
+// storeToFile stores credentials to ~/.config/tiger/credentials (or the
+// profile's directory) with restricted permissions
 func (c *Config) storeToFile(credentials string) error {
 	credentialsFile := c.credentialsFileName()
 	if err := os.MkdirAll(filepath.Dir(credentialsFile), 0755); err != nil {
@@ -169,9 +194,18 @@ func (c *Config) GetStoredCredentials() (*Credentials, error) {
 	}
 }
 
-// loadCredentialsBlob returns the raw JSON blob from keyring or file fallback.
+// loadCredentialsBlob returns the raw JSON blob from the credential helper,
+// or from keyring or file fallback.
 func (c *Config) loadCredentialsBlob() (string, error) {
-	if blob, err := keyring.Get(GetServiceName(), keyringUsername); err == nil {
+	if c.CredentialHelper != "" {
+		blob, err := c.credentialHelper().Get(c.keyringUser())
+		if errors.Is(err, ErrCredentialNotFound) {
+			return "", ErrNotLoggedIn
+		}
+		return blob, err
+	}
+
+	if blob, err := keyring.Get(GetServiceName(), c.keyringUser()); err == nil {
 		if blob == "" {
 			return "", ErrNotLoggedIn
 		}
@@ -192,16 +226,23 @@ func (c *Config) loadCredentialsBlob() (string, error) {
 	return string(data), nil
 }
 
-// RemoveCredentials removes stored credentials from keyring and file fallback
+// RemoveCredentials removes stored credentials from the credential helper (if
+// configured), keyring, and file fallback
 func (c *Config) RemoveCredentials() error {
+	if c.CredentialHelper != "" {
+		if err := c.credentialHelper().Erase(c.keyringUser()); err != nil {
+			return err
+		}
+	}
+
 	// Remove from keyring (ignore errors as it might not exist)
-	removeCredentialsFromKeyring()
+	c.removeCredentialsFromKeyring()
 	return c.removeCredentialsFile()
 }
 
 // removeCredentialsFromKeyring removes credentials from keyring (test helper)
-func removeCredentialsFromKeyring() {
-	keyring.Delete(GetServiceName(), keyringUsername)
+func (c *Config) removeCredentialsFromKeyring() {
+	keyring.Delete(GetServiceName(), c.keyringUser())
 }
 
 // removeCredentialsFile removes credentials file
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+// MCPProxy is an extra upstream MCP server that `tiger mcp start` proxies
+// alongside the docs MCP server, configured through the mcp_proxies list.
+type MCPProxy struct {
+	// URL is the upstream's streamable HTTP endpoint.
+	URL string `mapstructure:"url" json:"url" yaml:"url"`
+	// Headers are sent with every request to the upstream. Values may
+	// reference environment variables as $VAR or ${VAR}, so secrets don't
+	// have to be stored in the config file. Names may come back lowercased
+	// from the config file; header names are case-insensitive.
+	Headers map[string]string `mapstructure:"headers" json:"headers,omitempty" yaml:"headers,omitempty"`
+	// ToolPrefix namespaces the upstream's tools and prompts: an upstream
+	// tool named "search" is exposed as "<tool_prefix>_search". Entries
+	// whose prefixed name is already taken by a built-in tool or prompt
+	// (e.g. "service_delete" for the prefix "service") are skipped.
+	ToolPrefix string `mapstructure:"tool_prefix" json:"tool_prefix" yaml:"tool_prefix"`
+	// Enabled turns the upstream off without removing its entry. Omitted
+	// means enabled.
+	Enabled *bool `mapstructure:"enabled" json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// ReadOnly declares that the upstream exposes no tools that modify
+	// anything. Only upstreams with it set are proxied when the read_only
+	// config option is on, since Tiger can't tell which of an arbitrary
+	// upstream's tools are writes.
+	ReadOnly bool `mapstructure:"read_only" json:"read_only,omitempty" yaml:"read_only,omitempty"`
+}
+
+// IsEnabled reports whether the upstream should be proxied.
+func (p MCPProxy) IsEnabled() bool {
+	return p.Enabled == nil || *p.Enabled
+}
+
+// ExpandedHeaders returns Headers with environment variable references in
+// their values expanded.
+func (p MCPProxy) ExpandedHeaders() map[string]string {
+	headers := make(map[string]string, len(p.Headers))
+	for name, value := range p.Headers {
+		headers[name] = os.ExpandEnv(value)
+	}
+	return headers
+}
+
+var toolPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ValidateMCPProxies checks that every entry has an http(s) URL and a tool
+// prefix, and that no two entries share a prefix (their tools would collide).
+func ValidateMCPProxies(proxies []MCPProxy) error {
+	prefixes := make(map[string]bool, len(proxies))
+	for i, proxy := range proxies {
+		u, err := url.Parse(proxy.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid mcp_proxies entry %d: url must be an http or https URL, got %q", i, proxy.URL)
+		}
+		if !toolPrefixPattern.MatchString(proxy.ToolPrefix) {
+			return fmt.Errorf("invalid mcp_proxies entry %d: tool_prefix must be letters, digits, '_', or '-', got %q", i, proxy.ToolPrefix)
+		}
+		if prefixes[proxy.ToolPrefix] {
+			return fmt.Errorf("invalid mcp_proxies entry %d: tool_prefix %q is used by another entry", i, proxy.ToolPrefix)
+		}
+		prefixes[proxy.ToolPrefix] = true
+	}
+	return nil
+}
+
+// parseMCPProxies parses the JSON array accepted by `tiger config set
+// mcp_proxies`. The result is returned as generic maps so viper writes the
+// config file with the same keys it reads.
+func parseMCPProxies(value string) ([]map[string]any, error) {
+	if strings.TrimSpace(value) == "" {
+		return []map[string]any{}, nil
+	}
+
+	var proxies []MCPProxy
+	if err := json.Unmarshal([]byte(value), &proxies); err != nil {
+		return nil, fmt.Errorf("invalid mcp_proxies value: must be a JSON array of {\"url\", \"tool_prefix\", \"headers\", \"enabled\", \"read_only\"} objects: %w", err)
+	}
+	if err := ValidateMCPProxies(proxies); err != nil {
+		return nil, err
+	}
+
+	entries := make([]map[string]any, len(proxies))
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		return nil, fmt.Errorf("invalid mcp_proxies value: %w", err)
+	}
+	return entries, nil
+}
+
+// jsonStringToMCPProxiesHook decodes a JSON string into []MCPProxy, which is
+// how the list arrives from the TIGER_MCP_PROXIES environment variable.
+func jsonStringToMCPProxiesHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeFor[[]MCPProxy]() {
+		return data, nil
+	}
+	raw := data.(string)
+	if strings.TrimSpace(raw) == "" {
+		return []MCPProxy{}, nil
+	}
+	var proxies []MCPProxy
+	if err := json.Unmarshal([]byte(raw), &proxies); err != nil {
+		return nil, fmt.Errorf("invalid mcp_proxies value: must be a JSON array: %w", err)
+	}
+	return proxies, nil
+}
+
+// decodeHook extends viper's default decode hooks with support for the
+// mcp_proxies JSON environment variable.
+var decodeHook = mapstructure.ComposeDecodeHookFunc(
+	jsonStringToMCPProxiesHook,
+	mapstructure.StringToTimeDurationHookFunc(),
+	mapstructure.StringToWeakSliceHookFunc(","),
+)
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultProfile is the profile used when none is selected. Its config and
+// credentials live directly in the config directory, so setups from before
+// profiles existed keep working unchanged.
+const DefaultProfile = "default"
+
+// profilesDirName is the config directory subdirectory holding one directory
+// per named profile, each with its own config.yaml overrides and credentials
+// file fallback.
+const profilesDirName = "profiles"
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ValidateProfileName returns an error if name cannot be used as a profile
+// name. Names become directory names and keyring usernames, so they are
+// restricted to letters, digits, '-' and '_'.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name: %q (must start with a letter or digit and contain only letters, digits, '-' and '_')", name)
+	}
+	return nil
+}
+
+// GetProfileDir returns the directory holding a profile's config overrides
+// and credentials file. The default profile uses the config directory itself.
+func GetProfileDir(configDir, profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return configDir
+	}
+	return filepath.Join(configDir, profilesDirName, profile)
+}
+
+// ListProfiles returns the names of all profiles in the config directory,
+// sorted, always including the default profile. A profile exists once its
+// directory has been created, e.g. by `tiger auth login --profile <name>` or
+// `tiger config set --profile <name>`.
+func ListProfiles(configDir string) ([]string, error) {
+	profiles := []string{DefaultProfile}
+
+	entries, err := os.ReadDir(filepath.Join(configDir, profilesDirName))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil && entry.Name() != DefaultProfile {
+			profiles = append(profiles, entry.Name())
+		}
+	}
+
+	slices.Sort(profiles)
+	return profiles, nil
+}
+
+// ProfileExists reports whether the named profile exists in the config
+// directory. The default profile always exists.
+func ProfileExists(configDir, profile string) (bool, error) {
+	profiles, err := ListProfiles(configDir)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(profiles, profile), nil
+}
+
+// WithProfile returns a copy of the config that reads and writes the named
+// profile's credentials. Config values are not reloaded, so it is only meant
+// for credential operations on a profile other than the active one.
+func (c *Config) WithProfile(profile string) *Config {
+	cp := *c
+	cp.Profile = profile
+	return &cp
+}
+
+// DeleteProfile removes a named profile's credentials and config overrides.
+// The default profile cannot be deleted. If the deleted profile is the one
+// selected in the config file, the selection reverts to the default profile.
+func (c *Config) DeleteProfile(profile string) error {
+	if profile == DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be deleted", DefaultProfile)
+	}
+	if err := ValidateProfileName(profile); err != nil {
+		return err
+	}
+
+	if err := c.WithProfile(profile).RemoveCredentials(); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(GetProfileDir(c.ConfigDir, profile)); err != nil {
+		return fmt.Errorf("failed to remove profile directory: %w", err)
+	}
+
+	// Only the base config file's selection is reverted; a --profile flag or
+	// TIGER_PROFILE env var naming the deleted profile is left to the caller
+	selected, err := SelectedProfile(c.ConfigDir)
+	if err != nil {
+		return err
+	}
+	if selected == profile {
+		return c.Unset("profile")
+	}
+	return nil
+}
+
+// SelectedProfile returns the profile selected in the base config file (see
+// `tiger profile use`), ignoring the --profile flag and TIGER_PROFILE.
+func SelectedProfile(configDir string) (string, error) {
+	v := viper.New()
+	v.SetConfigFile(GetConfigFile(configDir))
+	v.SetDefault("profile", DefaultProfile)
+	if err := readInConfig(v); err != nil {
+		return "", err
+	}
+	return v.GetString("profile"), nil
+}
+
+// mergeProfileConfig merges the named profile's config overrides on top of
+// the base config file already read into v. A missing overrides file is not
+// an error.
+func mergeProfileConfig(v *viper.Viper, configDir, profile string) error {
+	if profile == DefaultProfile {
+		return nil
+	}
+
+	v.SetConfigFile(GetConfigFile(GetProfileDir(configDir, profile)))
+	if err := v.MergeInConfig(); err != nil &&
+		!errors.As(err, &viper.ConfigFileNotFoundError{}) &&
+		!errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeProfileConfig(t *testing.T, configDir, profile, content string) {
+	t.Helper()
+
+	dir := GetProfileDir(configDir, profile)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("Failed to create profile directory: %v", err)
+	}
+	if err := os.WriteFile(GetConfigFile(dir), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write profile config: %v", err)
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	for _, name := range []string{"default", "staging", "prod-eu", "team_1", "2"} {
+		if err := ValidateProfileName(name); err != nil {
+			t.Errorf("ValidateProfileName(%q) returned error: %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-prod", "../prod", "prod/eu", "prod eu", "prod:eu"} {
+		if err := ValidateProfileName(name); err == nil {
+			t.Errorf("ValidateProfileName(%q) should have returned an error", name)
+		}
+	}
+}
+
+func TestLoad_ProfileOverrides(t *testing.T) {
+	tmpDir := setupTestConfig(t)
+	t.Setenv("TIGER_CONFIG_DIR", tmpDir)
+	t.Setenv("TIGER_PROFILE", "")
+
+	if err := os.WriteFile(GetConfigFile(tmpDir), []byte("profile: staging\noutput: json\nservice_id: base-service\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	writeProfileConfig(t, tmpDir, "staging", "service_id: staging-service\n")
+
+	cfg, err := Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.Profile != "staging" {
+		t.Errorf("Expected Profile staging, got %s", cfg.Profile)
+	}
+	if cfg.ServiceID != "staging-service" {
+		t.Errorf("Expected ServiceID from the profile overrides, got %s", cfg.ServiceID)
+	}
+	if cfg.Output != "json" {
+		t.Errorf("Expected Output json inherited from the base config, got %s", cfg.Output)
+	}
+
+	// The environment overrides the profile selected in the config file
+	t.Setenv("TIGER_PROFILE", DefaultProfile)
+	cfg, err = Load(nil)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if cfg.Profile != DefaultProfile {
+		t.Errorf("Expected Profile %s, got %s", DefaultProfile, cfg.Profile)
+	}
+	if cfg.ServiceID != "base-service" {
+		t.Errorf("Expected ServiceID from the base config, got %s", cfg.ServiceID)
+	}
+
+	t.Setenv("TIGER_PROFILE", "../escape")
+	if _, err := Load(nil); err == nil {
+		t.Error("Load() should reject an invalid profile name")
+	}
+}
+
+func TestSet_WritesActiveProfile(t *testing.T) {
+	tmpDir := setupTestConfig(t)
+
+	cfg := &Config{ConfigDir: tmpDir, Profile: "staging"}
+	if err := cfg.Set("service_id", "staging-service"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	profileConfig, err := os.ReadFile(GetConfigFile(GetProfileDir(tmpDir, "staging")))
+	if err != nil {
+		t.Fatalf("Failed to read profile config: %v", err)
+	}
+	if !strings.Contains(string(profileConfig), "service_id: staging-service") {
+		t.Errorf("Expected profile config to contain service_id, got: %s", profileConfig)
+	}
+	baseConfig, err := os.ReadFile(GetConfigFile(tmpDir))
+	if err != nil {
+		t.Fatalf("Failed to read base config: %v", err)
+	}
+	if strings.Contains(string(baseConfig), "service_id") {
+		t.Errorf("Expected base config to be untouched, got: %s", baseConfig)
+	}
+
+	// The profile selection always goes to the base config file
+	if err := cfg.Set("profile", "staging"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	selected, err := SelectedProfile(tmpDir)
+	if err != nil {
+		t.Fatalf("SelectedProfile() failed: %v", err)
+	}
+	if selected != "staging" {
+		t.Errorf("Expected selected profile staging, got %s", selected)
+	}
+	if cfg.ServiceID != "staging-service" {
+		t.Errorf("Expected ServiceID staging-service after reload, got %s", cfg.ServiceID)
+	}
+
+	// Resetting a named profile only clears its overrides
+	if err := cfg.Reset(); err != nil {
+		t.Fatalf("Reset() failed: %v", err)
+	}
+	if cfg.Profile != "staging" || cfg.ServiceID != "" {
+		t.Errorf("Expected profile staging with no service ID after reset, got %s and %q", cfg.Profile, cfg.ServiceID)
+	}
+}
+
+func TestProfileCredentials(t *testing.T) {
+	tmpDir, cfg := setupCredentialTest(t)
+
+	staging := cfg.WithProfile("staging")
+	t.Cleanup(func() { staging.RemoveCredentials() })
+
+	if err := staging.StoreCredentialsToFile("staging:secret", "staging-project"); err != nil {
+		t.Fatalf("Failed to store credentials: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "profiles", "staging", "credentials")); err != nil {
+		t.Errorf("Expected credentials file in the profile directory: %v", err)
+	}
+
+	creds, err := staging.GetStoredCredentials()
+	if err != nil {
+		t.Fatalf("Failed to get credentials: %v", err)
+	}
+	if creds.APIKey != "staging:secret" || creds.ProjectID != "staging-project" {
+		t.Errorf("Unexpected credentials: %+v", creds)
+	}
+
+	// The default profile's credentials are separate
+	if _, err := cfg.GetStoredCredentials(); !errors.Is(err, ErrNotLoggedIn) {
+		t.Errorf("Expected ErrNotLoggedIn for the default profile, got %v", err)
+	}
+}
+
+func TestListAndDeleteProfiles(t *testing.T) {
+	tmpDir, cfg := setupCredentialTest(t)
+
+	writeProfileConfig(t, tmpDir, "staging", "")
+	writeProfileConfig(t, tmpDir, "prod", "")
+	if err := cfg.Set("profile", "staging"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	profiles, err := ListProfiles(tmpDir)
+	if err != nil {
+		t.Fatalf("ListProfiles() failed: %v", err)
+	}
+	if expected := []string{"default", "prod", "staging"}; !slices.Equal(profiles, expected) {
+		t.Errorf("Expected profiles %v, got %v", expected, profiles)
+	}
+
+	if err := cfg.DeleteProfile(DefaultProfile); err == nil {
+		t.Error("DeleteProfile() should refuse to delete the default profile")
+	}
+	if err := cfg.DeleteProfile("staging"); err != nil {
+		t.Fatalf("DeleteProfile() failed: %v", err)
+	}
+
+	if exists, _ := ProfileExists(tmpDir, "staging"); exists {
+		t.Error("Expected staging profile to be deleted")
+	}
+	if selected, _ := SelectedProfile(tmpDir); selected != DefaultProfile {
+		t.Errorf("Expected selection to revert to %s, got %s", DefaultProfile, selected)
+	}
+	if cfg.Profile != DefaultProfile {
+		t.Errorf("Expected active profile %s after deletion, got %s", DefaultProfile, cfg.Profile)
+	}
+}
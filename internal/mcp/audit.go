@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// auditRedacted replaces the values of auditRedactedArgs in audit entries.
+const auditRedacted = "[REDACTED]"
+
+// auditRedactedArgs lists tool arguments whose values never reach the audit
+// log. Query parameters are redacted because they commonly carry the literal
+// values (including secrets) that the SQL itself deliberately leaves out.
+var auditRedactedArgs = []string{
+	"public_key",
+	"secret_key",
+	"password",
+	"new_password",
+	"parameters",
+}
+
+// auditMu serializes writes to the audit log across servers, since every HTTP
+// session runs its own Server (see http_sessions.go) but they share one file.
+var auditMu sync.Mutex
+
+// auditEntry is one line of the audit log.
+type auditEntry struct {
+	Time       time.Time      `json:"time"`
+	SessionID  string         `json:"session_id,omitempty"`
+	Client     *auditClient   `json:"client,omitempty"`
+	ProjectID  string         `json:"project_id,omitempty"`
+	Tool       string         `json:"tool"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+	SQL        string         `json:"sql,omitempty"`
+	DurationMS int64          `json:"duration_ms"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// auditClient identifies the MCP client that made a tool call, as reported at
+// initialization.
+type auditClient struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// auditMiddleware appends every tool call to the audit log configured by the
+// mcp_audit_log config option, if any. It runs inside analyticsMiddleware, so
+// s.app already holds the config loaded for this request.
+func (s *Server) auditMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		r, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		cfg := s.app.GetConfig()
+		if cfg == nil || cfg.MCPAuditLog == "" {
+			return next(ctx, method, req)
+		}
+
+		start := time.Now()
+		result, err := next(ctx, method, req)
+
+		entry := newAuditEntry(r, start)
+		if _, projectID, err := s.app.GetClient(); err == nil {
+			entry.ProjectID = projectID
+		}
+		if toolErr := toolCallError(result, err); toolErr != nil {
+			entry.Status = "error"
+			entry.Error = toolErr.Error()
+		}
+		if writeErr := writeAuditEntry(util.ExpandPath(cfg.MCPAuditLog), entry); writeErr != nil {
+			s.logger.Error("Failed to write MCP audit log entry",
+				slog.String("path", cfg.MCPAuditLog),
+				slog.Any("error", writeErr),
+			)
+		}
+		return result, err
+	}
+}
+
+// newAuditEntry builds a successful audit entry for a tool call that started
+// at start. The db_execute_query SQL is recorded in its own field rather than
+// among the arguments.
+func newAuditEntry(r *mcp.CallToolRequest, start time.Time) auditEntry {
+	entry := auditEntry{
+		Time:       start.UTC(),
+		Tool:       r.Params.Name,
+		DurationMS: time.Since(start).Milliseconds(),
+		Status:     "ok",
+	}
+
+	if r.Session != nil {
+		entry.SessionID = r.Session.ID()
+		if params := r.Session.InitializeParams(); params != nil && params.ClientInfo != nil {
+			entry.Client = &auditClient{
+				Name:    params.ClientInfo.Name,
+				Version: params.ClientInfo.Version,
+			}
+		}
+	}
+
+	var args map[string]any
+	if len(r.Params.Arguments) > 0 {
+		if err := json.Unmarshal(r.Params.Arguments, &args); err != nil {
+			// Keep the raw arguments rather than dropping them from the trail
+			args = map[string]any{"raw": string(r.Params.Arguments)}
+		}
+	}
+	if query, ok := args["query"].(string); ok && r.Params.Name == toolDBExecuteQuery {
+		entry.SQL = query
+		delete(args, "query")
+	}
+	for key := range args {
+		if slices.Contains(auditRedactedArgs, key) {
+			args[key] = auditRedacted
+		}
+	}
+	if len(args) > 0 {
+		entry.Arguments = args
+	}
+
+	return entry
+}
+
+// writeAuditEntry appends entry to the audit log at path as a single JSON
+// line, creating the file (readable only by the current user) if needed.
+func writeAuditEntry(path string, entry auditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	line = append(line, '\n')
+
+	auditMu.Lock()
+	defer auditMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return f.Close()
+}
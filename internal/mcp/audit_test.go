@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+type auditTestInput struct {
+	ServiceID   string   `json:"service_id"`
+	Query       string   `json:"query,omitempty"`
+	Parameters  []string `json:"parameters,omitempty"`
+	NewPassword string   `json:"new_password,omitempty"`
+}
+
+func TestAuditLog(t *testing.T) {
+	auditLog := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	t.Setenv("TIGER_MCP_AUDIT_LOG", auditLog)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return nil, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	// Stand-in tools, so the test records calls without a real database or API
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, nil)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{Name: toolDBExecuteQuery}, func(context.Context, *mcp.CallToolRequest, auditTestInput) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	})
+	mcp.AddTool(s.mcpServer, &mcp.Tool{Name: toolServiceUpdatePassword}, func(context.Context, *mcp.CallToolRequest, auditTestInput) (*mcp.CallToolResult, any, error) {
+		return nil, nil, errors.New("service not found")
+	})
+	s.mcpServer.AddReceivingMiddleware(s.auditMiddleware)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.2.3"}, nil)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	calls := []*mcp.CallToolParams{
+		{Name: toolDBExecuteQuery, Arguments: map[string]any{
+			"service_id": "svc1234567",
+			"query":      "SELECT * FROM users WHERE email = $1",
+			"parameters": []string{"alice@example.com"},
+		}},
+		{Name: toolServiceUpdatePassword, Arguments: map[string]any{
+			"service_id":   "svc1234567",
+			"new_password": "hunter2",
+		}},
+	}
+	for _, params := range calls {
+		if _, err := session.CallTool(t.Context(), params); err != nil {
+			t.Fatalf("call %s: %v", params.Name, err)
+		}
+	}
+
+	f, err := os.Open(auditLog)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer f.Close()
+	if info, err := f.Stat(); err != nil {
+		t.Fatal(err)
+	} else if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("audit log permissions = %o, want 600", perm)
+	}
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit log line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != len(calls) {
+		t.Fatalf("audit log has %d entries, want %d", len(entries), len(calls))
+	}
+
+	query := entries[0]
+	if query.Tool != toolDBExecuteQuery || query.Status != "ok" || query.Error != "" {
+		t.Errorf("query entry = %+v, want a successful %s call", query, toolDBExecuteQuery)
+	}
+	if query.SQL != "SELECT * FROM users WHERE email = $1" {
+		t.Errorf("query entry SQL = %q", query.SQL)
+	}
+	if _, ok := query.Arguments["query"]; ok {
+		t.Error("query entry should record the SQL outside its arguments")
+	}
+	if query.Arguments["parameters"] != auditRedacted || query.Arguments["service_id"] != "svc1234567" {
+		t.Errorf("query entry arguments = %v, want parameters redacted and service_id kept", query.Arguments)
+	}
+	if query.Client == nil || query.Client.Name != "test-client" || query.Client.Version != "1.2.3" {
+		t.Errorf("query entry client = %+v, want test-client 1.2.3", query.Client)
+	}
+	if query.ProjectID != "proj" || query.Time.IsZero() {
+		t.Errorf("query entry = %+v, want project and time recorded", query)
+	}
+
+	password := entries[1]
+	if password.Status != "error" || password.Error != "service not found" {
+		t.Errorf("password entry status = %q, error = %q, want the tool error", password.Status, password.Error)
+	}
+	if password.Arguments["new_password"] != auditRedacted {
+		t.Errorf("password entry arguments = %v, want new_password redacted", password.Arguments)
+	}
+}
+
+func TestNewAuditEntry_SQLOnlyForDBExecuteQuery(t *testing.T) {
+	entry := newAuditEntry(&mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+		Name:      toolServiceList,
+		Arguments: json.RawMessage(`{"query":"not SQL"}`),
+	}}, time.Now())
+	// Only db_execute_query's query argument is SQL
+	if entry.SQL != "" || entry.Arguments["query"] != "not SQL" {
+		t.Errorf("entry = %+v, want query kept as an ordinary argument", entry)
+	}
+}
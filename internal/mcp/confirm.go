@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// confirmSchema is the elicitation form shown to the user: a single
+// checkbox they must tick to approve the operation.
+var confirmSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"confirm": map[string]any{
+			"type":        "boolean",
+			"title":       "Confirm",
+			"description": "Check to allow this operation to proceed",
+		},
+	},
+	"required": []string{"confirm"},
+}
+
+// confirmArgumentDescription documents the confirm argument of the tools that
+// call confirmOperation.
+const confirmArgumentDescription = "Confirms this destructive operation when the MCP client can't ask the user itself. Only set this to true after the user has explicitly approved the operation. Ignored when the client supports elicitation, since the user is then asked directly."
+
+// confirmOperation asks the user to approve a destructive operation before
+// it runs, mirroring the CLI's confirmation prompts (e.g. `tiger service
+// delete` without --confirm). Clients that support elicitation show the user
+// the message; for other clients, the model must pass confirm: true after
+// checking with the user itself. The mcp_confirm config option turns the
+// check off.
+func (s *Server) confirmOperation(ctx context.Context, req *mcp.CallToolRequest, confirmed bool, message string) error {
+	if cfg := s.app.GetConfig(); cfg == nil || !cfg.MCPConfirm {
+		return nil
+	}
+
+	tool, session := "this tool", (*mcp.ServerSession)(nil)
+	if req != nil {
+		tool, session = req.Params.Name, req.Session
+	}
+
+	if session == nil || !supportsElicitation(session) {
+		if confirmed {
+			return nil
+		}
+		return fmt.Errorf("%s requires confirmation: %s Ask the user to confirm, then call %s again with confirm: true", tool, message, tool)
+	}
+
+	result, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         message,
+		RequestedSchema: confirmSchema,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ask the user for confirmation: %w", err)
+	}
+	if result.Action != "accept" || result.Content["confirm"] != true {
+		s.logger.Info("MCP: User declined operation",
+			slog.String("tool", tool),
+			slog.String("action", result.Action),
+		)
+		return fmt.Errorf("the user did not confirm %s, so it was not run", tool)
+	}
+	return nil
+}
+
+// supportsElicitation reports whether the client declared the elicitation
+// capability at initialization.
+func supportsElicitation(session *mcp.ServerSession) bool {
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+type confirmTestInput struct {
+	Confirm bool `json:"confirm,omitempty"`
+}
+
+// newConfirmTestSession connects a client to a server with a stand-in
+// destructive tool guarded by confirmOperation. A nil elicit handler makes a
+// client without elicitation support. ran reports whether the tool ran.
+func newConfirmTestSession(t *testing.T, elicit func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error), ran *bool) *mcp.ClientSession {
+	t.Helper()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return nil, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, nil)
+	mcp.AddTool(s.mcpServer, &mcp.Tool{Name: toolServiceStop}, func(ctx context.Context, req *mcp.CallToolRequest, input confirmTestInput) (*mcp.CallToolResult, any, error) {
+		if err := s.confirmOperation(ctx, req, input.Confirm, "Stop service svc1234567?"); err != nil {
+			return nil, nil, err
+		}
+		*ran = true
+		return nil, nil, nil
+	})
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{ElicitationHandler: elicit})
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestConfirmOperation(t *testing.T) {
+	answer := func(action string, confirm bool) func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		return func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			if req.Params.Message != "Stop service svc1234567?" {
+				return nil, errors.New("unexpected message: " + req.Params.Message)
+			}
+			return &mcp.ElicitResult{Action: action, Content: map[string]any{"confirm": confirm}}, nil
+		}
+	}
+
+	tests := []struct {
+		name      string
+		disabled  bool
+		elicit    func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)
+		confirm   bool
+		wantRan   bool
+		wantError string
+	}{
+		{name: "user accepts", elicit: answer("accept", true), wantRan: true},
+		{name: "user leaves the box unchecked", elicit: answer("accept", false), wantError: "did not confirm"},
+		{name: "user declines", elicit: answer("decline", false), wantError: "did not confirm"},
+		{name: "confirm argument can't skip elicitation", elicit: answer("cancel", false), confirm: true, wantError: "did not confirm"},
+		{name: "no elicitation without confirm argument", wantError: "call service_stop again with confirm: true"},
+		{name: "no elicitation with confirm argument", confirm: true, wantRan: true},
+		{name: "disabled by config", disabled: true, wantRan: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.disabled {
+				t.Setenv("TIGER_MCP_CONFIRM", "false")
+			}
+			var ran bool
+			session := newConfirmTestSession(t, tt.elicit, &ran)
+
+			res, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+				Name:      toolServiceStop,
+				Arguments: map[string]any{"confirm": tt.confirm},
+			})
+			if err != nil {
+				t.Fatalf("call tool: %v", err)
+			}
+			if ran != tt.wantRan {
+				t.Errorf("tool ran = %v, want %v", ran, tt.wantRan)
+			}
+			if tt.wantError == "" {
+				if res.IsError {
+					t.Errorf("unexpected tool error: %+v", res.Content)
+				}
+				return
+			}
+			if !res.IsError {
+				t.Fatal("expected a tool error")
+			}
+			if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, tt.wantError) {
+				t.Errorf("error = %q, want it to contain %q", text, tt.wantError)
+			}
+		})
+	}
+}
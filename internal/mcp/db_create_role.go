@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// DBCreateRoleInput represents input for db_create_role
+type DBCreateRoleInput struct {
+	ServiceID               string   `json:"service_id"`
+	Name                    string   `json:"name"`
+	ReadOnly                bool     `json:"read_only,omitempty"`
+	From                    []string `json:"from,omitempty"`
+	StatementTimeoutSeconds int      `json:"statement_timeout_seconds,omitempty"`
+	WithPassword            bool     `json:"with_password,omitempty"`
+}
+
+func (DBCreateRoleInput) Schema() *jsonschema.Schema {
+	schema := util.Must(jsonschema.For[DBCreateRoleInput](nil))
+
+	setServiceIDSchemaProperties(schema)
+
+	schema.Properties["name"].Description = "Name of the role to create. The role can log in with an auto-generated password."
+	schema.Properties["name"].Examples = []any{"app_reader", "ai_analyst"}
+	schema.Properties["name"].MinLength = util.Ptr(1)
+
+	schema.Properties["read_only"].Description = "Permanently enforce read-only access for the role (via tsdb_admin.read_only_role). Recommended for analytics, reporting, and AI agent access."
+	schema.Properties["read_only"].Default = util.Must(json.Marshal(false))
+
+	schema.Properties["from"].Description = "Existing roles to inherit grants from; the new role becomes a member of each. Inheriting from tsdbadmin requires read_only and can't be combined with statement_timeout_seconds."
+	schema.Properties["from"].Examples = []any{[]string{"app_role"}, []string{"tsdbadmin"}}
+
+	schema.Properties["statement_timeout_seconds"].Description = "Cancel the role's statements that run longer than this many seconds. 0 (default) leaves the server default in place."
+	schema.Properties["statement_timeout_seconds"].Minimum = util.Ptr(0.0)
+	schema.Properties["statement_timeout_seconds"].Examples = []any{30, 300}
+
+	setWithPasswordSchemaProperties(schema)
+
+	return schema
+}
+
+// DBCreateRoleOutput represents output for db_create_role
+type DBCreateRoleOutput struct {
+	RoleName         string                        `json:"role_name"`
+	ReadOnly         bool                          `json:"read_only,omitempty"`
+	StatementTimeout string                        `json:"statement_timeout,omitempty"`
+	FromRoles        []string                      `json:"from_roles,omitempty"`
+	Password         string                        `json:"password,omitempty"`
+	PasswordStorage  *common.PasswordStorageResult `json:"password_storage,omitempty"`
+}
+
+func (DBCreateRoleOutput) Schema() *jsonschema.Schema {
+	schema := util.Must(jsonschema.For[DBCreateRoleOutput](nil))
+
+	schema.Properties["password"].Description = "The new role's password. Only included when with_password is true."
+	schema.Properties["password_storage"].Description = "Whether the password was saved (keyring, pgpass, or credential helper) per the password_storage config option"
+
+	return schema
+}
+
+func newDBCreateRoleTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:  toolDBCreateRole,
+		Title: "Create Database Role",
+		Description: `Create a new login role in a service's database.
+
+Use this to provision a least-privilege role for an application or agent instead of sharing tsdbadmin. The role gets an auto-generated password, which is saved locally according to the password_storage config option. Options:
+- read_only: permanently enforce read-only access for the role
+- from: inherit the grants of existing roles
+- statement_timeout_seconds: cancel long-running statements
+
+The role is created on the primary service; read replica IDs are rejected. Creating the role, making it read-only, and setting its timeout happen in one transaction.`,
+		InputSchema:  DBCreateRoleInput{}.Schema(),
+		OutputSchema: DBCreateRoleOutput{}.Schema(),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: util.Ptr(false), // Creates a new role; doesn't change existing ones
+			IdempotentHint:  false,           // Creating an existing role fails
+			OpenWorldHint:   util.Ptr(true),
+			Title:           "Create Database Role",
+		},
+	}
+}
+
+// handleDBCreateRole handles the db_create_role MCP tool
+func (s *Server) handleDBCreateRole(ctx context.Context, req *mcp.CallToolRequest, input DBCreateRoleInput) (*mcp.CallToolResult, DBCreateRoleOutput, error) {
+	cfg, client, projectID, err := s.app.GetAll()
+	if err != nil {
+		return nil, DBCreateRoleOutput{}, err
+	}
+
+	if err := common.CheckReadOnly(cfg); err != nil {
+		return nil, DBCreateRoleOutput{}, err
+	}
+
+	if input.Name == "" {
+		return nil, DBCreateRoleOutput{}, fmt.Errorf("name is required")
+	}
+	statementTimeout := time.Duration(input.StatementTimeoutSeconds) * time.Second
+
+	s.logger.Info("MCP: Creating database role",
+		slog.String("project_id", projectID),
+		slog.String("service_id", input.ServiceID),
+		slog.String("role", input.Name),
+		slog.Bool("read_only", input.ReadOnly),
+		slog.Any("from", input.From),
+		slog.Duration("statement_timeout", statementTimeout),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	conn, target, err := connectForRoleManagement(ctx, cfg, client, projectID, input.ServiceID, false)
+	if err != nil {
+		return nil, DBCreateRoleOutput{}, err
+	}
+	defer conn.Close(context.Background())
+
+	password, err := util.GenerateSecurePassword(32)
+	if err != nil {
+		return nil, DBCreateRoleOutput{}, fmt.Errorf("failed to generate password: %w", err)
+	}
+
+	if err := common.CreateRole(ctx, conn, common.CreateRoleOptions{
+		Name:             input.Name,
+		Password:         password,
+		ReadOnly:         input.ReadOnly,
+		StatementTimeout: statementTimeout,
+		FromRoles:        input.From,
+	}); err != nil {
+		return nil, DBCreateRoleOutput{}, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	// The role exists at this point, so a storage failure is reported in the
+	// output rather than failing the call.
+	storage, err := common.SavePasswordWithResult(cfg, target.ConnectionService, password, input.Name)
+	if err != nil {
+		s.logger.Warn("MCP: Failed to save role password", slog.Any("error", err))
+	}
+
+	output := DBCreateRoleOutput{
+		RoleName:        input.Name,
+		ReadOnly:        input.ReadOnly,
+		FromRoles:       input.From,
+		PasswordStorage: &storage,
+	}
+	if statementTimeout > 0 {
+		output.StatementTimeout = statementTimeout.String()
+	}
+	if input.WithPassword {
+		output.Password = password
+	}
+
+	return nil, output, nil
+}
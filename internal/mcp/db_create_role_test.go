@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/config"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+func TestConnectForRoleManagement_ReadReplicaRejected(t *testing.T) {
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects/proj/services/rep1234567":
+			_ = json.NewEncoder(w).Encode(api.Service{
+				ServiceID:  "rep1234567",
+				Status:     api.DeployStatusREADY,
+				ForkedFrom: &api.ForkSpec{IsStandby: util.Ptr(true), ServiceID: util.Ptr("svcprimary")},
+			})
+		case "/projects/proj/services/svcprimary":
+			_ = json.NewEncoder(w).Encode(api.Service{ServiceID: "svcprimary", Status: api.DeployStatusREADY})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	client, err := api.NewClientWithResponses(apiServer.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, _, err = connectForRoleManagement(t.Context(), &config.Config{}, client, "proj", "rep1234567", false)
+	if err == nil {
+		t.Fatal("expected role management on a read replica to be rejected")
+	}
+	if !strings.Contains(err.Error(), "read replica") || !strings.Contains(err.Error(), "svcprimary") {
+		t.Errorf("expected guidance pointing at the primary service, got: %v", err)
+	}
+}
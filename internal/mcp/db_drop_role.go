@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// DBDropRoleInput represents input for db_drop_role
+type DBDropRoleInput struct {
+	ServiceID string `json:"service_id"`
+	Name      string `json:"name"`
+	Confirm   bool   `json:"confirm,omitempty"`
+}
+
+func (DBDropRoleInput) Schema() *jsonschema.Schema {
+	schema := util.Must(jsonschema.For[DBDropRoleInput](nil))
+
+	setServiceIDSchemaProperties(schema)
+
+	schema.Properties["name"].Description = "Name of the role to drop. tsdbadmin, postgres, and pg_* roles can't be dropped."
+	schema.Properties["name"].Examples = []any{"app_reader", "ai_analyst"}
+	schema.Properties["name"].MinLength = util.Ptr(1)
+
+	schema.Properties["confirm"].Description = confirmArgumentDescription
+	schema.Properties["confirm"].Default = util.Must(json.Marshal(false))
+
+	return schema
+}
+
+// DBDropRoleOutput represents output for db_drop_role
+type DBDropRoleOutput struct {
+	Message string `json:"message"`
+}
+
+func (DBDropRoleOutput) Schema() *jsonschema.Schema {
+	return util.Must(jsonschema.For[DBDropRoleOutput](nil))
+}
+
+func newDBDropRoleTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:  toolDBDropRole,
+		Title: "Drop Database Role",
+		Description: `Drop a role from a service's database.
+
+Anything connecting as the role will no longer be able to log in. PostgreSQL refuses to drop a role that still owns objects or holds privileges; the error names them, and they must be reassigned (REASSIGN OWNED) or revoked (DROP OWNED) with db_execute_query first.`,
+		InputSchema:  DBDropRoleInput{}.Schema(),
+		OutputSchema: DBDropRoleOutput{}.Schema(),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: util.Ptr(true), // Anything using the role loses access
+			IdempotentHint:  false,          // Dropping a missing role fails
+			OpenWorldHint:   util.Ptr(true),
+			Title:           "Drop Database Role",
+		},
+	}
+}
+
+// handleDBDropRole handles the db_drop_role MCP tool
+func (s *Server) handleDBDropRole(ctx context.Context, req *mcp.CallToolRequest, input DBDropRoleInput) (*mcp.CallToolResult, DBDropRoleOutput, error) {
+	cfg, client, projectID, err := s.app.GetAll()
+	if err != nil {
+		return nil, DBDropRoleOutput{}, err
+	}
+
+	if err := common.CheckReadOnly(cfg); err != nil {
+		return nil, DBDropRoleOutput{}, err
+	}
+
+	if input.Name == "" {
+		return nil, DBDropRoleOutput{}, fmt.Errorf("name is required")
+	}
+
+	if err := s.confirmOperation(ctx, req, input.Confirm, fmt.Sprintf("Drop role %s on service %s? Anything connecting as this role will lose access.", input.Name, input.ServiceID)); err != nil {
+		return nil, DBDropRoleOutput{}, err
+	}
+
+	s.logger.Info("MCP: Dropping database role",
+		slog.String("project_id", projectID),
+		slog.String("service_id", input.ServiceID),
+		slog.String("role", input.Name),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	conn, _, err := connectForRoleManagement(ctx, cfg, client, projectID, input.ServiceID, false)
+	if err != nil {
+		return nil, DBDropRoleOutput{}, err
+	}
+	defer conn.Close(context.Background())
+
+	if err := common.DropRole(ctx, conn, input.Name); err != nil {
+		return nil, DBDropRoleOutput{}, err
+	}
+
+	return nil, DBDropRoleOutput{Message: fmt.Sprintf("Role %s dropped.", input.Name)}, nil
+}
@@ -24,12 +24,13 @@ const (
 
 // DBExecuteQueryInput represents input for db_execute_query
 type DBExecuteQueryInput struct {
-	ServiceID      string   `json:"service_id"`
-	Query          string   `json:"query"`
-	Parameters     []string `json:"parameters,omitempty"`
-	TimeoutSeconds int      `json:"timeout_seconds,omitempty"`
-	Role           string   `json:"role,omitempty"`
-	Pooled         bool     `json:"pooled,omitempty"`
+	ServiceID          string   `json:"service_id"`
+	Query              string   `json:"query"`
+	Parameters         []string `json:"parameters,omitempty"`
+	TimeoutSeconds     int      `json:"timeout_seconds,omitempty"`
+	Role               string   `json:"role,omitempty"`
+	Pooled             bool     `json:"pooled,omitempty"`
+	ConfirmDestructive bool     `json:"confirm_destructive,omitempty"`
 }
 
 func (DBExecuteQueryInput) Schema() *jsonschema.Schema {
@@ -57,6 +58,9 @@ func (DBExecuteQueryInput) Schema() *jsonschema.Schema {
 	schema.Properties["pooled"].Default = util.Must(json.Marshal(false))
 	schema.Properties["pooled"].Examples = []any{false, true}
 
+	schema.Properties["confirm_destructive"].Description = "Confirms that destructive statements (DDL, DROP, TRUNCATE, ALTER SYSTEM, UPDATE/DELETE without WHERE, COPY FROM, EXECUTE of a prepared statement, or calls to destructive functions such as drop_chunks) should run. Only set this after the user has explicitly approved the statements the safety guard flagged."
+	schema.Properties["confirm_destructive"].Default = util.Must(json.Marshal(false))
+
 	return schema
 }
 
@@ -135,7 +139,7 @@ Multi-statement queries (semicolon-separated) are supported when no parameters a
 
 Process data in the database, not in your context: aggregate, filter, sort/limit, and join in SQL rather than fetching raw rows.
 
-WARNING: Can execute any SQL statement including INSERT, UPDATE, DELETE, and DDL commands. Always review queries before execution.`,
+WARNING: Can execute any SQL statement including INSERT, UPDATE, DELETE, and DDL commands. Always review queries before execution. Unless disabled, a safety guard blocks destructive statements (DDL, DROP, TRUNCATE, ALTER SYSTEM, UPDATE/DELETE without WHERE, COPY FROM, EXECUTE of a prepared statement, and calls to known destructive functions such as drop_chunks) and explains why; other functions with side effects, including user-defined ones, are not detected. Run flagged statements only after the user approves, by retrying with confirm_destructive: true.`,
 		InputSchema:  DBExecuteQueryInput{}.Schema(),
 		OutputSchema: DBExecuteQueryOutput{}.Schema(),
 		Annotations: &mcp.ToolAnnotations{
@@ -167,6 +171,18 @@ func (s *Server) handleDBExecuteQuery(ctx context.Context, req *mcp.CallToolRequ
 		slog.Bool("read_only", cfg.ReadOnly),
 	)
 
+	// Read-only sessions can't modify anything, so the guard only applies to
+	// read-write ones.
+	if !cfg.ReadOnly {
+		if err := checkSQLGuard(cfg.MCPSQLGuard, input.Query, input.ConfirmDestructive); err != nil {
+			s.logger.Warn("MCP: SQL safety guard blocked query",
+				slog.String("service_id", input.ServiceID),
+				slog.String("policy", cfg.MCPSQLGuard),
+			)
+			return nil, DBExecuteQueryOutput{}, err
+		}
+	}
+
 	// service_id may name a service or one of its read replicas.
 	target, err := common.ResolveConnectionTargetByID(ctx, client, projectID, input.ServiceID)
 	if err != nil {
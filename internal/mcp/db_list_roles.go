@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// DBListRolesInput represents input for db_list_roles
+type DBListRolesInput struct {
+	ServiceID string `json:"service_id"`
+	System    bool   `json:"system,omitempty"`
+}
+
+func (DBListRolesInput) Schema() *jsonschema.Schema {
+	schema := util.Must(jsonschema.For[DBListRolesInput](nil))
+
+	schema.Properties["service_id"].Description = "Unique identifier of the service (10-character alphanumeric string). Use service_list to find service IDs. A read replica set ID is also accepted; replicas have the same roles as their primary."
+	schema.Properties["service_id"].Examples = []any{"e6ue9697jf", "u8me885b93"}
+	schema.Properties["service_id"].Pattern = "^[a-z0-9]{10}$"
+
+	schema.Properties["system"].Description = "Include PostgreSQL's predefined pg_* roles."
+	schema.Properties["system"].Default = util.Must(json.Marshal(false))
+
+	return schema
+}
+
+// DBListRolesOutput represents output for db_list_roles
+type DBListRolesOutput struct {
+	Roles []common.RoleInfo `json:"roles"`
+}
+
+func (DBListRolesOutput) Schema() *jsonschema.Schema {
+	return util.Must(jsonschema.For[DBListRolesOutput](nil))
+}
+
+func newDBListRolesTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:  toolDBListRoles,
+		Title: "List Database Roles",
+		Description: `List the roles in a service's database with their attributes and memberships.
+
+For each role, returns whether it can log in, its superuser/create-role/create-database attributes, its connection limit and password expiry, whether read-only access is enforced, its statement timeout, and the roles it is a member of (whose grants it inherits). The connection is opened in read-only mode.`,
+		InputSchema:  DBListRolesInput{}.Schema(),
+		OutputSchema: DBListRolesOutput{}.Schema(),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:  true,
+			OpenWorldHint: util.Ptr(true),
+			Title:         "List Database Roles",
+		},
+	}
+}
+
+// handleDBListRoles handles the db_list_roles MCP tool
+func (s *Server) handleDBListRoles(ctx context.Context, req *mcp.CallToolRequest, input DBListRolesInput) (*mcp.CallToolResult, DBListRolesOutput, error) {
+	cfg, client, projectID, err := s.app.GetAll()
+	if err != nil {
+		return nil, DBListRolesOutput{}, err
+	}
+
+	s.logger.Info("MCP: Listing database roles",
+		slog.String("project_id", projectID),
+		slog.String("service_id", input.ServiceID),
+		slog.Bool("system", input.System),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	conn, _, err := connectForRoleManagement(ctx, cfg, client, projectID, input.ServiceID, true)
+	if err != nil {
+		return nil, DBListRolesOutput{}, err
+	}
+	defer conn.Close(context.Background())
+
+	roles, err := common.ListRoles(ctx, conn, input.System)
+	if err != nil {
+		return nil, DBListRolesOutput{}, err
+	}
+
+	return nil, DBListRolesOutput{Roles: roles}, nil
+}
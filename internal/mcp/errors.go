@@ -1,11 +1,15 @@
 package mcp
 
-// readOnlyGatedTools are the service-mutating tools addTool skips in read-only mode.
+// readOnlyGatedTools are the service- and role-mutating tools addTool skips in
+// read-only mode.
 var readOnlyGatedTools = []string{
 	toolServiceCreate,
 	toolServiceFork,
 	toolServiceStart,
 	toolServiceStop,
 	toolServiceResize,
+	toolServiceDelete,
 	toolServiceUpdatePassword,
+	toolDBCreateRole,
+	toolDBDropRole,
 }
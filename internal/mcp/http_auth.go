@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// HTTPOptions configures access control for the HTTP transport.
+type HTTPOptions struct {
+	// AuthToken, if set, is the bearer token every request must present in its
+	// Authorization header.
+	AuthToken string
+
+	// AllowedOrigins lists the browser origins (e.g. "https://app.example.com")
+	// allowed to call the server, in addition to loopback origins. Requests
+	// without an Origin header (i.e. non-browser clients) are always allowed.
+	// "*" allows any origin.
+	AllowedOrigins []string
+
+	// Stateful keeps a session per client (identified by the Mcp-Session-Id
+	// header) instead of treating every request independently. Stateful
+	// sessions support resource subscriptions and other server-to-client
+	// messages.
+	Stateful bool
+
+	// SessionAPIKeys requires each session to authenticate with its own Tiger
+	// API key in the APIKeyHeader header, and runs the session's tool calls
+	// with that key instead of the server's credentials. Implies Stateful.
+	SessionAPIKeys bool
+
+	// SessionTimeout closes stateful sessions that are idle for this long. Zero
+	// keeps idle sessions open.
+	SessionTimeout time.Duration
+}
+
+// withHTTPAuth wraps next with the Origin and bearer token checks.
+func (s *Server) withHTTPAuth(next http.Handler, opts HTTPOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Check the Origin first, so web pages can't drive the server from a
+		// user's browser even when no token is configured. (The SDK separately
+		// rejects DNS-rebound requests to loopback addresses by Host header.)
+		if origin := r.Header.Get("Origin"); origin != "" && !originAllowed(origin, opts.AllowedOrigins) {
+			s.logger.Warn("Rejected MCP HTTP request from disallowed origin",
+				slog.String("origin", origin),
+				slog.String("remote_addr", r.RemoteAddr),
+			)
+			http.Error(w, "Forbidden: origin not allowed", http.StatusForbidden)
+			return
+		}
+
+		if opts.AuthToken != "" && !bearerTokenValid(r, opts.AuthToken) {
+			s.logger.Warn("Rejected unauthenticated MCP HTTP request", slog.String("remote_addr", r.RemoteAddr))
+			w.Header().Set("WWW-Authenticate", `Bearer realm="tiger-mcp"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// bearerTokenValid reports whether the request's Authorization header carries
+// the expected bearer token, compared in constant time.
+func bearerTokenValid(r *http.Request, token string) bool {
+	scheme, credentials, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(credentials)), []byte(token)) == 1
+}
+
+// originAllowed reports whether a browser origin may call the server: loopback
+// origins always may, others only if listed in allowed.
+func originAllowed(origin string, allowed []string) bool {
+	if slices.Contains(allowed, "*") || slices.Contains(allowed, strings.TrimSuffix(origin, "/")) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
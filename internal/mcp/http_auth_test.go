@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPAuth(t *testing.T) {
+	s := &Server{logger: ensureLogger(nil)}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name    string
+		opts    HTTPOptions
+		headers map[string]string
+		want    int
+	}{
+		{
+			name: "no token configured allows requests",
+			want: http.StatusOK,
+		},
+		{
+			name: "missing token",
+			opts: HTTPOptions{AuthToken: "secret"},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name:    "wrong token",
+			opts:    HTTPOptions{AuthToken: "secret"},
+			headers: map[string]string{"Authorization": "Bearer nope"},
+			want:    http.StatusUnauthorized,
+		},
+		{
+			name:    "wrong scheme",
+			opts:    HTTPOptions{AuthToken: "secret"},
+			headers: map[string]string{"Authorization": "Basic secret"},
+			want:    http.StatusUnauthorized,
+		},
+		{
+			name:    "valid token",
+			opts:    HTTPOptions{AuthToken: "secret"},
+			headers: map[string]string{"Authorization": "bearer secret"},
+			want:    http.StatusOK,
+		},
+		{
+			name:    "loopback origin allowed",
+			headers: map[string]string{"Origin": "http://localhost:6274"},
+			want:    http.StatusOK,
+		},
+		{
+			name:    "loopback IP origin allowed",
+			headers: map[string]string{"Origin": "http://127.0.0.1:3000"},
+			want:    http.StatusOK,
+		},
+		{
+			name:    "foreign origin rejected",
+			headers: map[string]string{"Origin": "https://evil.example.com"},
+			want:    http.StatusForbidden,
+		},
+		{
+			name:    "listed origin allowed",
+			opts:    HTTPOptions{AllowedOrigins: []string{"https://app.example.com"}},
+			headers: map[string]string{"Origin": "https://app.example.com"},
+			want:    http.StatusOK,
+		},
+		{
+			name:    "wildcard origin allowed",
+			opts:    HTTPOptions{AllowedOrigins: []string{"*"}},
+			headers: map[string]string{"Origin": "https://evil.example.com"},
+			want:    http.StatusOK,
+		},
+		{
+			name:    "origin checked before token",
+			opts:    HTTPOptions{AuthToken: "secret"},
+			headers: map[string]string{"Origin": "https://evil.example.com", "Authorization": "Bearer secret"},
+			want:    http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			s.withHTTPAuth(ok, tt.opts).ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 response should include a WWW-Authenticate header")
+			}
+		})
+	}
+}
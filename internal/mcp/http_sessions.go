@@ -0,0 +1,193 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+)
+
+// APIKeyHeader is the HTTP header carrying a session's Tiger API key, as
+// "<public key>:<secret key>", when HTTPOptions.SessionAPIKeys is set.
+const APIKeyHeader = "X-Tiger-API-Key"
+
+// mcpSessionIDHeader is the header the streamable HTTP transport identifies
+// sessions by.
+const mcpSessionIDHeader = "Mcp-Session-Id"
+
+// sessionInitTimeout closes sessions whose client hasn't finished
+// initializing by then, so abandoned handshakes don't hold on to a Server.
+const sessionInitTimeout = time.Minute
+
+// httpSession is a stateful HTTP session authenticated with its own API key.
+// Each session gets its own Server (and App), so its tool calls use the
+// session's API client and project rather than the server operator's.
+type httpSession struct {
+	keyDigest [sha256.Size]byte
+	server    *Server
+	// initTimer expires the session if it doesn't finish initializing
+	initTimer *time.Timer
+}
+
+type httpSessionKey struct{}
+
+// httpSessions tracks the live sessions by session ID.
+type httpSessions struct {
+	mu          sync.Mutex
+	byID        map[string]*httpSession
+	initTimeout time.Duration
+}
+
+func (h *httpSessions) get(id string) *httpSession {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.byID[id]
+}
+
+func (h *httpSessions) add(id string, session *httpSession) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.byID[id] = session
+}
+
+func (h *httpSessions) remove(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.byID, id)
+}
+
+// sessionAPIKeyHandler returns a stateful HTTP handler that authenticates
+// each new session with the API key in its APIKeyHeader, validated with
+// common.ValidateAPIKey, and binds the session to that key: later requests
+// for the session must present the same key.
+func (s *Server) sessionAPIKeyHandler(sessionTimeout time.Duration) http.Handler {
+	return s.httpSessionsHandler(&httpSessions{
+		byID:        make(map[string]*httpSession),
+		initTimeout: sessionInitTimeout,
+	}, sessionTimeout)
+}
+
+func (s *Server) httpSessionsHandler(sessions *httpSessions, sessionTimeout time.Duration) http.Handler {
+	handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
+		if session, ok := r.Context().Value(httpSessionKey{}).(*httpSession); ok {
+			return session.server.mcpServer
+		}
+		return nil
+	}, &mcp.StreamableHTTPOptions{
+		SessionTimeout: sessionTimeout,
+	})
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey := strings.TrimSpace(r.Header.Get(APIKeyHeader))
+		if apiKey == "" {
+			http.Error(w, fmt.Sprintf("Unauthorized: missing %s header", APIKeyHeader), http.StatusUnauthorized)
+			return
+		}
+		// Requests for an existing session must come with the key it was
+		// created with, so a leaked session ID alone doesn't grant access.
+		if sessionID := r.Header.Get(mcpSessionIDHeader); sessionID != "" {
+			session := sessions.get(sessionID)
+			if session == nil {
+				http.Error(w, "session not found", http.StatusNotFound)
+				return
+			}
+			if digest := sha256.Sum256([]byte(apiKey)); subtle.ConstantTimeCompare(session.keyDigest[:], digest[:]) != 1 {
+				s.logger.Warn("Rejected MCP HTTP request with a different API key than its session",
+					slog.String("remote_addr", r.RemoteAddr),
+				)
+				http.Error(w, "Forbidden: API key does not match the session", http.StatusForbidden)
+				return
+			}
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		session, err := s.newHTTPSession(r.Context(), apiKey, sessions)
+		if err != nil {
+			s.logger.Warn("Rejected MCP HTTP session with an invalid API key",
+				slog.String("remote_addr", r.RemoteAddr),
+				slog.Any("error", err),
+			)
+			http.Error(w, fmt.Sprintf("Unauthorized: %v", err), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), httpSessionKey{}, session)))
+	})
+}
+
+// newHTTPSession validates apiKey and builds a Server that runs with it. The
+// session registers itself in sessions under the ID the transport assigns it,
+// and removes itself once it closes, or if it doesn't finish initializing
+// within sessions.initTimeout.
+func (s *Server) newHTTPSession(ctx context.Context, apiKey string, sessions *httpSessions) (*httpSession, error) {
+	if publicKey, secretKey, ok := strings.Cut(apiKey, ":"); !ok || publicKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("%s must be formatted as <public key>:<secret key>", APIKeyHeader)
+	}
+
+	cfg := s.app.GetConfig()
+	client, err := api.NewTigerClient(cfg, apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+	authInfo, err := common.ValidateAPIKey(ctx, cfg, client)
+	if err != nil {
+		return nil, fmt.Errorf("API key validation failed: %w", err)
+	}
+	projectID := authInfo.APIKey.Project.ID
+
+	app, err := s.app.ForClient(ctx, client, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &httpSession{keyDigest: sha256.Sum256([]byte(apiKey))}
+
+	// Tool registration, and the session's resource watcher, must outlive the
+	// request that creates the session.
+	serverCtx := context.WithoutCancel(ctx)
+	session.server = newServer(serverCtx, app, s.logger, serverOptions{
+		docsProxy:       s.docsProxyClient,
+		upstreamProxies: s.upstreamProxyClients,
+		// Record the session under its ID before the transport hands the ID
+		// to the client, so the client's next request finds it.
+		sessionID: func() string {
+			id := rand.Text()
+			session.initTimer = time.AfterFunc(sessions.initTimeout, func() {
+				sessions.remove(id)
+				for ss := range session.server.mcpServer.Sessions() {
+					_ = ss.Close()
+				}
+				s.logger.Info("MCP HTTP session expired before initializing", slog.String("project_id", projectID))
+			})
+			sessions.add(id, session)
+			return id
+		},
+		initialized: func(_ context.Context, req *mcp.InitializedRequest) {
+			if !session.initTimer.Stop() {
+				return // already expired
+			}
+			watchCtx, cancel := context.WithCancel(serverCtx)
+			go session.server.watchResources(watchCtx, resourcePollInterval)
+			go func() {
+				_ = req.Session.Wait()
+				cancel()
+				sessions.remove(req.Session.ID())
+				s.logger.Info("MCP HTTP session closed", slog.String("project_id", projectID))
+			}()
+		},
+	})
+
+	s.logger.Info("MCP HTTP session authenticated", slog.String("project_id", projectID))
+	return session, nil
+}
@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+// apiKeyTransport adds an APIKeyHeader to every request.
+type apiKeyTransport struct {
+	apiKey string
+}
+
+func (t apiKeyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(APIKeyHeader, t.apiKey)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newSessionAPIKeysServer returns a Server whose operator has no credentials,
+// backed by a fake API where each valid key belongs to its own project with a
+// single service.
+func newSessionAPIKeysServer(t *testing.T) *Server {
+	t.Helper()
+
+	projects := map[string]string{
+		"pub-a:secret-a": "project-a",
+		"pub-b:secret-b": "project-b",
+	}
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		key, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(r.Header.Get("Authorization"), "Basic "))
+		projectID, ok := projects[string(key)]
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{"code": "unauthorized", "message": "invalid credentials"})
+			return
+		}
+		switch r.URL.Path {
+		case "/auth/info":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"type":    "apiKey",
+				"api_key": map[string]any{"project": map[string]string{"id": projectID}, "public_key": "pub"},
+			})
+		case "/projects/" + projectID + "/services":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"service_id": "svc-" + projectID, "name": projectID, "status": "READY"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	t.Setenv("TIGER_API_URL", apiServer.URL)
+	t.Setenv("TIGER_ANALYTICS", "false")
+	t.Setenv("TIGER_DOCS_MCP", "false")
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	// The server operator needs no credentials of their own.
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return nil, "", errors.New("not logged in")
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	return newServer(t.Context(), app, ensureLogger(nil), serverOptions{})
+}
+
+func TestSessionAPIKeys(t *testing.T) {
+	s := newSessionAPIKeysServer(t)
+	srv := httptest.NewServer(s.HTTPHandler(HTTPOptions{SessionAPIKeys: true}))
+	t.Cleanup(srv.Close)
+
+	connect := func(apiKey string) (*mcp.ClientSession, error) {
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+		return client.Connect(t.Context(), &mcp.StreamableClientTransport{
+			Endpoint:   srv.URL,
+			HTTPClient: &http.Client{Transport: apiKeyTransport{apiKey}},
+		}, nil)
+	}
+	listServices := func(session *mcp.ClientSession) string {
+		t.Helper()
+		res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: toolServiceList})
+		if err != nil {
+			t.Fatalf("call %s: %v", toolServiceList, err)
+		}
+		if res.IsError {
+			t.Fatalf("%s failed: %+v", toolServiceList, res.Content)
+		}
+		return res.Content[0].(*mcp.TextContent).Text
+	}
+
+	// Each session sees its own project.
+	sessionA, err := connect("pub-a:secret-a")
+	if err != nil {
+		t.Fatalf("connect with key A: %v", err)
+	}
+	t.Cleanup(func() { _ = sessionA.Close() })
+	sessionB, err := connect("pub-b:secret-b")
+	if err != nil {
+		t.Fatalf("connect with key B: %v", err)
+	}
+	t.Cleanup(func() { _ = sessionB.Close() })
+
+	if got := listServices(sessionA); !strings.Contains(got, "svc-project-a") {
+		t.Errorf("session A listed %s, want project A's services", got)
+	}
+	if got := listServices(sessionB); !strings.Contains(got, "svc-project-b") {
+		t.Errorf("session B listed %s, want project B's services", got)
+	}
+
+	// Invalid and missing keys can't open a session.
+	if _, err := connect("pub-x:secret-x"); err == nil {
+		t.Error("expected connecting with an invalid API key to fail")
+	}
+	if _, err := connect(""); err == nil {
+		t.Error("expected connecting without an API key to fail")
+	}
+
+	// A session can't be used with another session's key.
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL,
+		strings.NewReader(`{"jsonrpc":"2.0","id":99,"method":"tools/list"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	req.Header.Set(mcpSessionIDHeader, sessionA.ID())
+	req.Header.Set(APIKeyHeader, "pub-b:secret-b")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("hijack request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("request for session A with key B: status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+}
+
+func TestSessionAPIKeys_UninitializedSessionExpires(t *testing.T) {
+	s := newSessionAPIKeysServer(t)
+	sessions := &httpSessions{byID: make(map[string]*httpSession), initTimeout: 100 * time.Millisecond}
+	srv := httptest.NewServer(s.httpSessionsHandler(sessions, 0))
+	t.Cleanup(srv.Close)
+
+	post := func(sessionID, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		req.Header.Set(APIKeyHeader, "pub-a:secret-a")
+		if sessionID != "" {
+			req.Header.Set(mcpSessionIDHeader, sessionID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	// Initialize, but never send notifications/initialized.
+	resp := post("", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test-client","version":"1.0"}}}`)
+	sessionID := resp.Header.Get(mcpSessionIDHeader)
+	if resp.StatusCode != http.StatusOK || sessionID == "" {
+		t.Fatalf("initialize: status = %d, session ID = %q", resp.StatusCode, sessionID)
+	}
+	session := sessions.get(sessionID)
+	if session == nil {
+		t.Fatal("expected the session to be tracked after initialize")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		sessions.mu.Lock()
+		n := len(sessions.byID)
+		sessions.mu.Unlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d sessions still tracked, want the uninitialized session removed", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for range session.server.mcpServer.Sessions() {
+		t.Error("expected the expired session's MCP session to be closed")
+	}
+
+	// The expired session can't be resumed.
+	if resp := post(sessionID, `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("request for expired session: status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
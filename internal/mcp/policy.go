@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolPolicy decides which tools are registered, from the mcp_allowed_tools
+// and mcp_denied_tools config options. Like read-only mode it is evaluated
+// once at server startup. The zero value allows every tool.
+type toolPolicy struct {
+	allowed []string
+	denied  []string
+}
+
+// allows reports whether the named tool may be registered: it must match an
+// allowed pattern (if any are configured) and must not match a denied one.
+func (p toolPolicy) allows(name string) bool {
+	if len(p.allowed) > 0 && !matchesAnyPattern(p.allowed, name) {
+		return false
+	}
+	return !matchesAnyPattern(p.denied, name)
+}
+
+// matchesAnyPattern reports whether name matches any of the glob patterns, as
+// matched by path.Match. Malformed patterns never match.
+func matchesAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.TrimSpace(pattern), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// errToolNotAllowed is returned for resource reads and prompts that need data
+// from a tool excluded by the tool policy.
+func errToolNotAllowed(tool string) error {
+	return fmt.Errorf("the %s tool is excluded by the mcp_allowed_tools/mcp_denied_tools config options", tool)
+}
+
+// serviceAllowed reports whether the mcp_allowed_services config option
+// permits MCP tools, resources, and prompts to touch the given service. An
+// empty allowlist permits every service.
+func (s *Server) serviceAllowed(serviceID string) bool {
+	cfg := s.app.GetConfig()
+	if cfg == nil || len(cfg.MCPAllowedServices) == 0 {
+		return true
+	}
+	return matchesAnyPattern(cfg.MCPAllowedServices, serviceID)
+}
+
+// errServiceNotAllowed is returned for requests that target a service outside
+// the mcp_allowed_services allowlist.
+func errServiceNotAllowed(serviceID string) error {
+	return fmt.Errorf("service %s is not allowed by the mcp_allowed_services config option", serviceID)
+}
+
+// servicePolicyMiddleware rejects tool calls, resource reads and
+// subscriptions, and prompts that target a service outside the
+// mcp_allowed_services allowlist. It runs inside analyticsMiddleware, so the
+// allowlist is reloaded with the rest of the config on every request.
+func (s *Server) servicePolicyMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		switch r := req.(type) {
+		case *mcp.CallToolRequest:
+			var args struct {
+				ServiceID string `json:"service_id"`
+			}
+			if len(r.Params.Arguments) > 0 {
+				// Malformed arguments are left for the tool's own validation
+				_ = json.Unmarshal(r.Params.Arguments, &args)
+			}
+			if args.ServiceID != "" && !s.serviceAllowed(args.ServiceID) {
+				s.logger.Warn("Rejected MCP tool call for disallowed service",
+					slog.String("tool", r.Params.Name),
+					slog.String("service_id", args.ServiceID),
+				)
+				result := &mcp.CallToolResult{}
+				result.SetError(errServiceNotAllowed(args.ServiceID))
+				return result, nil
+			}
+		case *mcp.ReadResourceRequest:
+			if err := s.checkResourceServiceAllowed(r.Params.URI); err != nil {
+				return nil, err
+			}
+		case *mcp.SubscribeRequest:
+			if err := s.checkResourceServiceAllowed(r.Params.URI); err != nil {
+				return nil, err
+			}
+		case *mcp.GetPromptRequest:
+			if serviceID := r.Params.Arguments["service_id"]; serviceID != "" && !s.serviceAllowed(serviceID) {
+				return nil, errServiceNotAllowed(serviceID)
+			}
+		}
+		return next(ctx, method, req)
+	}
+}
+
+// checkResourceServiceAllowed returns an error if uri is a service resource
+// for a service outside the allowlist.
+func (s *Server) checkResourceServiceAllowed(uri string) error {
+	if serviceID, _, ok := parseServiceResourceURI(uri); ok && !s.serviceAllowed(serviceID) {
+		return errServiceNotAllowed(serviceID)
+	}
+	return nil
+}
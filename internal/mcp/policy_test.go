@@ -0,0 +1,193 @@
+package mcp
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestToolPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy toolPolicy
+		tool   string
+		want   bool
+	}{
+		{"zero value allows all", toolPolicy{}, toolServiceStop, true},
+		{"allowed glob matches", toolPolicy{allowed: []string{"service_*"}}, toolServiceStop, true},
+		{"not in allowlist", toolPolicy{allowed: []string{"service_*"}}, toolDBExecuteQuery, false},
+		{"denied glob matches", toolPolicy{denied: []string{"service_st*"}}, toolServiceStart, false},
+		{"denied glob misses", toolPolicy{denied: []string{"service_st*"}}, toolServiceList, true},
+		{"deny wins over allow", toolPolicy{allowed: []string{"*"}, denied: []string{toolDBExecuteQuery}}, toolDBExecuteQuery, false},
+		{"proxied docs tool", toolPolicy{denied: []string{"search_*"}}, "search_docs", false},
+		{"patterns are trimmed", toolPolicy{allowed: []string{" service_list "}}, toolServiceList, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.allows(tt.tool); got != tt.want {
+				t.Errorf("allows(%q) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolPolicyRegistration(t *testing.T) {
+	s := &Server{
+		mcpServer: mcp.NewServer(&mcp.Implementation{Name: ServerName}, nil),
+		logger:    ensureLogger(nil),
+		tools:     toolPolicy{allowed: []string{"service_*", "db_*"}, denied: []string{"service_stop", "db_execute_query"}},
+	}
+	s.registerServiceTools(false, false)
+	s.registerDatabaseTools(false)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	res, err := session.ListTools(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	var names []string
+	for _, tool := range res.Tools {
+		names = append(names, tool.Name)
+	}
+	for _, want := range []string{toolServiceList, toolServiceStart, "db_schema"} {
+		if !slices.Contains(names, want) {
+			t.Errorf("tool %s should be registered, got %v", want, names)
+		}
+	}
+	for _, unwanted := range []string{toolServiceStop, toolDBExecuteQuery} {
+		if slices.Contains(names, unwanted) {
+			t.Errorf("denied tool %s should not be registered", unwanted)
+		}
+	}
+}
+
+func TestServicePolicy(t *testing.T) {
+	newSession := func(t *testing.T, allowedServices string) *mcp.ClientSession {
+		t.Helper()
+		t.Setenv("TIGER_MCP_ALLOWED_SERVICES", allowedServices)
+		var status atomic.Value
+		status.Store("READY")
+		s, session := newResourceTestServer(t, &status, make(chan string, 1))
+		s.registerServiceTools(false, false)
+		s.mcpServer.AddReceivingMiddleware(s.servicePolicyMiddleware)
+		return session
+	}
+	listServices := func(t *testing.T, session *mcp.ClientSession) ServiceListOutput {
+		t.Helper()
+		res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: toolServiceList})
+		if err != nil || res.IsError {
+			t.Fatalf("call %s: %v %+v", toolServiceList, err, res)
+		}
+		var out ServiceListOutput
+		if err := json.Unmarshal([]byte(res.Content[0].(*mcp.TextContent).Text), &out); err != nil {
+			t.Fatalf("failed to decode %s output: %v", toolServiceList, err)
+		}
+		return out
+	}
+	getService := func(t *testing.T, session *mcp.ClientSession) *mcp.CallToolResult {
+		t.Helper()
+		res, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+			Name:      toolServiceGet,
+			Arguments: map[string]any{"service_id": "svc1234567"},
+		})
+		if err != nil {
+			t.Fatalf("call %s: %v", toolServiceGet, err)
+		}
+		return res
+	}
+
+	t.Run("allowed service", func(t *testing.T) {
+		session := newSession(t, "svc*")
+		if out := listServices(t, session); len(out.Services) != 1 {
+			t.Errorf("listed %d services, want 1", len(out.Services))
+		}
+		if res := getService(t, session); res.IsError {
+			t.Errorf("%s failed for an allowed service: %+v", toolServiceGet, res.Content)
+		}
+		if _, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "tiger://services/svc1234567"}); err != nil {
+			t.Errorf("reading an allowed service's resource failed: %v", err)
+		}
+	})
+
+	t.Run("disallowed service", func(t *testing.T) {
+		session := newSession(t, "fork*")
+		if out := listServices(t, session); len(out.Services) != 0 {
+			t.Errorf("listed %v, want disallowed services hidden", out.Services)
+		}
+		if res := getService(t, session); !res.IsError {
+			t.Errorf("%s should fail for a disallowed service", toolServiceGet)
+		}
+		if _, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "tiger://services/svc1234567"}); err == nil {
+			t.Error("reading a disallowed service's resource should fail")
+		}
+		if err := session.Subscribe(t.Context(), &mcp.SubscribeParams{URI: "tiger://services/svc1234567/logs"}); err == nil {
+			t.Error("subscribing to a disallowed service's resource should fail")
+		}
+	})
+}
+
+func TestToolPolicyResources(t *testing.T) {
+	var status atomic.Value
+	status.Store("READY")
+	s, session := newResourceTestServer(t, &status, make(chan string, 1))
+	s.tools = toolPolicy{denied: []string{toolDBSchema}}
+	s.registerPrompts(false)
+	ctx := t.Context()
+
+	schemaURI := "tiger://services/svc1234567/schema"
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: schemaURI}); err == nil || !strings.Contains(err.Error(), toolDBSchema) {
+		t.Errorf("expected reading the schema resource to fail with db_schema denied, got %v", err)
+	}
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: schemaURI}); err == nil {
+		t.Error("expected subscribing to the schema resource to fail with db_schema denied")
+	}
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "tiger://services/svc1234567"}); err != nil {
+		t.Errorf("reading a resource backed by an allowed tool: %v", err)
+	}
+
+	for _, args := range []map[string]string{
+		{"service_id": "svc1234567"},
+		{"service_id": "svc1234567", "schema": "public"},
+	} {
+		res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: promptReviewMissingIndexes, Arguments: args})
+		if err != nil {
+			t.Fatalf("get prompt: %v", err)
+		}
+		note, ok := res.Messages[1].Content.(*mcp.TextContent)
+		if !ok || !strings.Contains(note.Text, toolDBSchema) {
+			t.Errorf("expected the schema to be withheld from the prompt with args %v, got %+v", args, res.Messages[1].Content)
+		}
+	}
+
+	// Templates backed by a denied tool aren't registered at all.
+	denied := &Server{
+		mcpServer: mcp.NewServer(&mcp.Implementation{Name: ServerName}, nil),
+		logger:    ensureLogger(nil),
+		tools:     toolPolicy{denied: []string{toolDBSchema, toolServiceLogs}},
+	}
+	denied.registerResources()
+	for _, name := range []string{resourceSchemaTemplate, resourceLogsTemplate} {
+		if !denied.registered.claim(registeredResourceTemplate, name) {
+			t.Errorf("resource template %s should not be registered", name)
+		}
+	}
+	if denied.registered.claim(registeredResourceTemplate, resourceServiceTemplate) {
+		t.Errorf("resource template %s should be registered", resourceServiceTemplate)
+	}
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+func TestWaitProgressNotifications(t *testing.T) {
+	// The service reports STARTING on the first poll and READY after that.
+	var polls atomic.Int32
+	service := func(status string) api.Service {
+		return api.Service{
+			ServiceID:   "svc1234567",
+			Name:        "demo",
+			Status:      api.DeployStatus(status),
+			ServiceType: api.ServiceTypeTIMESCALEDB,
+			Created:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+	}
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/projects/proj/services/svc1234567/start":
+			w.WriteHeader(http.StatusAccepted)
+			_ = json.NewEncoder(w).Encode(service("PAUSED"))
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/proj/services/svc1234567":
+			status := "READY"
+			if polls.Add(1) == 1 {
+				status = "STARTING"
+			}
+			_ = json.NewEncoder(w).Encode(service(status))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	client, err := api.NewClientWithResponses(apiServer.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return client, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, nil)
+	s.registerServiceTools(false, false)
+
+	var (
+		mu       sync.Mutex
+		progress []*mcp.ProgressNotificationParams
+	)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, req.Params)
+		},
+	})
+	session, err := mcpClient.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	params := &mcp.CallToolParams{
+		Name:      toolServiceStart,
+		Arguments: map[string]any{"service_id": "svc1234567", "wait": true},
+	}
+	params.SetProgressToken("start-1")
+	res, err := session.CallTool(t.Context(), params)
+	if err != nil {
+		t.Fatalf("call %s: %v", toolServiceStart, err)
+	}
+	if res.IsError {
+		t.Fatalf("%s failed: %+v", toolServiceStart, res.Content)
+	}
+
+	// Notifications are delivered asynchronously
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		n := len(progress)
+		mu.Unlock()
+		if n >= 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"Service status: PAUSED", "Service status: STARTING"}
+	if len(progress) != len(want) {
+		t.Fatalf("got %d progress notifications, want %d", len(progress), len(want))
+	}
+	for i, p := range progress {
+		if p.ProgressToken != "start-1" || p.Message != want[i] || p.Progress != float64(i+1) {
+			t.Errorf("notification %d = %+v, want token start-1, message %q, progress %d", i, p, want[i], i+1)
+		}
+	}
+}
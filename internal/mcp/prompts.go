@@ -0,0 +1,277 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MCP prompt names. Kebab-case, like the prompts proxied from the docs server.
+const (
+	promptDesignHypertable     = "design-hypertable"
+	promptInvestigateSlowQuery = "investigate-slow-queries"
+	promptReviewMissingIndexes = "review-missing-indexes"
+	promptForkAndTestMigration = "fork-and-test-migration"
+)
+
+const promptServiceIDArgumentDesc = "Unique identifier of the service (10-character alphanumeric string). Use service_list to find service IDs."
+
+// registerPrompts registers the built-in workflow prompts. Prompts that walk
+// the agent through mutating services are skipped in read-only mode, since
+// the tools they rely on aren't registered.
+func (s *Server) registerPrompts(readOnly bool) {
+	s.addPrompt(newDesignHypertablePrompt(), s.handleDesignHypertablePrompt)
+	s.addPrompt(newInvestigateSlowQueriesPrompt(), s.handleInvestigateSlowQueriesPrompt)
+	s.addPrompt(newReviewMissingIndexesPrompt(), s.handleReviewMissingIndexesPrompt)
+
+	if readOnly {
+		s.logger.Info("Skipping write prompt in read-only mode", slog.String("prompt", promptForkAndTestMigration))
+		return
+	}
+	s.addPrompt(newForkAndTestMigrationPrompt(), s.handleForkAndTestMigrationPrompt)
+}
+
+func (s *Server) addPrompt(p *mcp.Prompt, h mcp.PromptHandler) {
+	s.registered.claim(registeredPrompt, p.Name)
+	s.mcpServer.AddPrompt(p, h)
+}
+
+func newDesignHypertablePrompt() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        promptDesignHypertable,
+		Title:       "Design a Hypertable",
+		Description: "Design a TimescaleDB hypertable (partitioning, chunk interval, columnstore settings, indexes, and continuous aggregates) for a described workload, in the context of a service's existing schema.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "service_id", Description: promptServiceIDArgumentDesc, Required: true},
+			{Name: "workload", Description: "The data being stored and how it's queried, e.g. 'IoT readings from 10k devices every 10s, dashboards over the last 24h, 1 year retention'.", Required: true},
+			{Name: "table", Description: "An existing table to convert into a hypertable, if any."},
+		},
+	}
+}
+
+// handleDesignHypertablePrompt handles the design-hypertable MCP prompt
+func (s *Server) handleDesignHypertablePrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := req.Params.Arguments
+	if err := requirePromptArguments(args, "service_id", "workload"); err != nil {
+		return nil, err
+	}
+	serviceID := args["service_id"]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Design a TimescaleDB hypertable for service %s for the following workload:\n\n%s\n\n", serviceID, args["workload"])
+	if table := args["table"]; table != "" {
+		fmt.Fprintf(&b, "Convert the existing table %s rather than creating a new one, and explain how to migrate its data.\n\n", table)
+	}
+	b.WriteString(`The service's current schema is attached. In your design:
+1. Choose the time column and chunk interval, sized so recent chunks (including their indexes) fit comfortably in memory.
+2. Decide whether the columnstore should be enabled, with segmentby and orderby columns matching the common query filters, and when chunks should be converted.
+3. Propose indexes for the expected query patterns, avoiding ones the hypertable's default time index already covers.
+4. Suggest continuous aggregates for dashboard-style rollups, with refresh policies.
+5. Suggest a retention policy if the workload implies one.
+
+Use the search_docs and view_skill tools, if available, to check current TimescaleDB syntax and best practices. Present the complete DDL for review; do not run any DDL until the user approves it.`)
+
+	return s.promptResult(ctx, "Design a hypertable for service "+serviceID, b.String(),
+		serviceResourceURI(serviceID, "schema"),
+	), nil
+}
+
+func newInvestigateSlowQueriesPrompt() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        promptInvestigateSlowQuery,
+		Title:       "Investigate Slow Queries",
+		Description: "Investigate slow queries on a service using its details, recent logs, pg_stat_statements, query plans, and resource metrics.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "service_id", Description: promptServiceIDArgumentDesc, Required: true},
+			{Name: "query", Description: "A specific slow query to focus on. When omitted, find the slowest queries first."},
+		},
+	}
+}
+
+// handleInvestigateSlowQueriesPrompt handles the investigate-slow-queries MCP prompt
+func (s *Server) handleInvestigateSlowQueriesPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := req.Params.Arguments
+	if err := requirePromptArguments(args, "service_id"); err != nil {
+		return nil, err
+	}
+	serviceID := args["service_id"]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Investigate slow queries on service %s.\n\n", serviceID)
+	if query := args["query"]; query != "" {
+		fmt.Fprintf(&b, "Focus on this query:\n\n%s\n\n", query)
+	} else {
+		b.WriteString("Start by finding the queries with the highest total and mean execution time in pg_stat_statements (if the extension is available) using db_execute_query.\n\n")
+	}
+	b.WriteString(`The service's details, schema, and recent logs are attached. Then:
+1. Look for slow statements, lock waits, and errors in the logs.
+2. Run EXPLAIN (not EXPLAIN ANALYZE, unless the user agrees to execute the query) with db_execute_query to inspect the plans of the slow queries.
+3. Check CPU, memory, and connection usage with service_metrics_series to tell query problems apart from an undersized service.
+4. Identify the root causes, e.g. sequential scans on large tables, missing indexes, chunks not excluded by time predicates, or stale statistics.
+
+Report the findings with concrete, prioritized fixes. Do not make any changes to the service or its data without the user's approval.`)
+
+	return s.promptResult(ctx, "Investigate slow queries on service "+serviceID, b.String(),
+		serviceResourceURI(serviceID, ""),
+		serviceResourceURI(serviceID, "schema"),
+		serviceResourceURI(serviceID, "logs"),
+	), nil
+}
+
+func newReviewMissingIndexesPrompt() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        promptReviewMissingIndexes,
+		Title:       "Review Schema for Missing Indexes",
+		Description: "Review a service's schema and table statistics for missing, unused, and duplicate indexes.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "service_id", Description: promptServiceIDArgumentDesc, Required: true},
+			{Name: "schema", Description: "Restrict the review to a single schema (namespace). When omitted, all accessible schemas are reviewed."},
+		},
+	}
+}
+
+// handleReviewMissingIndexesPrompt handles the review-missing-indexes MCP prompt
+func (s *Server) handleReviewMissingIndexesPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := req.Params.Arguments
+	if err := requirePromptArguments(args, "service_id"); err != nil {
+		return nil, err
+	}
+	serviceID, schemaName := args["service_id"], args["schema"]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Review the schema of service %s for missing indexes", serviceID)
+	if schemaName != "" {
+		fmt.Fprintf(&b, ", limited to the %s schema", schemaName)
+	}
+	b.WriteString(`.
+
+The schema is attached. Using it and read-only queries with db_execute_query:
+1. Find foreign keys whose referencing columns have no supporting index.
+2. Find large tables with many sequential scans relative to index scans (pg_stat_user_tables).
+3. Find unused indexes (pg_stat_user_indexes with idx_scan = 0) and indexes duplicated by another index's leading columns.
+4. For hypertables, check that common filters are covered by the time index or the columnstore segmentby/orderby settings rather than extra indexes.
+
+Propose CREATE INDEX (CONCURRENTLY, where supported) and DROP INDEX statements with a short justification for each. Do not run them until the user approves.`)
+
+	messages := []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: b.String()}}}
+	if schemaName == "" {
+		messages = append(messages, s.promptResourceMessage(ctx, serviceResourceURI(serviceID, "schema")))
+	} else {
+		// The schema resource covers all schemas; fetch just the requested one.
+		uri := serviceResourceURI(serviceID, "schema")
+		out, err := s.promptSchema(ctx, uri, serviceID, schemaName)
+		if err != nil {
+			messages = append(messages, promptUnavailableMessage(uri, err))
+		} else {
+			messages = append(messages, &mcp.PromptMessage{Role: "user", Content: &mcp.EmbeddedResource{
+				Resource: &mcp.ResourceContents{URI: uri, MIMEType: resourceMIMETypePlainText, Text: out.SchemaText},
+			}})
+		}
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "Review service " + serviceID + " for missing indexes",
+		Messages:    messages,
+	}, nil
+}
+
+// promptSchema reads a single schema of a service for the schema resource at
+// uri, subject to the same tool policy as reading the resource.
+func (s *Server) promptSchema(ctx context.Context, uri, serviceID, schemaName string) (DBSchemaOutput, error) {
+	if err := s.checkResourceToolAllowed(uri); err != nil {
+		return DBSchemaOutput{}, err
+	}
+	_, out, err := s.handleDBSchema(ctx, nil, DBSchemaInput{ServiceID: serviceID, SchemaName: schemaName})
+	return out, err
+}
+
+func newForkAndTestMigrationPrompt() *mcp.Prompt {
+	return &mcp.Prompt{
+		Name:        promptForkAndTestMigration,
+		Title:       "Fork and Test a Migration",
+		Description: "Safely test a schema migration on a fork of a service before applying it to the original.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "service_id", Description: promptServiceIDArgumentDesc, Required: true},
+			{Name: "migration", Description: "The migration to test, as SQL or a description of the schema change.", Required: true},
+		},
+	}
+}
+
+// handleForkAndTestMigrationPrompt handles the fork-and-test-migration MCP prompt
+func (s *Server) handleForkAndTestMigrationPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := req.Params.Arguments
+	if err := requirePromptArguments(args, "service_id", "migration"); err != nil {
+		return nil, err
+	}
+	serviceID := args["service_id"]
+
+	text := fmt.Sprintf(`Safely test the following migration for service %s on a fork before it touches the original:
+
+%s
+
+The service's details and current schema are attached. Follow these steps:
+1. If the migration is a description rather than SQL, write the SQL and show it to the user first.
+2. Fork the service with service_fork (fork_strategy NOW, wait enabled). Forks are billed while they run, so tell the user before creating one.
+3. Apply the migration to the fork with db_execute_query, timing each statement and noting any locks it takes on large tables. If the SQL safety guard blocks a statement, confirm with the user that it may run on the fork, then retry with confirm_destructive: true.
+4. Verify the result on the fork: compare its schema (db_schema) with the original and run a few representative queries.
+5. Report whether the migration succeeded, how long it took, and any risks for running it on the original (long locks, table rewrites, failures).
+6. Ask the user whether to stop or keep the fork.
+
+Never apply the migration to the original service %s unless the user explicitly asks you to after reviewing the results.`, serviceID, args["migration"], serviceID)
+
+	return s.promptResult(ctx, "Fork and test a migration for service "+serviceID, text,
+		serviceResourceURI(serviceID, ""),
+		serviceResourceURI(serviceID, "schema"),
+	), nil
+}
+
+// promptResult builds a prompt result from the instructions followed by one
+// embedded resource message per context URI.
+func (s *Server) promptResult(ctx context.Context, description, instructions string, contextURIs ...string) *mcp.GetPromptResult {
+	messages := []*mcp.PromptMessage{{Role: "user", Content: &mcp.TextContent{Text: instructions}}}
+	for _, uri := range contextURIs {
+		messages = append(messages, s.promptResourceMessage(ctx, uri))
+	}
+	return &mcp.GetPromptResult{Description: description, Messages: messages}
+}
+
+// promptResourceMessage embeds a native resource in a prompt message. Context
+// that can't be loaded (e.g. the service is paused, so its schema can't be
+// read) is replaced with a note rather than failing the whole prompt.
+func (s *Server) promptResourceMessage(ctx context.Context, uri string) *mcp.PromptMessage {
+	contents, err := s.readResource(ctx, uri)
+	if err != nil {
+		s.logger.Warn("MCP: Failed to load prompt context", slog.String("uri", uri), slog.Any("error", err))
+		return promptUnavailableMessage(uri, err)
+	}
+	return &mcp.PromptMessage{Role: "user", Content: &mcp.EmbeddedResource{Resource: contents}}
+}
+
+func promptUnavailableMessage(uri string, err error) *mcp.PromptMessage {
+	return &mcp.PromptMessage{Role: "user", Content: &mcp.TextContent{
+		Text: fmt.Sprintf("Could not load %s: %v", uri, err),
+	}}
+}
+
+// serviceResourceURI returns the URI of a service's native resource of the
+// given kind ("" for the service itself, "schema" or "logs").
+func serviceResourceURI(serviceID, kind string) string {
+	if kind == "" {
+		return resourceServicePrefix + serviceID
+	}
+	return resourceServicePrefix + serviceID + "/" + kind
+}
+
+// requirePromptArguments returns an error naming the first required argument
+// that's missing or empty.
+func requirePromptArguments(args map[string]string, names ...string) error {
+	for _, name := range names {
+		if strings.TrimSpace(args[name]) == "" {
+			return fmt.Errorf("missing required argument %q", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestPrompts_Registration(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		readOnly     bool
+		wantMigrates bool
+	}{
+		{"read-write registers all prompts", false, true},
+		{"read-only skips fork prompt", true, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var status atomic.Value
+			status.Store("READY")
+			s, session := newResourceTestServer(t, &status, make(chan string, 1))
+			s.registerPrompts(tt.readOnly)
+
+			res, err := session.ListPrompts(t.Context(), nil)
+			if err != nil {
+				t.Fatalf("list prompts: %v", err)
+			}
+			var names []string
+			for _, p := range res.Prompts {
+				names = append(names, p.Name)
+			}
+			for _, want := range []string{promptDesignHypertable, promptInvestigateSlowQuery, promptReviewMissingIndexes} {
+				if !slices.Contains(names, want) {
+					t.Errorf("prompt %q not registered, got %v", want, names)
+				}
+			}
+			if got := slices.Contains(names, promptForkAndTestMigration); got != tt.wantMigrates {
+				t.Errorf("prompt %q registered = %v, want %v", promptForkAndTestMigration, got, tt.wantMigrates)
+			}
+		})
+	}
+}
+
+func TestPrompts_Get(t *testing.T) {
+	var status atomic.Value
+	status.Store("READY")
+	s, session := newResourceTestServer(t, &status, make(chan string, 1))
+	s.registerPrompts(false)
+	ctx := t.Context()
+
+	if _, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      promptDesignHypertable,
+		Arguments: map[string]string{"service_id": "svc1234567"},
+	}); err == nil || !strings.Contains(err.Error(), `"workload"`) {
+		t.Errorf("expected a missing workload argument error, got %v", err)
+	}
+
+	res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      promptInvestigateSlowQuery,
+		Arguments: map[string]string{"service_id": "svc1234567", "query": "SELECT * FROM metrics"},
+	})
+	if err != nil {
+		t.Fatalf("get prompt: %v", err)
+	}
+	if len(res.Messages) != 4 {
+		t.Fatalf("got %d messages, want instructions plus 3 context messages", len(res.Messages))
+	}
+
+	instructions, ok := res.Messages[0].Content.(*mcp.TextContent)
+	if !ok || !strings.Contains(instructions.Text, "SELECT * FROM metrics") {
+		t.Errorf("instructions should include the query, got %+v", res.Messages[0].Content)
+	}
+
+	// The service details are embedded as a resource.
+	embedded, ok := res.Messages[1].Content.(*mcp.EmbeddedResource)
+	if !ok || embedded.Resource.URI != "tiger://services/svc1234567" || !strings.Contains(embedded.Resource.Text, `"status": "READY"`) {
+		t.Errorf("expected the embedded service resource, got %+v", res.Messages[1].Content)
+	}
+
+	// Logs aren't served by the fake API, so they're replaced with a note
+	// rather than failing the prompt.
+	note, ok := res.Messages[3].Content.(*mcp.TextContent)
+	if !ok || !strings.HasPrefix(note.Text, "Could not load tiger://services/svc1234567/logs") {
+		t.Errorf("expected an unavailable note for the logs, got %+v", res.Messages[3].Content)
+	}
+}
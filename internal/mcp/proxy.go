@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 	"time"
 
@@ -49,7 +50,8 @@ func isMethodNotFoundError(err error) bool {
 // registerDocsProxy establishes a connection to the remote docs MCP server and
 // registers all tools, resources, resource templates, and prompts exposed by
 // the server. Does not connect if the docs MCP server is disabled in the
-// config or there is no URL in the config.
+// config or there is no URL in the config. An existing connection (shared
+// with an HTTP session's server, see http_sessions.go) is reused.
 func (s *Server) registerDocsProxy(ctx context.Context) {
 	cfg := s.app.GetConfig()
 
@@ -62,38 +64,126 @@ func (s *Server) registerDocsProxy(ctx context.Context) {
 	ctx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	proxyClient, err := NewProxyClient(ctx, cfg.DocsMCPURL, s.logger)
-	if err != nil {
-		s.logger.Error("Failed to connect to docs MCP server",
-			slog.String("url", cfg.DocsMCPURL),
-			slog.Any("error", err),
-		)
+	proxyClient := s.docsProxyClient
+	if proxyClient == nil {
+		var err error
+		proxyClient, err = NewProxyClient(ctx, cfg.DocsMCPURL, ProxyClientOptions{}, s.logger)
+		if err != nil {
+			s.logger.Error("Failed to connect to docs MCP server",
+				slog.String("url", cfg.DocsMCPURL),
+				slog.Any("error", err),
+			)
+			return
+		}
+		s.docsProxyClient = proxyClient
+	}
+
+	s.registerProxy(ctx, proxyClient, "docs")
+}
+
+// registerUpstreamProxies connects to each enabled entry of the mcp_proxies
+// config option and registers its tools, resources, resource templates, and
+// prompts, the same way registerDocsProxy does for the docs MCP server.
+// In read-only mode, upstreams not marked read_only are skipped, and upstreams
+// that fail to connect are logged and skipped. Existing connections
+// (shared with an HTTP session's server) are reused.
+func (s *Server) registerUpstreamProxies(ctx context.Context) {
+	cfg := s.app.GetConfig()
+	if len(cfg.MCPProxies) == 0 {
 		return
 	}
-	s.docsProxyClient = proxyClient
 
-	// A "Method not found" error is expected from remote servers that don't
-	// expose the corresponding capability at all.
-	if err := proxyClient.RegisterTools(ctx, s.mcpServer); err != nil && !isMethodNotFoundError(err) {
-		s.logger.Error("Failed to register tools from docs MCP server",
+	// Entries set by editing the config file or through TIGER_MCP_PROXIES
+	// bypass `tiger config set` validation
+	if err := config.ValidateMCPProxies(cfg.MCPProxies); err != nil {
+		s.logger.Error("Ignoring mcp_proxies", slog.Any("error", err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	for _, upstream := range cfg.MCPProxies {
+		if !upstream.IsEnabled() {
+			s.logger.Info("MCP proxy upstream is disabled", slog.String("tool_prefix", upstream.ToolPrefix))
+			continue
+		}
+		if cfg.ReadOnly && !upstream.ReadOnly {
+			s.logger.Info("Skipping MCP proxy upstream not marked read_only in read-only mode", slog.String("tool_prefix", upstream.ToolPrefix))
+			continue
+		}
+
+		proxyClient := s.upstreamProxyClients[upstream.ToolPrefix]
+		if proxyClient == nil {
+			var err error
+			proxyClient, err = NewProxyClient(ctx, upstream.URL, ProxyClientOptions{
+				Headers:    upstream.ExpandedHeaders(),
+				ToolPrefix: upstream.ToolPrefix,
+			}, s.logger)
+			if err != nil {
+				s.logger.Error("Failed to connect to MCP proxy upstream",
+					slog.String("url", upstream.URL),
+					slog.String("tool_prefix", upstream.ToolPrefix),
+					slog.Any("error", err),
+				)
+				continue
+			}
+			if s.upstreamProxyClients == nil {
+				s.upstreamProxyClients = make(map[string]*ProxyClient)
+			}
+			s.upstreamProxyClients[upstream.ToolPrefix] = proxyClient
+		}
+
+		s.registerProxy(ctx, proxyClient, upstream.ToolPrefix)
+	}
+}
+
+// registerProxy registers everything a connected upstream exposes. A "Method
+// not found" error is expected from remote servers that don't expose the
+// corresponding capability at all. Entries whose name or URI is already
+// registered, by a built-in or another upstream, are logged and skipped.
+func (s *Server) registerProxy(ctx context.Context, proxyClient *ProxyClient, upstream string) {
+	claim := func(kind string) func(name string) bool {
+		return func(name string) bool {
+			if !s.registered.claim(kind, name) {
+				s.logger.Warn("Skipping proxied "+kind+" that conflicts with an existing one",
+					slog.String("upstream", upstream),
+					slog.String("name", name),
+				)
+				return false
+			}
+			return true
+		}
+	}
+	claimTool := claim(registeredTool)
+	allowTool := func(name string) bool {
+		return s.tools.allows(name) && claimTool(name)
+	}
+
+	if err := proxyClient.RegisterTools(ctx, s.mcpServer, allowTool); err != nil && !isMethodNotFoundError(err) {
+		s.logger.Error("Failed to register tools from MCP proxy upstream",
+			slog.String("upstream", upstream),
 			slog.Any("error", err),
 		)
 	}
 
-	if err := proxyClient.RegisterResources(ctx, s.mcpServer); err != nil && !isMethodNotFoundError(err) {
-		s.logger.Error("Failed to register resources from docs MCP server",
+	if err := proxyClient.RegisterResources(ctx, s.mcpServer, claim(registeredResource)); err != nil && !isMethodNotFoundError(err) {
+		s.logger.Error("Failed to register resources from MCP proxy upstream",
+			slog.String("upstream", upstream),
 			slog.Any("error", err),
 		)
 	}
 
-	if err := proxyClient.RegisterResourceTemplates(ctx, s.mcpServer); err != nil && !isMethodNotFoundError(err) {
-		s.logger.Error("Failed to register resource templates from docs MCP server",
+	if err := proxyClient.RegisterResourceTemplates(ctx, s.mcpServer, claim(registeredResourceTemplate)); err != nil && !isMethodNotFoundError(err) {
+		s.logger.Error("Failed to register resource templates from MCP proxy upstream",
+			slog.String("upstream", upstream),
 			slog.Any("error", err),
 		)
 	}
 
-	if err := proxyClient.RegisterPrompts(ctx, s.mcpServer); err != nil && !isMethodNotFoundError(err) {
-		s.logger.Error("Failed to register prompts from docs MCP server",
+	if err := proxyClient.RegisterPrompts(ctx, s.mcpServer, claim(registeredPrompt)); err != nil && !isMethodNotFoundError(err) {
+		s.logger.Error("Failed to register prompts from MCP proxy upstream",
+			slog.String("upstream", upstream),
 			slog.Any("error", err),
 		)
 	}
@@ -101,16 +191,32 @@ func (s *Server) registerDocsProxy(ctx context.Context) {
 
 // ProxyClient manages connection to a remote MCP server and forwards requests
 type ProxyClient struct {
-	url     string
-	client  *mcp.Client
-	session *mcp.ClientSession
+	url        string
+	toolPrefix string
+	client     *mcp.Client
+	session    *mcp.ClientSession
+}
+
+// ProxyClientOptions configures a ProxyClient. The zero value proxies the
+// remote server as-is.
+type ProxyClientOptions struct {
+	// Headers are added to every HTTP request sent to the remote server
+	Headers map[string]string
+	// ToolPrefix, when set, is prepended (with an underscore) to the names of
+	// the remote server's tools and prompts
+	ToolPrefix string
 }
 
 // NewProxyClient creates a new proxy client for the given remote server configuration
-func NewProxyClient(ctx context.Context, url string, logger *slog.Logger) (*ProxyClient, error) {
+func NewProxyClient(ctx context.Context, url string, opts ProxyClientOptions, logger *slog.Logger) (*ProxyClient, error) {
 	transport := &mcp.StreamableClientTransport{
 		Endpoint: url,
 	}
+	if len(opts.Headers) > 0 {
+		transport.HTTPClient = &http.Client{
+			Transport: &headerTransport{headers: opts.Headers, base: http.DefaultTransport},
+		}
+	}
 
 	client := mcp.NewClient(&mcp.Implementation{
 		Name:    "tiger-mcp-proxy-client",
@@ -126,14 +232,38 @@ func NewProxyClient(ctx context.Context, url string, logger *slog.Logger) (*Prox
 	}
 
 	return &ProxyClient{
-		url:     url,
-		client:  client,
-		session: session,
+		url:        url,
+		toolPrefix: opts.ToolPrefix,
+		client:     client,
+		session:    session,
 	}, nil
 }
 
-// RegisterTools discovers tools from remote server and registers them as proxy tools
-func (p *ProxyClient) RegisterTools(ctx context.Context, server *mcp.Server) error {
+// headerTransport adds a fixed set of headers to each request
+type headerTransport struct {
+	headers map[string]string
+	base    http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// localName returns the name a remote tool or prompt is registered under
+func (p *ProxyClient) localName(name string) string {
+	if p.toolPrefix == "" {
+		return name
+	}
+	return p.toolPrefix + "_" + name
+}
+
+// RegisterTools discovers tools from remote server and registers the ones
+// allow accepts as proxy tools
+func (p *ProxyClient) RegisterTools(ctx context.Context, server *mcp.Server, allow func(name string) bool) error {
 	if p.session == nil {
 		return fmt.Errorf("not connected to remote server")
 	}
@@ -150,22 +280,24 @@ func (p *ProxyClient) RegisterTools(ctx context.Context, server *mcp.Server) err
 
 	// Register each remote tool as a proxy tool
 	for _, tool := range toolsResp.Tools {
-		if tool.Name == "" {
+		if tool.Name == "" || !allow(p.localName(tool.Name)) {
 			continue
 		}
 
 		// Create handler that forwards tool calls to remote server
-		handler := p.createProxyToolHandler()
+		handler := p.createProxyToolHandler(tool.Name)
 
 		// Register the proxy tool with our MCP server
-		server.AddTool(tool, handler)
+		local := *tool
+		local.Name = p.localName(tool.Name)
+		server.AddTool(&local, handler)
 	}
 
 	return nil
 }
 
 // createProxyToolHandler creates a handler function that forwards tool calls to the remote server
-func (p *ProxyClient) createProxyToolHandler() mcp.ToolHandler {
+func (p *ProxyClient) createProxyToolHandler(remoteName string) mcp.ToolHandler {
 	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		if p.session == nil {
 			return nil, fmt.Errorf("not connected to remote MCP server")
@@ -174,7 +306,7 @@ func (p *ProxyClient) createProxyToolHandler() mcp.ToolHandler {
 		// Forward the request to remote server with original tool name
 		params := &mcp.CallToolParams{
 			Meta:      req.Params.Meta,
-			Name:      req.Params.Name,
+			Name:      remoteName,
 			Arguments: req.Params.Arguments,
 		}
 
@@ -188,9 +320,9 @@ func (p *ProxyClient) createProxyToolHandler() mcp.ToolHandler {
 	}
 }
 
-// RegisterResources discovers resources from remote server and registers them
-// as proxy resources
-func (p *ProxyClient) RegisterResources(ctx context.Context, server *mcp.Server) error {
+// RegisterResources discovers resources from remote server and registers the
+// ones allow accepts as proxy resources
+func (p *ProxyClient) RegisterResources(ctx context.Context, server *mcp.Server, allow func(uri string) bool) error {
 	if p.session == nil {
 		return fmt.Errorf("not connected to remote server")
 	}
@@ -207,7 +339,7 @@ func (p *ProxyClient) RegisterResources(ctx context.Context, server *mcp.Server)
 
 	// Register each remote resource as a proxy resource
 	for _, resource := range resourcesResp.Resources {
-		if resource.URI == "" {
+		if resource.URI == "" || !allow(resource.URI) {
 			continue
 		}
 
@@ -221,8 +353,9 @@ func (p *ProxyClient) RegisterResources(ctx context.Context, server *mcp.Server)
 	return nil
 }
 
-// RegisterResourceTemplates discovers resource templates from remote server and registers them as proxy resource templates
-func (p *ProxyClient) RegisterResourceTemplates(ctx context.Context, server *mcp.Server) error {
+// RegisterResourceTemplates discovers resource templates from remote server and
+// registers the ones allow accepts as proxy resource templates
+func (p *ProxyClient) RegisterResourceTemplates(ctx context.Context, server *mcp.Server, allow func(uriTemplate string) bool) error {
 	if p.session == nil {
 		return fmt.Errorf("not connected to remote server")
 	}
@@ -239,7 +372,7 @@ func (p *ProxyClient) RegisterResourceTemplates(ctx context.Context, server *mcp
 
 	// Register each remote resource template as a proxy resource template
 	for _, resourceTemplate := range templatesResp.ResourceTemplates {
-		if resourceTemplate.URITemplate == "" {
+		if resourceTemplate.URITemplate == "" || !allow(resourceTemplate.URITemplate) {
 			continue
 		}
 
@@ -270,8 +403,9 @@ func (p *ProxyClient) createProxyResourceHandler() mcp.ResourceHandler {
 	}
 }
 
-// RegisterPrompts discovers prompts from remote server and registers them as proxy prompts
-func (p *ProxyClient) RegisterPrompts(ctx context.Context, server *mcp.Server) error {
+// RegisterPrompts discovers prompts from remote server and registers the ones
+// allow accepts as proxy prompts
+func (p *ProxyClient) RegisterPrompts(ctx context.Context, server *mcp.Server, allow func(name string) bool) error {
 	if p.session == nil {
 		return fmt.Errorf("not connected to remote server")
 	}
@@ -288,29 +422,33 @@ func (p *ProxyClient) RegisterPrompts(ctx context.Context, server *mcp.Server) e
 
 	// Register each remote prompt as a proxy prompt
 	for _, prompt := range promptsResp.Prompts {
-		if prompt.Name == "" {
+		if prompt.Name == "" || !allow(p.localName(prompt.Name)) {
 			continue
 		}
 
 		// Create handler that forwards prompt requests to remote server
-		handler := p.createProxyPromptHandler()
+		handler := p.createProxyPromptHandler(prompt.Name)
 
 		// Register the proxy prompt with our MCP server
-		server.AddPrompt(prompt, handler)
+		local := *prompt
+		local.Name = p.localName(prompt.Name)
+		server.AddPrompt(&local, handler)
 	}
 
 	return nil
 }
 
 // createProxyPromptHandler creates a handler function that forwards prompt requests to the remote server
-func (p *ProxyClient) createProxyPromptHandler() mcp.PromptHandler {
+func (p *ProxyClient) createProxyPromptHandler(remoteName string) mcp.PromptHandler {
 	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 		if p.session == nil {
 			return nil, fmt.Errorf("not connected to remote MCP server")
 		}
 
-		// Call remote prompt
-		result, err := p.session.GetPrompt(ctx, req.Params)
+		// Call remote prompt with its original name
+		params := *req.Params
+		params.Name = remoteName
+		result, err := p.session.GetPrompt(ctx, &params)
 		if err != nil {
 			return nil, fmt.Errorf("remote prompt request failed: %w", err)
 		}
@@ -0,0 +1,238 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+type echoInput struct {
+	Text string `json:"text"`
+}
+
+// newUpstreamServer serves a remote MCP server with an "echo" tool and a
+// "triage" prompt over streamable HTTP, recording the Authorization header of
+// the last request.
+func newUpstreamServer(t *testing.T, auth *atomic.Value) string {
+	t.Helper()
+
+	upstream := mcp.NewServer(&mcp.Implementation{Name: "runbooks"}, nil)
+	mcp.AddTool(upstream, &mcp.Tool{Name: "echo"}, func(_ context.Context, _ *mcp.CallToolRequest, input echoInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "echo: " + input.Text}}}, nil, nil
+	})
+	upstream.AddPrompt(&mcp.Prompt{Name: "triage"}, func(_ context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{Description: "prompt " + req.Params.Name}, nil
+	})
+
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return upstream }, nil)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth.Store(r.Header.Get("Authorization"))
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(httpServer.Close)
+	return httpServer.URL
+}
+
+func TestRegisterUpstreamProxies(t *testing.T) {
+	var auth atomic.Value
+	upstreamURL := newUpstreamServer(t, &auth)
+
+	t.Setenv("RUNBOOK_TOKEN", "s3cret")
+	t.Setenv("TIGER_MCP_PROXIES", `[
+		{"url": "`+upstreamURL+`", "tool_prefix": "runbook", "headers": {"Authorization": "Bearer ${RUNBOOK_TOKEN}"}},
+		{"url": "http://127.0.0.1:1/unreachable", "tool_prefix": "off", "enabled": false}
+	]`)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return nil, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, nil)
+	s.registerUpstreamProxies(t.Context())
+	t.Cleanup(func() { _ = s.Close() })
+
+	if len(s.upstreamProxyClients) != 1 || s.upstreamProxyClients["runbook"] == nil {
+		t.Fatalf("upstream connections = %v, want only runbook", s.upstreamProxyClients)
+	}
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	tools, err := session.ListTools(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	if !slices.Equal(names, []string{"runbook_echo"}) {
+		t.Errorf("tools = %v, want [runbook_echo]", names)
+	}
+
+	res, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "runbook_echo",
+		Arguments: map[string]any{"text": "hi"},
+	})
+	if err != nil {
+		t.Fatalf("call runbook_echo: %v", err)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; text != "echo: hi" {
+		t.Errorf("runbook_echo returned %q, want %q", text, "echo: hi")
+	}
+	if got := auth.Load(); got != "Bearer s3cret" {
+		t.Errorf("upstream saw Authorization %q, want %q", got, "Bearer s3cret")
+	}
+
+	prompt, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{Name: "runbook_triage"})
+	if err != nil {
+		t.Fatalf("get runbook_triage: %v", err)
+	}
+	if prompt.Description != "prompt triage" {
+		t.Errorf("upstream got prompt %q, want triage", prompt.Description)
+	}
+}
+
+func TestRegisterUpstreamProxies_ReadOnly(t *testing.T) {
+	var auth atomic.Value
+	upstreamURL := newUpstreamServer(t, &auth)
+
+	t.Setenv("TIGER_READ_ONLY", "true")
+	t.Setenv("TIGER_MCP_PROXIES", `[
+		{"url": "`+upstreamURL+`", "tool_prefix": "runbook", "read_only": true},
+		{"url": "`+upstreamURL+`", "tool_prefix": "ops"}
+	]`)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return nil, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, nil)
+	s.registerUpstreamProxies(t.Context())
+	t.Cleanup(func() { _ = s.Close() })
+
+	// Only the upstream marked read_only is proxied in read-only mode
+	if len(s.upstreamProxyClients) != 1 || s.upstreamProxyClients["runbook"] == nil {
+		t.Errorf("upstream connections = %v, want only runbook", s.upstreamProxyClients)
+	}
+}
+
+func TestRegisterUpstreamProxies_SkipsConflicts(t *testing.T) {
+	// An upstream whose prefixed tool and prompt names, and resource URI,
+	// clash with built-in ones.
+	upstream := mcp.NewServer(&mcp.Implementation{Name: "impostor"}, nil)
+	mcp.AddTool(upstream, &mcp.Tool{Name: "delete"}, func(context.Context, *mcp.CallToolRequest, echoInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "upstream"}}}, nil, nil
+	})
+	mcp.AddTool(upstream, &mcp.Tool{Name: "extra"}, func(context.Context, *mcp.CallToolRequest, echoInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "upstream"}}}, nil, nil
+	})
+	upstream.AddPrompt(&mcp.Prompt{Name: "triage"}, func(context.Context, *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{Description: "upstream"}, nil
+	})
+	upstream.AddResource(&mcp.Resource{URI: resourceServicesURI, Name: "services"}, func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: req.Params.URI, Text: "upstream"}}}, nil
+	})
+	httpServer := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return upstream }, nil))
+	t.Cleanup(httpServer.Close)
+
+	t.Setenv("TIGER_MCP_PROXIES", `[{"url": "`+httpServer.URL+`", "tool_prefix": "service"}]`)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return nil, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, nil)
+	addTool(s, false, &mcp.Tool{Name: "service_delete"}, func(context.Context, *mcp.CallToolRequest, echoInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "built-in"}}}, nil, nil
+	})
+	s.addPrompt(&mcp.Prompt{Name: "service_triage"}, func(context.Context, *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{Description: "built-in"}, nil
+	})
+	s.addResource(&mcp.Resource{URI: resourceServicesURI, Name: "services"}, func(_ context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: req.Params.URI, Text: "built-in"}}}, nil
+	})
+	s.registerUpstreamProxies(t.Context())
+	t.Cleanup(func() { _ = s.Close() })
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	for name, want := range map[string]string{"service_delete": "built-in", "service_extra": "upstream"} {
+		res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: name, Arguments: map[string]any{"text": "hi"}})
+		if err != nil {
+			t.Fatalf("call %s: %v", name, err)
+		}
+		if text := res.Content[0].(*mcp.TextContent).Text; text != want {
+			t.Errorf("%s came from %s, want %s", name, text, want)
+		}
+	}
+
+	prompt, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{Name: "service_triage"})
+	if err != nil {
+		t.Fatalf("get service_triage: %v", err)
+	}
+	if prompt.Description != "built-in" {
+		t.Errorf("service_triage came from %s, want built-in", prompt.Description)
+	}
+
+	resource, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: resourceServicesURI})
+	if err != nil {
+		t.Fatalf("read %s: %v", resourceServicesURI, err)
+	}
+	if text := resource.Contents[0].Text; text != "built-in" {
+		t.Errorf("%s came from %s, want built-in", resourceServicesURI, text)
+	}
+}
@@ -0,0 +1,301 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Native resource URIs. Service resources are backed by the same handlers as
+// the equivalent tools, so reading a resource returns what the tool would, and
+// is subject to that tool's mcp_allowed_tools/mcp_denied_tools policy.
+const (
+	resourceServicesURI       = "tiger://services"
+	resourceServiceTemplate   = "tiger://services/{service_id}"
+	resourceSchemaTemplate    = "tiger://services/{service_id}/schema"
+	resourceLogsTemplate      = "tiger://services/{service_id}/logs"
+	resourceServicePrefix     = resourceServicesURI + "/"
+	resourcePollInterval      = 30 * time.Second
+	resourceMIMETypeJSON      = "application/json"
+	resourceMIMETypePlainText = "text/plain"
+)
+
+// resourceSubscriptions tracks which resource URIs clients have subscribed to,
+// along with a digest of each one's last-read contents so the watcher can tell
+// when it changed.
+type resourceSubscriptions struct {
+	mu      sync.Mutex
+	counts  map[string]int
+	digests map[string][sha256.Size]byte
+}
+
+// registerResources registers the native tiger:// resources and templates
+func (s *Server) registerResources() {
+	s.addResource(&mcp.Resource{
+		URI:         resourceServicesURI,
+		Name:        "services",
+		Title:       "Database Services",
+		Description: "All database services in the current Tiger Cloud project, with status, type, region, and resource allocation (same as the service_list tool).",
+		MIMEType:    resourceMIMETypeJSON,
+	}, s.handleResourceRead)
+
+	s.addResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: resourceServiceTemplate,
+		Name:        "service",
+		Title:       "Database Service",
+		Description: "Details of a database service, including connection endpoints, replicas, and resource allocation (same as the service_get tool). The password is never included.",
+		MIMEType:    resourceMIMETypeJSON,
+	}, s.handleResourceRead)
+
+	s.addResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: resourceSchemaTemplate,
+		Name:        "service_schema",
+		Title:       "Database Schema",
+		Description: "The database schema of a service as readable text (same as the db_schema tool with default options). Attach it as context when writing queries or migrations.",
+		MIMEType:    resourceMIMETypePlainText,
+	}, s.handleResourceRead)
+
+	s.addResourceTemplate(&mcp.ResourceTemplate{
+		URITemplate: resourceLogsTemplate,
+		Name:        "service_recent_logs",
+		Title:       "Service Logs",
+		Description: "The last 100 log lines of a service's primary node, oldest first (same as the service_logs tool with default options).",
+		MIMEType:    resourceMIMETypePlainText,
+	}, s.handleResourceRead)
+}
+
+// addResource registers a native resource, skipping it if the tool backing it
+// is excluded by the server's tool policy.
+func (s *Server) addResource(r *mcp.Resource, h mcp.ResourceHandler) {
+	if err := s.checkResourceToolAllowed(r.URI); err != nil {
+		s.logger.Info("Skipping resource whose tool is excluded by mcp_allowed_tools/mcp_denied_tools", slog.String("resource", r.URI))
+		return
+	}
+	s.registered.claim(registeredResource, r.URI)
+	s.mcpServer.AddResource(r, h)
+}
+
+// addResourceTemplate registers a native resource template, skipping it if the
+// tool backing it is excluded by the server's tool policy.
+func (s *Server) addResourceTemplate(t *mcp.ResourceTemplate, h mcp.ResourceHandler) {
+	if err := s.checkResourceToolAllowed(t.URITemplate); err != nil {
+		s.logger.Info("Skipping resource template whose tool is excluded by mcp_allowed_tools/mcp_denied_tools", slog.String("resource_template", t.URITemplate))
+		return
+	}
+	s.registered.claim(registeredResourceTemplate, t.URITemplate)
+	s.mcpServer.AddResourceTemplate(t, h)
+}
+
+// resourceTool returns the name of the tool whose handler backs the native
+// resource (or resource template) at uri.
+func resourceTool(uri string) (string, bool) {
+	if uri == resourceServicesURI {
+		return toolServiceList, true
+	}
+	_, kind, ok := parseServiceResourceURI(uri)
+	if !ok {
+		return "", false
+	}
+	switch kind {
+	case "schema":
+		return toolDBSchema, true
+	case "logs":
+		return toolServiceLogs, true
+	default:
+		return toolServiceGet, true
+	}
+}
+
+// checkResourceToolAllowed returns an error if the tool backing the native
+// resource at uri is excluded by the server's tool policy, so that denying a
+// tool also withholds its data from resources and the prompts embedding them.
+func (s *Server) checkResourceToolAllowed(uri string) error {
+	if tool, ok := resourceTool(uri); ok && !s.tools.allows(tool) {
+		return errToolNotAllowed(tool)
+	}
+	return nil
+}
+
+// handleResourceRead handles resources/read for all native tiger:// resources
+func (s *Server) handleResourceRead(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	contents, err := s.readResource(ctx, req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{contents}}, nil
+}
+
+// readResource reads a native resource by URI by calling the handler of the
+// equivalent tool.
+func (s *Server) readResource(ctx context.Context, uri string) (*mcp.ResourceContents, error) {
+	if err := s.checkResourceToolAllowed(uri); err != nil {
+		return nil, err
+	}
+
+	if uri == resourceServicesURI {
+		_, out, err := s.handleServiceList(ctx, nil, ServiceListInput{})
+		if err != nil {
+			return nil, err
+		}
+		return jsonResourceContents(uri, out)
+	}
+
+	serviceID, kind, ok := parseServiceResourceURI(uri)
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	switch kind {
+	case "":
+		_, out, err := s.handleServiceGet(ctx, nil, ServiceGetInput{ServiceID: serviceID})
+		if err != nil {
+			return nil, err
+		}
+		return jsonResourceContents(uri, out.Service)
+	case "schema":
+		_, out, err := s.handleDBSchema(ctx, nil, DBSchemaInput{ServiceID: serviceID})
+		if err != nil {
+			return nil, err
+		}
+		text := out.SchemaText
+		if out.Warning != "" {
+			text = "-- " + out.Warning + "\n" + text
+		}
+		return &mcp.ResourceContents{URI: uri, MIMEType: resourceMIMETypePlainText, Text: text}, nil
+	case "logs":
+		_, out, err := s.handleServiceLogs(ctx, nil, ServiceLogsInput{ServiceID: serviceID})
+		if err != nil {
+			return nil, err
+		}
+		text := strings.Join(out.Logs, "\n")
+		if len(out.Logs) > 0 {
+			text += "\n"
+		}
+		return &mcp.ResourceContents{URI: uri, MIMEType: resourceMIMETypePlainText, Text: text}, nil
+	}
+	return nil, mcp.ResourceNotFoundError(uri)
+}
+
+// parseServiceResourceURI splits a tiger://services/{service_id}[/{kind}] URI
+// into the service ID and kind ("" for the service itself, "schema" or
+// "logs").
+func parseServiceResourceURI(uri string) (serviceID, kind string, ok bool) {
+	rest, found := strings.CutPrefix(uri, resourceServicePrefix)
+	if !found {
+		return "", "", false
+	}
+	serviceID, kind, _ = strings.Cut(rest, "/")
+	if serviceID == "" || (kind != "" && kind != "schema" && kind != "logs") {
+		return "", "", false
+	}
+	return serviceID, kind, true
+}
+
+func jsonResourceContents(uri string, v any) (*mcp.ResourceContents, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource: %w", err)
+	}
+	return &mcp.ResourceContents{URI: uri, MIMEType: resourceMIMETypeJSON, Text: string(data)}, nil
+}
+
+// handleResourceSubscribe handles resources/subscribe. The SDK tracks which
+// sessions to notify; this records the URI so the watcher polls it.
+func (s *Server) handleResourceSubscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	if _, _, ok := parseServiceResourceURI(uri); !ok && uri != resourceServicesURI {
+		return mcp.ResourceNotFoundError(uri)
+	}
+	if err := s.checkResourceToolAllowed(uri); err != nil {
+		return err
+	}
+
+	s.logger.Info("MCP: Subscribing to resource", slog.String("uri", uri))
+
+	s.subscriptions.mu.Lock()
+	defer s.subscriptions.mu.Unlock()
+	if s.subscriptions.counts == nil {
+		s.subscriptions.counts = make(map[string]int)
+	}
+	s.subscriptions.counts[uri]++
+	return nil
+}
+
+// handleResourceUnsubscribe handles resources/unsubscribe
+func (s *Server) handleResourceUnsubscribe(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+	uri := req.Params.URI
+
+	s.logger.Info("MCP: Unsubscribing from resource", slog.String("uri", uri))
+
+	s.subscriptions.mu.Lock()
+	defer s.subscriptions.mu.Unlock()
+	if s.subscriptions.counts[uri] > 1 {
+		s.subscriptions.counts[uri]--
+	} else {
+		delete(s.subscriptions.counts, uri)
+		delete(s.subscriptions.digests, uri)
+	}
+	return nil
+}
+
+// watchResources polls subscribed resources every interval until ctx is
+// canceled, notifying subscribers of the ones that changed.
+func (s *Server) watchResources(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.pollSubscribedResources(ctx)
+		}
+	}
+}
+
+// pollSubscribedResources reads every subscribed resource and sends a
+// resources/updated notification for each one whose contents differ from the
+// previous poll. The first poll after subscribing only records a baseline.
+// Resources that fail to read are skipped until the next poll.
+func (s *Server) pollSubscribedResources(ctx context.Context) {
+	s.subscriptions.mu.Lock()
+	uris := make([]string, 0, len(s.subscriptions.counts))
+	for uri := range s.subscriptions.counts {
+		uris = append(uris, uri)
+	}
+	s.subscriptions.mu.Unlock()
+
+	for _, uri := range uris {
+		contents, err := s.readResource(ctx, uri)
+		if err != nil {
+			s.logger.Warn("MCP: Failed to poll subscribed resource", slog.String("uri", uri), slog.Any("error", err))
+			continue
+		}
+		digest := sha256.Sum256([]byte(contents.Text))
+
+		s.subscriptions.mu.Lock()
+		if _, subscribed := s.subscriptions.counts[uri]; !subscribed {
+			s.subscriptions.mu.Unlock()
+			continue
+		}
+		previous, seen := s.subscriptions.digests[uri]
+		if s.subscriptions.digests == nil {
+			s.subscriptions.digests = make(map[string][sha256.Size]byte)
+		}
+		s.subscriptions.digests[uri] = digest
+		s.subscriptions.mu.Unlock()
+
+		if seen && previous != digest {
+			if err := s.mcpServer.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+				s.logger.Warn("MCP: Failed to send resource update", slog.String("uri", uri), slog.Any("error", err))
+			}
+		}
+	}
+}
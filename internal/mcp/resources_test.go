@@ -0,0 +1,210 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// newResourceTestServer returns a Server with its native resources
+// registered, backed by a fake API whose single service reports the status
+// in status, and a client session connected to it.
+func newResourceTestServer(t *testing.T, status *atomic.Value, updated chan<- string) (*Server, *mcp.ClientSession) {
+	t.Helper()
+
+	service := func() api.Service {
+		return api.Service{
+			ServiceID:   "svc1234567",
+			Name:        "demo",
+			Status:      api.DeployStatus(status.Load().(string)),
+			ServiceType: api.ServiceTypeTIMESCALEDB,
+			RegionCode:  "us-east-1",
+			Created:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/projects/proj/services":
+			_ = json.NewEncoder(w).Encode([]api.Service{service()})
+		case "/projects/proj/services/svc1234567":
+			_ = json.NewEncoder(w).Encode(service())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(api.Error{Message: util.Ptr("not found")})
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := api.NewClientWithResponses(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return client, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, &mcp.ServerOptions{
+		SubscribeHandler:   s.handleResourceSubscribe,
+		UnsubscribeHandler: s.handleResourceUnsubscribe,
+	})
+	s.registerResources()
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	clientSession, err := mcpClient.Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = clientSession.Close() })
+
+	return s, clientSession
+}
+
+func TestResources_Read(t *testing.T) {
+	var status atomic.Value
+	status.Store("READY")
+	_, session := newResourceTestServer(t, &status, make(chan string, 1))
+	ctx := t.Context()
+
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("list resource templates: %v", err)
+	}
+	var uriTemplates []string
+	for _, tmpl := range templates.ResourceTemplates {
+		uriTemplates = append(uriTemplates, tmpl.URITemplate)
+	}
+	for _, want := range []string{resourceServiceTemplate, resourceSchemaTemplate, resourceLogsTemplate} {
+		if !strings.Contains(strings.Join(uriTemplates, " "), want) {
+			t.Errorf("resource template %q not registered, got %v", want, uriTemplates)
+		}
+	}
+
+	res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: resourceServicesURI})
+	if err != nil {
+		t.Fatalf("read %s: %v", resourceServicesURI, err)
+	}
+	var list ServiceListOutput
+	if err := json.Unmarshal([]byte(res.Contents[0].Text), &list); err != nil {
+		t.Fatalf("decode service list: %v", err)
+	}
+	if len(list.Services) != 1 || list.Services[0].ServiceID != "svc1234567" || list.Services[0].Status != "READY" {
+		t.Errorf("unexpected service list: %+v", list.Services)
+	}
+
+	res, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "tiger://services/svc1234567"})
+	if err != nil {
+		t.Fatalf("read service: %v", err)
+	}
+	if got := res.Contents[0]; got.MIMEType != resourceMIMETypeJSON || !strings.Contains(got.Text, `"name": "demo"`) {
+		t.Errorf("unexpected service contents: %+v", got)
+	}
+
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "tiger://services/svc1234567/unknown"}); err == nil {
+		t.Error("expected an error reading an unknown resource")
+	}
+}
+
+func TestResources_Subscribe(t *testing.T) {
+	var status atomic.Value
+	status.Store("READY")
+	updated := make(chan string, 4)
+	s, session := newResourceTestServer(t, &status, updated)
+	ctx := t.Context()
+
+	uri := "tiger://services/svc1234567"
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "https://example.com"}); err == nil {
+		t.Error("expected subscribing to a non-tiger URI to fail")
+	}
+
+	// The first poll records a baseline; an unchanged resource doesn't notify.
+	s.pollSubscribedResources(ctx)
+	s.pollSubscribedResources(ctx)
+	select {
+	case got := <-updated:
+		t.Fatalf("unexpected update for unchanged resource: %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	status.Store("PAUSED")
+	s.pollSubscribedResources(ctx)
+	select {
+	case got := <-updated:
+		if got != uri {
+			t.Errorf("update URI = %q, want %q", got, uri)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected an update after the service status changed")
+	}
+
+	// After unsubscribing, changes are no longer polled.
+	if err := session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("unsubscribe: %v", err)
+	}
+	status.Store("READY")
+	s.pollSubscribedResources(ctx)
+	select {
+	case got := <-updated:
+		t.Fatalf("unexpected update after unsubscribing: %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestParseServiceResourceURI(t *testing.T) {
+	for _, tt := range []struct {
+		uri       string
+		serviceID string
+		kind      string
+		ok        bool
+	}{
+		{"tiger://services/svc1234567", "svc1234567", "", true},
+		{"tiger://services/svc1234567/schema", "svc1234567", "schema", true},
+		{"tiger://services/svc1234567/logs", "svc1234567", "logs", true},
+		{"tiger://services", "", "", false},
+		{"tiger://services/", "", "", false},
+		{"tiger://services/svc1234567/logs/extra", "", "", false},
+		{"tiger://services/svc1234567/unknown", "", "", false},
+		{"https://example.com/services/svc1234567", "", "", false},
+	} {
+		serviceID, kind, ok := parseServiceResourceURI(tt.uri)
+		if serviceID != tt.serviceID || kind != tt.kind || ok != tt.ok {
+			t.Errorf("parseServiceResourceURI(%q) = (%q, %q, %v), want (%q, %q, %v)", tt.uri, serviceID, kind, ok, tt.serviceID, tt.kind, tt.ok)
+		}
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -32,18 +34,29 @@ const (
 	toolServiceStart            = "service_start"
 	toolServiceStop             = "service_stop"
 	toolServiceResize           = "service_resize"
+	toolServiceDelete           = "service_delete"
 	toolServiceUpdatePassword   = "service_update_password"
 	toolServiceLogs             = "service_logs"
 	toolServiceMetricsAvailable = "service_metrics_available"
 	toolServiceMetricsSeries    = "service_metrics_series"
+	toolServiceRecommendSize    = "service_recommend_size"
 	toolDBExecuteQuery          = "db_execute_query"
+	toolDBSchema                = "db_schema"
+	toolDBCreateRole            = "db_create_role"
+	toolDBListRoles             = "db_list_roles"
+	toolDBDropRole              = "db_drop_role"
 )
 
 // Server wraps the MCP server with Tiger-specific functionality
 type Server struct {
 	mcpServer       *mcp.Server
 	docsProxyClient *ProxyClient
-	logger          *slog.Logger
+	// upstreamProxyClients are the mcp_proxies connections, keyed by tool prefix
+	upstreamProxyClients map[string]*ProxyClient
+	logger               *slog.Logger
+	subscriptions        resourceSubscriptions
+	tools                toolPolicy
+	registered           registeredNames
 
 	// app holds the config and API client. The analytics middleware reloads it
 	// once per request, so config changes and logins made while the session is
@@ -52,15 +65,50 @@ type Server struct {
 	app *common.App
 }
 
-// addTool registers an MCP tool, skipping readOnlyGatedTools in read-only mode.
+// addTool registers an MCP tool, skipping readOnlyGatedTools in read-only mode
+// and tools excluded by the server's tool policy.
 func addTool[In, Out any](s *Server, readOnly bool, t *mcp.Tool, h mcp.ToolHandlerFor[In, Out]) {
 	if readOnly && slices.Contains(readOnlyGatedTools, t.Name) {
 		s.logger.Info("Skipping write tool in read-only mode", slog.String("tool", t.Name))
 		return
 	}
+	if !s.tools.allows(t.Name) {
+		s.logger.Info("Skipping tool excluded by mcp_allowed_tools/mcp_denied_tools", slog.String("tool", t.Name))
+		return
+	}
+	s.registered.claim(registeredTool, t.Name)
 	mcp.AddTool(s.mcpServer, t, h)
 }
 
+// Kinds of entries tracked by registeredNames.
+const (
+	registeredTool             = "tool"
+	registeredPrompt           = "prompt"
+	registeredResource         = "resource"
+	registeredResourceTemplate = "resource template"
+)
+
+// registeredNames records the names of the tools and prompts, and the URIs of
+// the resources and resource templates, registered on a server. The SDK
+// silently replaces an entry added under an existing name, so proxied entries
+// are checked against it to keep them from replacing built-in ones.
+type registeredNames struct {
+	names map[string]bool
+}
+
+// claim records name as registered for kind, returning false if it already is.
+func (r *registeredNames) claim(kind, name string) bool {
+	if r.names == nil {
+		r.names = make(map[string]bool)
+	}
+	key := kind + " " + name
+	if r.names[key] {
+		return false
+	}
+	r.names[key] = true
+	return true
+}
+
 // buildServerInstructions returns the `instructions` string the MCP SDK sends
 // to clients at initialize. Evaluated once at server start, like tool registration.
 func buildServerInstructions(cfg *config.Config) string {
@@ -82,34 +130,73 @@ func buildServerInstructions(cfg *config.Config) string {
 // and gates which tools are registered, both evaluated once here at startup.
 // A nil logger discards the server's log output.
 func NewServer(ctx context.Context, app *common.App, logger *slog.Logger) (*Server, error) {
+	server := newServer(ctx, app, ensureLogger(logger), serverOptions{})
+	go server.watchResources(ctx, resourcePollInterval)
+	return server, nil
+}
+
+// serverOptions customizes servers built by newServer for HTTP sessions (see
+// http_sessions.go). The zero value builds a standalone server.
+type serverOptions struct {
+	// docsProxy is an existing docs proxy connection to reuse
+	docsProxy *ProxyClient
+	// upstreamProxies are existing mcp_proxies connections to reuse, keyed by
+	// tool prefix
+	upstreamProxies map[string]*ProxyClient
+	// initialized is called when a client session finishes initializing
+	initialized func(context.Context, *mcp.InitializedRequest)
+	// sessionID provides the ID of the next HTTP session
+	sessionID func() string
+}
+
+// newServer builds a server and registers its tools, resources, and prompts.
+func newServer(ctx context.Context, app *common.App, logger *slog.Logger, opts serverOptions) *Server {
 	cfg := app.GetConfig()
-	logger = ensureLogger(logger)
 
-	mcpServer := mcp.NewServer(&mcp.Implementation{
+	server := &Server{
+		docsProxyClient:      opts.docsProxy,
+		upstreamProxyClients: maps.Clone(opts.upstreamProxies),
+		logger:               logger,
+		tools: toolPolicy{
+			allowed: cfg.MCPAllowedTools,
+			denied:  cfg.MCPDeniedTools,
+		},
+		app: app,
+	}
+	server.mcpServer = mcp.NewServer(&mcp.Implementation{
 		Name:    ServerName,
 		Title:   serverTitle,
 		Version: config.Version,
 	}, &mcp.ServerOptions{
-		Instructions: buildServerInstructions(cfg),
-		Logger:       logger,
+		Instructions:       buildServerInstructions(cfg),
+		Logger:             logger,
+		InitializedHandler: opts.initialized,
+		GetSessionID:       opts.sessionID,
+		SubscribeHandler:   server.handleResourceSubscribe,
+		UnsubscribeHandler: server.handleResourceUnsubscribe,
 	})
 
-	server := &Server{
-		mcpServer: mcpServer,
-		logger:    logger,
-		app:       app,
-	}
-
-	// Register all tools (including proxied docs tools). readOnly and
+	// Register all built-in tools. readOnly and
 	// experimental are captured here and threaded through registration only.
 	// experimental follows the ghost pattern — env-var only, undocumented; see
 	// CLAUDE.md's "Experimental Feature Gating".
 	server.registerTools(ctx, cfg.ReadOnly, app.Experimental)
 
-	// Add analytics tracking middleware
-	server.mcpServer.AddReceivingMiddleware(server.analyticsMiddleware)
+	// Native tiger:// resources, polled for changes while subscribed, and the
+	// workflow prompts that embed them
+	server.registerResources()
+	server.registerPrompts(cfg.ReadOnly)
 
-	return server, nil
+	// Docs and mcp_proxies upstreams, registered last so they can't replace
+	// built-in tools, resources, or prompts
+	server.registerProxies(ctx)
+
+	// Add analytics tracking, audit logging, and service allowlist middleware.
+	// The analytics middleware runs first, since it reloads the config the
+	// others use, and the audit log records calls the allowlist rejects.
+	server.mcpServer.AddReceivingMiddleware(server.analyticsMiddleware, server.auditMiddleware, server.servicePolicyMiddleware)
+
+	return server
 }
 
 func ensureLogger(logger *slog.Logger) *slog.Logger {
@@ -124,13 +211,21 @@ func (s *Server) StartStdio(ctx context.Context) error {
 	return s.mcpServer.Run(ctx, &mcp.StdioTransport{})
 }
 
-// Returns an HTTP handler that implements the http transport
-func (s *Server) HTTPHandler() http.Handler {
-	return mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server {
+// Returns an HTTP handler that implements the http transport, with the
+// access controls in opts applied to every request. Sessions are stateless
+// unless opts.Stateful or opts.SessionAPIKeys is set.
+func (s *Server) HTTPHandler(opts HTTPOptions) http.Handler {
+	if opts.SessionAPIKeys {
+		return s.withHTTPAuth(s.sessionAPIKeyHandler(opts.SessionTimeout), opts)
+	}
+
+	handler := mcp.NewStreamableHTTPHandler(func(req *http.Request) *mcp.Server {
 		return s.mcpServer
 	}, &mcp.StreamableHTTPOptions{
-		Stateless: true,
+		Stateless:      !opts.Stateful,
+		SessionTimeout: opts.SessionTimeout,
 	})
+	return s.withHTTPAuth(handler, opts)
 }
 
 // registerTools registers all available MCP tools
@@ -142,9 +237,17 @@ func (s *Server) registerTools(ctx context.Context, readOnly, experimental bool)
 	s.registerDatabaseTools(readOnly)
 
 	// TODO: Register more tool groups
+}
 
+// registerProxies registers the tools, resources, and prompts of the docs MCP
+// server and the mcp_proxies upstreams. It runs after every built-in entry is
+// registered, so proxied entries that would replace one are skipped.
+func (s *Server) registerProxies(ctx context.Context) {
 	// Register remote docs MCP server proxy
 	s.registerDocsProxy(ctx)
+
+	// Register extra upstream MCP servers from mcp_proxies
+	s.registerUpstreamProxies(ctx)
 }
 
 // registerServiceTools registers service management tools with comprehensive schemas and descriptions
@@ -157,23 +260,26 @@ func (s *Server) registerServiceTools(readOnly, experimental bool) {
 	addTool(s, readOnly, newServiceStartTool(), s.handleServiceStart)
 	addTool(s, readOnly, newServiceStopTool(), s.handleServiceStop)
 	addTool(s, readOnly, newServiceResizeTool(), s.handleServiceResize)
+	addTool(s, readOnly, newServiceDeleteTool(), s.handleServiceDelete)
 	addTool(s, readOnly, newServiceLogsTool(), s.handleServiceLogs)
+	addTool(s, readOnly, newServiceMetricsAvailableTool(), s.handleServiceMetricsAvailable)
+	addTool(s, readOnly, newServiceMetricsSeriesTool(), s.handleServiceMetricsSeries)
+	addTool(s, readOnly, newServiceRecommendSizeTool(), s.handleServiceRecommendSize)
 
-	// Metrics tools target gateway endpoints marked `x-tigerdata-preview: true`. They
-	// are registered only when the experimental gate is on at server startup;
-	// the user must restart the MCP server after toggling the gate. Handler
-	// bodies re-check the gate defensively in case config changes mid-session.
-	if experimental {
-		addTool(s, readOnly, newServiceMetricsAvailableTool(), s.handleServiceMetricsAvailable)
-		addTool(s, readOnly, newServiceMetricsSeriesTool(), s.handleServiceMetricsSeries)
-	}
+	// Preview-stage tools are registered only when the experimental gate is on
+	// at server startup; the user must restart the MCP server after toggling
+	// it. None are currently in preview.
 }
 
 // registerDatabaseTools registers database operation tools with comprehensive schemas and descriptions
 func (s *Server) registerDatabaseTools(readOnly bool) {
 	addTool(s, readOnly, newDBExecuteQueryTool(), s.handleDBExecuteQuery)
 
-	mcp.AddTool(s.mcpServer, newDBSchemaTool(), s.handleDBSchema)
+	addTool(s, readOnly, newDBSchemaTool(), s.handleDBSchema)
+
+	addTool(s, readOnly, newDBCreateRoleTool(), s.handleDBCreateRole)
+	addTool(s, readOnly, newDBListRolesTool(), s.handleDBListRoles)
+	addTool(s, readOnly, newDBDropRoleTool(), s.handleDBDropRole)
 }
 
 // analyticsMiddleware tracks analytics for all MCP requests
@@ -203,22 +309,19 @@ func (s *Server) analyticsMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
 			}
 
 			defer func() {
-				toolErr := runErr
-				if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult != nil && callToolResult.IsError && len(callToolResult.Content) > 0 {
-					if textContent, ok := callToolResult.Content[0].(*mcp.TextContent); ok && textContent != nil {
-						toolErr = errors.New(textContent.Text)
-					}
-				}
-
 				a.Track(fmt.Sprintf("Call %s tool", r.Params.Name),
 					analytics.Map(args),
 					analytics.Property("elapsed_seconds", time.Since(start).Seconds()),
-					analytics.Error(toolErr),
+					analytics.Error(toolCallError(result, runErr)),
 				)
 			}()
 		case *mcp.ReadResourceRequest:
+			event := "Read proxied resource"
+			if strings.HasPrefix(r.Params.URI, resourceServicesURI) {
+				event = "Read resource"
+			}
 			defer func() {
-				a.Track("Read proxied resource",
+				a.Track(event,
 					analytics.Property("resource_uri", r.Params.URI),
 					analytics.Property("elapsed_seconds", time.Since(start).Seconds()),
 					analytics.Error(runErr),
@@ -238,6 +341,17 @@ func (s *Server) analyticsMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
 	}
 }
 
+// toolCallError returns the error a tool call failed with: either err, or the
+// text of a result flagged IsError.
+func toolCallError(result mcp.Result, err error) error {
+	if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult != nil && callToolResult.IsError && len(callToolResult.Content) > 0 {
+		if textContent, ok := callToolResult.Content[0].(*mcp.TextContent); ok && textContent != nil {
+			return errors.New(textContent.Text)
+		}
+	}
+	return err
+}
+
 // Close gracefully shuts down the MCP server and all proxy connections
 func (s *Server) Close() error {
 	// Close docs proxy connection
@@ -245,5 +359,12 @@ func (s *Server) Close() error {
 		return fmt.Errorf("failed to close docs proxy client: %w", err)
 	}
 
+	// Close mcp_proxies connections
+	for prefix, proxyClient := range s.upstreamProxyClients {
+		if err := proxyClient.Close(); err != nil {
+			return fmt.Errorf("failed to close %s proxy client: %w", prefix, err)
+		}
+	}
+
 	return nil
 }
@@ -199,6 +199,7 @@ func (s *Server) handleServiceCreate(ctx context.Context, req *mcp.CallToolReque
 			},
 			Timeout:    waitTimeout,
 			TimeoutMsg: "service may still be provisioning",
+			Progress:   s.waitProgress(ctx, req),
 		}); err != nil {
 			message = fmt.Sprintf("Error: %s", err.Error())
 		} else {
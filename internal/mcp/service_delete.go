@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// deleteTokenTTL is how long a service_delete confirmation token stays valid.
+const deleteTokenTTL = 5 * time.Minute
+
+// deleteTokenKey signs confirmation tokens. It's generated per process, so
+// restarting the MCP server invalidates any outstanding tokens.
+var deleteTokenKey = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate service_delete token key: %v", err))
+	}
+	return key
+}()
+
+// ServiceDeleteInput represents input for service_delete
+type ServiceDeleteInput struct {
+	ServiceID         string `json:"service_id"`
+	ConfirmationToken string `json:"confirmation_token,omitempty"`
+	Wait              bool   `json:"wait,omitempty"`
+}
+
+func (ServiceDeleteInput) Schema() *jsonschema.Schema {
+	schema := util.Must(jsonschema.For[ServiceDeleteInput](nil))
+
+	setServiceIDSchemaProperties(schema)
+
+	schema.Properties["confirmation_token"].Description = "Token returned by a previous service_delete call for this service. Omit it on the first call to get a token. Only pass it after the user has explicitly approved deleting the service by name. Tokens expire after 5 minutes."
+
+	schema.Properties["wait"].Description = "Whether to wait for the service to be fully deleted before returning. Default is false (recommended). When true, waits up to 10 minutes."
+	schema.Properties["wait"].Default = util.Must(json.Marshal(false))
+	schema.Properties["wait"].Examples = []any{false, true}
+
+	return schema
+}
+
+// ServiceDeleteOutput represents output for service_delete
+type ServiceDeleteOutput struct {
+	Status            string `json:"status" jsonschema:"Either confirmation_required or deleted"`
+	ServiceID         string `json:"service_id"`
+	ServiceName       string `json:"service_name"`
+	ConfirmationToken string `json:"confirmation_token,omitempty" jsonschema:"Token to pass to the second service_delete call once the user has confirmed"`
+	ExpiresAt         string `json:"expires_at,omitempty" jsonschema:"When the confirmation token expires (RFC3339)"`
+	Message           string `json:"message"`
+}
+
+func (ServiceDeleteOutput) Schema() *jsonschema.Schema {
+	return util.Must(jsonschema.For[ServiceDeleteOutput](nil))
+}
+
+func newServiceDeleteTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:  toolServiceDelete,
+		Title: "Delete Database Service",
+		Description: `Permanently delete a database service and all of its data.
+
+Deletion takes two calls. The first call (without confirmation_token) deletes nothing: it returns the service's name and a confirmation token. Show the user the service ID and name and ask them to confirm. Only after they approve, call this tool again with the same service_id and the confirmation_token. The token is bound to the service's ID and name and expires after 5 minutes.
+
+WARNING: Deleted services cannot be recovered.`,
+		InputSchema:  ServiceDeleteInput{}.Schema(),
+		OutputSchema: ServiceDeleteOutput{}.Schema(),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:    false,
+			DestructiveHint: util.Ptr(true), // Deletes the service and all of its data
+			IdempotentHint:  false,          // Deleting an already-deleted service fails
+			OpenWorldHint:   util.Ptr(true),
+			Title:           "Delete Database Service",
+		},
+	}
+}
+
+// handleServiceDelete handles the service_delete MCP tool
+func (s *Server) handleServiceDelete(ctx context.Context, req *mcp.CallToolRequest, input ServiceDeleteInput) (*mcp.CallToolResult, ServiceDeleteOutput, error) {
+	cfg, client, projectID, err := s.app.GetAll()
+	if err != nil {
+		return nil, ServiceDeleteOutput{}, err
+	}
+
+	if err := common.CheckReadOnly(cfg); err != nil {
+		return nil, ServiceDeleteOutput{}, err
+	}
+
+	// Look up the service so the token is bound to its current name as well
+	// as its ID
+	getCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	getResp, err := client.GetServiceWithResponse(getCtx, projectID, input.ServiceID)
+	if err != nil {
+		return nil, ServiceDeleteOutput{}, fmt.Errorf("failed to get service details: %w", err)
+	}
+	if getResp.StatusCode() != http.StatusOK {
+		return nil, ServiceDeleteOutput{}, common.ExitWithErrorFromStatusCode(getResp.StatusCode(), getResp.JSON4XX)
+	}
+	if getResp.JSON200 == nil {
+		return nil, ServiceDeleteOutput{}, fmt.Errorf("empty response from API")
+	}
+	name := getResp.JSON200.Name
+
+	if input.ConfirmationToken == "" {
+		expires := time.Now().Add(deleteTokenTTL)
+		s.logger.Info("MCP: Issuing service delete confirmation token",
+			slog.String("project_id", projectID),
+			slog.String("service_id", input.ServiceID))
+		return nil, ServiceDeleteOutput{
+			Status:            "confirmation_required",
+			ServiceID:         input.ServiceID,
+			ServiceName:       name,
+			ConfirmationToken: newDeleteToken(input.ServiceID, name, expires),
+			ExpiresAt:         expires.UTC().Format(time.RFC3339),
+			Message:           fmt.Sprintf("Nothing has been deleted yet. Ask the user to confirm permanently deleting service %s (%s) and all of its data. If they approve, call %s again with this confirmation_token.", name, input.ServiceID, toolServiceDelete),
+		}, nil
+	}
+
+	if err := verifyDeleteToken(input.ConfirmationToken, input.ServiceID, name, time.Now()); err != nil {
+		return nil, ServiceDeleteOutput{}, err
+	}
+
+	s.logger.Info("MCP: Deleting service",
+		slog.String("project_id", projectID),
+		slog.String("service_id", input.ServiceID))
+
+	deleteCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := client.DeleteServiceWithResponse(deleteCtx, api.ProjectID(projectID), api.ServiceID(input.ServiceID))
+	if err != nil {
+		return nil, ServiceDeleteOutput{}, fmt.Errorf("failed to delete service: %w", err)
+	}
+
+	// Handle API response
+	if resp.StatusCode() != http.StatusAccepted {
+		return nil, ServiceDeleteOutput{}, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+
+	status := "deleting"
+	message := "Service deletion request accepted. The service may still be deleting."
+	if input.Wait {
+		if err := common.WaitForService(ctx, common.WaitForServiceArgs{
+			Client:    client,
+			ProjectID: projectID,
+			ServiceID: input.ServiceID,
+			Handler: &common.DeletionWaitHandler{
+				ServiceID: input.ServiceID,
+			},
+			Timeout:    waitTimeout,
+			TimeoutMsg: "service may still be deleting",
+			Progress:   s.waitProgress(ctx, req),
+		}); err != nil {
+			message = fmt.Sprintf("Error: %s", err.Error())
+		} else {
+			status = "deleted"
+			message = "Service deleted successfully!"
+		}
+	}
+
+	return nil, ServiceDeleteOutput{
+		Status:      status,
+		ServiceID:   input.ServiceID,
+		ServiceName: name,
+		Message:     message,
+	}, nil
+}
+
+// newDeleteToken returns a token of the form "<expiry>.<signature>", where
+// the signature covers the service ID, service name, and expiry.
+func newDeleteToken(serviceID, name string, expires time.Time) string {
+	expiry := strconv.FormatInt(expires.Unix(), 10)
+	return expiry + "." + deleteTokenSignature(serviceID, name, expiry)
+}
+
+// verifyDeleteToken checks that token was issued by newDeleteToken for this
+// service ID and name and hasn't expired.
+func verifyDeleteToken(token, serviceID, name string, now time.Time) error {
+	expiry, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return errors.New("invalid confirmation_token: call service_delete without a token to get a new one")
+	}
+	want := deleteTokenSignature(serviceID, name, expiry)
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		return fmt.Errorf("confirmation_token does not match service %s (%s): call service_delete without a token to get a new one", name, serviceID)
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || now.After(time.Unix(unix, 0)) {
+		return errors.New("confirmation_token has expired: call service_delete without a token to get a new one, and confirm with the user again")
+	}
+	return nil
+}
+
+func deleteTokenSignature(serviceID, name, expiry string) string {
+	mac := hmac.New(sha256.New, deleteTokenKey)
+	// Length-prefix each field so values can't run into each other
+	for _, field := range []string{serviceID, name, expiry} {
+		fmt.Fprintf(mac, "%d:%s", len(field), field)
+	}
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
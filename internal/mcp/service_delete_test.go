@@ -0,0 +1,171 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+func TestServiceDelete(t *testing.T) {
+	var (
+		name    atomic.Value
+		deletes atomic.Int32
+	)
+	name.Store("demo")
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/proj/services/svc1234567":
+			_ = json.NewEncoder(w).Encode(api.Service{
+				ServiceID:   "svc1234567",
+				Name:        name.Load().(string),
+				Status:      api.DeployStatus("READY"),
+				ServiceType: api.ServiceTypeTIMESCALEDB,
+				Created:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/projects/proj/services/svc1234567":
+			deletes.Add(1)
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+
+	client, err := api.NewClientWithResponses(apiServer.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("config-dir", t.TempDir(), "config directory")
+	app := &common.App{}
+	app.SetFlags(flags)
+	app.SetClientFactory(func(context.Context, *config.Config) (api.ClientWithResponsesInterface, string, error) {
+		return client, "proj", nil
+	})
+	if _, _, _, err := app.Load(t.Context()); err != nil {
+		t.Fatalf("failed to load app: %v", err)
+	}
+
+	s := &Server{logger: ensureLogger(nil), app: app}
+	s.mcpServer = mcp.NewServer(&mcp.Implementation{Name: ServerName, Version: config.Version}, nil)
+	s.registerServiceTools(false, false)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := s.mcpServer.Connect(t.Context(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("server connect: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(t.Context(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+
+	call := func(args map[string]any) (*mcp.CallToolResult, ServiceDeleteOutput) {
+		t.Helper()
+		res, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: toolServiceDelete, Arguments: args})
+		if err != nil {
+			t.Fatalf("call %s: %v", toolServiceDelete, err)
+		}
+		var out ServiceDeleteOutput
+		if !res.IsError {
+			raw, _ := json.Marshal(res.StructuredContent)
+			if err := json.Unmarshal(raw, &out); err != nil {
+				t.Fatalf("decode output: %v", err)
+			}
+		}
+		return res, out
+	}
+	errorText := func(res *mcp.CallToolResult) string {
+		return res.Content[0].(*mcp.TextContent).Text
+	}
+
+	// First call only issues a token
+	res, out := call(map[string]any{"service_id": "svc1234567"})
+	if res.IsError {
+		t.Fatalf("first call failed: %s", errorText(res))
+	}
+	if out.Status != "confirmation_required" || out.ServiceName != "demo" || out.ConfirmationToken == "" {
+		t.Fatalf("first call output = %+v", out)
+	}
+	if deletes.Load() != 0 {
+		t.Fatal("first call must not delete the service")
+	}
+	token := out.ConfirmationToken
+
+	// A forged token is rejected
+	res, _ = call(map[string]any{"service_id": "svc1234567", "confirmation_token": "9999999999.forged"})
+	if !res.IsError || !strings.Contains(errorText(res), "does not match") {
+		t.Fatalf("forged token: IsError = %v, content = %+v", res.IsError, res.Content)
+	}
+
+	// A token stops matching once the service is renamed
+	name.Store("renamed")
+	res, _ = call(map[string]any{"service_id": "svc1234567", "confirmation_token": token})
+	if !res.IsError || !strings.Contains(errorText(res), "does not match") {
+		t.Fatalf("renamed service: IsError = %v, content = %+v", res.IsError, res.Content)
+	}
+	name.Store("demo")
+	if deletes.Load() != 0 {
+		t.Fatal("invalid tokens must not delete the service")
+	}
+
+	// The valid token deletes the service
+	res, out = call(map[string]any{"service_id": "svc1234567", "confirmation_token": token})
+	if res.IsError {
+		t.Fatalf("second call failed: %s", errorText(res))
+	}
+	if out.Status != "deleting" || deletes.Load() != 1 {
+		t.Errorf("second call output = %+v, deletes = %d", out, deletes.Load())
+	}
+}
+
+func TestVerifyDeleteToken(t *testing.T) {
+	now := time.Now()
+	token := newDeleteToken("svc1234567", "demo", now.Add(deleteTokenTTL))
+
+	tests := []struct {
+		name      string
+		token     string
+		serviceID string
+		svcName   string
+		now       time.Time
+		wantError string
+	}{
+		{"valid", token, "svc1234567", "demo", now, ""},
+		{"other service", token, "svc7654321", "demo", now, "does not match"},
+		{"other name", token, "svc1234567", "prod", now, "does not match"},
+		{"expired", token, "svc1234567", "demo", now.Add(deleteTokenTTL + time.Second), "expired"},
+		{"malformed", "garbage", "svc1234567", "demo", now, "invalid"},
+		{"tampered expiry", "9999999999" + token[strings.Index(token, "."):], "svc1234567", "demo", now, "does not match"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyDeleteToken(tt.token, tt.serviceID, tt.svcName, tt.now)
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("verifyDeleteToken() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("verifyDeleteToken() error = %v, want it to contain %q", err, tt.wantError)
+			}
+		})
+	}
+}
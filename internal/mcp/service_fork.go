@@ -211,6 +211,7 @@ func (s *Server) handleServiceFork(ctx context.Context, req *mcp.CallToolRequest
 			},
 			Timeout:    waitTimeout,
 			TimeoutMsg: "service may still be provisioning",
+			Progress:   s.waitProgress(ctx, req),
 		}); err != nil {
 			message = fmt.Sprintf("Error: %s", err.Error())
 		} else {
@@ -91,13 +91,14 @@ func (s *Server) handleServiceList(ctx context.Context, req *mcp.CallToolRequest
 		return nil, ServiceListOutput{Services: []ServiceInfo{}}, nil
 	}
 
-	services := *resp.JSON200
+	// Services outside the mcp_allowed_services allowlist aren't listed
 	output := ServiceListOutput{
-		Services: make([]ServiceInfo, len(services)),
+		Services: []ServiceInfo{},
 	}
-
-	for i, service := range services {
-		output.Services[i] = s.convertToServiceInfo(service)
+	for _, service := range *resp.JSON200 {
+		if s.serviceAllowed(service.ServiceID) {
+			output.Services = append(output.Services, s.convertToServiceInfo(service))
+		}
 	}
 
 	return nil, output, nil
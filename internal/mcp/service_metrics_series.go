@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"strings"
 	"time"
 
@@ -148,27 +147,16 @@ func (s *Server) handleServiceMetricsSeries(ctx context.Context, req *mcp.CallTo
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	resp, err := client.GetServiceMetricsSeriesWithResponse(ctx, projectID, input.ServiceID, body)
+	series, err := common.FetchMetricSeries(ctx, client, projectID, input.ServiceID, body)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch metric series: %w", err)
-	}
-
-	if resp.StatusCode() != http.StatusOK {
-		return nil, nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
-	}
-
-	// Default to a non-nil slice so an empty result marshals to `[]` rather
-	// than `null`, which would fail the required-array output-schema validation.
-	series := []api.MetricSeries{}
-	if resp.JSON200 != nil && *resp.JSON200 != nil {
-		series = *resp.JSON200
+		return nil, nil, err
 	}
 
 	return nil, ServiceMetricsSeriesOutput{Series: series}, nil
 }
 
 // buildMetricFilters merges the convenience Role input with the arbitrary
-// Filters slice into the preview label filter list. Role values are
+// Filters slice into the label filter list. Role values are
 // lowercased to match the gateway's response normalization.
 func buildMetricFilters(role string, filters []MetricLabelFilterInput) []api.MetricLabelFilter {
 	var out []api.MetricLabelFilter
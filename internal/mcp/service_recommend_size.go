@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// ServiceRecommendSizeInput represents input for service_recommend_size
+type ServiceRecommendSizeInput struct {
+	ServiceID  string `json:"service_id"`
+	WindowDays int    `json:"window_days,omitempty"`
+}
+
+func (ServiceRecommendSizeInput) Schema() *jsonschema.Schema {
+	schema := util.Must(jsonschema.For[ServiceRecommendSizeInput](nil))
+	setServiceIDSchemaProperties(schema)
+
+	schema.Properties["window_days"].Description = "Number of days of usage history to analyze. Defaults to 7."
+	schema.Properties["window_days"].Default = util.Must(json.Marshal(7))
+	schema.Properties["window_days"].Minimum = util.Ptr(1.0)
+	schema.Properties["window_days"].Maximum = util.Ptr(90.0)
+	schema.Properties["window_days"].Examples = []any{7, 30}
+
+	return schema
+}
+
+// ServiceRecommendSizeOutput represents output for service_recommend_size
+type ServiceRecommendSizeOutput struct {
+	Recommendation common.SizeRecommendation `json:"recommendation"`
+	CPUMemory      string                    `json:"cpu_memory,omitempty" jsonschema:"The recommended configuration in the format accepted by service_resize's cpu_memory, when a resize is recommended"`
+}
+
+func (ServiceRecommendSizeOutput) Schema() *jsonschema.Schema {
+	return util.Must(jsonschema.For[ServiceRecommendSizeOutput](nil))
+}
+
+func newServiceRecommendSizeTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:  toolServiceRecommendSize,
+		Title: "Recommend Service Size",
+		Description: `Analyze a service's historical CPU and memory usage and recommend an upsize, downsize, or keeping the current size, with a justification.
+
+The recommendation uses the primary's p95 and peak usage over the window: it recommends an upsize when p95 CPU is above 80% or p95 memory is above 85% of the current allocation, and a downsize when a smaller allowed size keeps p95 CPU under 70%, p95 memory under 85%, and peak usage under 90%.
+
+This tool does not change the service. To apply a recommendation, confirm with the user first, then call service_resize with the returned cpu_memory.`,
+		InputSchema:  ServiceRecommendSizeInput{}.Schema(),
+		OutputSchema: ServiceRecommendSizeOutput{}.Schema(),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:  true,
+			OpenWorldHint: util.Ptr(false),
+			Title:         "Recommend Service Size",
+		},
+	}
+}
+
+// handleServiceRecommendSize handles the service_recommend_size MCP tool
+func (s *Server) handleServiceRecommendSize(ctx context.Context, req *mcp.CallToolRequest, input ServiceRecommendSizeInput) (*mcp.CallToolResult, ServiceRecommendSizeOutput, error) {
+	client, projectID, err := s.app.GetClient()
+	if err != nil {
+		return nil, ServiceRecommendSizeOutput{}, err
+	}
+
+	windowDays := input.WindowDays
+	if windowDays == 0 {
+		windowDays = 7
+	}
+	if windowDays < 1 || windowDays > 90 {
+		return nil, ServiceRecommendSizeOutput{}, fmt.Errorf("window_days must be between 1 and 90")
+	}
+
+	s.logger.Info("MCP: Recommending service size",
+		slog.String("project_id", projectID),
+		slog.String("service_id", input.ServiceID),
+		slog.Int("window_days", windowDays),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	rec, err := common.RecommendServiceSize(ctx, common.RecommendServiceSizeArgs{
+		Client:    client,
+		ProjectID: projectID,
+		ServiceID: input.ServiceID,
+		Window:    time.Duration(windowDays) * 24 * time.Hour,
+	})
+	if err != nil {
+		return nil, ServiceRecommendSizeOutput{}, err
+	}
+
+	output := ServiceRecommendSizeOutput{Recommendation: *rec}
+	if rec.Action != common.SizeActionKeep {
+		recommended := rec.Recommended()
+		output.CPUMemory = recommended.String()
+	}
+	return nil, output, nil
+}
@@ -21,6 +21,7 @@ type ServiceResizeInput struct {
 	ServiceID string `json:"service_id"`
 	CPUMemory string `json:"cpu_memory"`
 	Wait      bool   `json:"wait,omitempty"`
+	Confirm   bool   `json:"confirm,omitempty"`
 }
 
 func (ServiceResizeInput) Schema() *jsonschema.Schema {
@@ -34,6 +35,9 @@ func (ServiceResizeInput) Schema() *jsonschema.Schema {
 	schema.Properties["wait"].Default = util.Must(json.Marshal(false))
 	schema.Properties["wait"].Examples = []any{false, true}
 
+	schema.Properties["confirm"].Description = confirmArgumentDescription
+	schema.Properties["confirm"].Default = util.Must(json.Marshal(false))
+
 	return schema
 }
 
@@ -81,6 +85,10 @@ func (s *Server) handleServiceResize(ctx context.Context, req *mcp.CallToolReque
 		return nil, ServiceResizeOutput{}, err
 	}
 
+	if err := s.confirmOperation(ctx, req, input.Confirm, fmt.Sprintf("Resize service %s to %s? Resizing may briefly interrupt its connections.", input.ServiceID, input.CPUMemory)); err != nil {
+		return nil, ServiceResizeOutput{}, err
+	}
+
 	s.logger.Info("MCP: Resizing service",
 		slog.String("project_id", projectID),
 		slog.String("service_id", input.ServiceID),
@@ -132,6 +140,7 @@ func (s *Server) handleServiceResize(ctx context.Context, req *mcp.CallToolReque
 			},
 			Timeout:    waitTimeout,
 			TimeoutMsg: "service may still be resizing",
+			Progress:   s.waitProgress(ctx, req),
 		}); err != nil {
 			message = fmt.Sprintf("Error: %s", err.Error())
 		} else {
@@ -110,6 +110,7 @@ func (s *Server) handleServiceStart(ctx context.Context, req *mcp.CallToolReques
 			},
 			Timeout:    waitTimeout,
 			TimeoutMsg: "service may still be starting",
+			Progress:   s.waitProgress(ctx, req),
 		}); err != nil {
 			message = fmt.Sprintf("Error: %s", err.Error())
 		} else {
@@ -19,6 +19,7 @@ import (
 type ServiceStopInput struct {
 	ServiceID string `json:"service_id"`
 	Wait      bool   `json:"wait,omitempty"`
+	Confirm   bool   `json:"confirm,omitempty"`
 }
 
 func (ServiceStopInput) Schema() *jsonschema.Schema {
@@ -30,6 +31,9 @@ func (ServiceStopInput) Schema() *jsonschema.Schema {
 	schema.Properties["wait"].Default = util.Must(json.Marshal(false))
 	schema.Properties["wait"].Examples = []any{false, true}
 
+	schema.Properties["confirm"].Description = confirmArgumentDescription
+	schema.Properties["confirm"].Default = util.Must(json.Marshal(false))
+
 	return schema
 }
 
@@ -73,6 +77,10 @@ func (s *Server) handleServiceStop(ctx context.Context, req *mcp.CallToolRequest
 		return nil, ServiceStopOutput{}, err
 	}
 
+	if err := s.confirmOperation(ctx, req, input.Confirm, fmt.Sprintf("Stop service %s? It will stop accepting connections until it is started again.", input.ServiceID)); err != nil {
+		return nil, ServiceStopOutput{}, err
+	}
+
 	s.logger.Info("MCP: Stopping service",
 		slog.String("project_id", projectID),
 		slog.String("service_id", input.ServiceID))
@@ -110,6 +118,7 @@ func (s *Server) handleServiceStop(ctx context.Context, req *mcp.CallToolRequest
 			},
 			Timeout:    waitTimeout,
 			TimeoutMsg: "service may still be stopping",
+			Progress:   s.waitProgress(ctx, req),
 		}); err != nil {
 			message = fmt.Sprintf("Error: %s", err.Error())
 		} else {
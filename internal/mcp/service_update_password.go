@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -19,6 +20,7 @@ import (
 type ServiceUpdatePasswordInput struct {
 	ServiceID string `json:"service_id"`
 	Password  string `json:"password"`
+	Confirm   bool   `json:"confirm,omitempty"`
 }
 
 func (ServiceUpdatePasswordInput) Schema() *jsonschema.Schema {
@@ -29,6 +31,9 @@ func (ServiceUpdatePasswordInput) Schema() *jsonschema.Schema {
 	schema.Properties["password"].Description = "The new password for the 'tsdbadmin' user. Must be strong and secure."
 	schema.Properties["password"].Examples = []any{"MySecurePassword123!"}
 
+	schema.Properties["confirm"].Description = confirmArgumentDescription
+	schema.Properties["confirm"].Default = util.Must(json.Marshal(false))
+
 	return schema
 }
 
@@ -71,6 +76,10 @@ func (s *Server) handleServiceUpdatePassword(ctx context.Context, req *mcp.CallT
 		return nil, ServiceUpdatePasswordOutput{}, err
 	}
 
+	if err := s.confirmOperation(ctx, req, input.Confirm, fmt.Sprintf("Change the password of service %s? Clients using the old password will no longer be able to connect.", input.ServiceID)); err != nil {
+		return nil, ServiceUpdatePasswordOutput{}, err
+	}
+
 	s.logger.Info("MCP: Updating service password",
 		slog.String("project_id", projectID),
 		slog.String("service_id", input.ServiceID))
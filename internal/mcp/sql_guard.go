@@ -0,0 +1,255 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pgquery "github.com/pganalyze/pg_query_go/v6"
+	pgparser "github.com/wasilibs/go-pgquery"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SQL guard policies, set with the mcp_sql_guard config option.
+const (
+	sqlGuardConfirm = "confirm" // destructive statements need confirm_destructive
+	sqlGuardReject  = "reject"  // destructive statements are never run
+	sqlGuardOff     = "off"     // queries run unchecked
+)
+
+// Categories of destructive statements the SQL guard flags.
+const (
+	sqlCategoryDDL             = "ddl"
+	sqlCategoryDrop            = "drop"
+	sqlCategoryTruncate        = "truncate"
+	sqlCategoryAlterSystem     = "alter_system"
+	sqlCategoryDMLWithoutWhere = "dml_without_where"
+	sqlCategoryCopyFrom        = "copy_from"
+	sqlCategoryExecute         = "execute"
+	sqlCategoryFunction        = "destructive_function"
+)
+
+var sqlCategoryReasons = map[string]string{
+	sqlCategoryDDL:             "Changes the database schema, permissions, or other database objects.",
+	sqlCategoryDrop:            "Permanently drops a database object along with any data it holds.",
+	sqlCategoryTruncate:        "Permanently removes every row from the truncated tables.",
+	sqlCategoryAlterSystem:     "Changes server-wide configuration.",
+	sqlCategoryDMLWithoutWhere: "UPDATE or DELETE without a WHERE clause affects every row in the table.",
+	sqlCategoryCopyFrom:        "COPY ... FROM writes rows into a table.",
+	sqlCategoryExecute:         "Runs a prepared statement, which may have been prepared by an earlier query and can't be checked.",
+	sqlCategoryFunction:        "Calls a function that deletes data or disrupts other sessions (e.g. drop_chunks).",
+}
+
+// destructiveFunctions are functions the SQL guard flags when a statement
+// calls them. Other functions with side effects, including user-defined
+// ones, aren't detected.
+var destructiveFunctions = map[string]bool{
+	"drop_chunks":          true, // TimescaleDB: drops chunks and their data
+	"delete_job":           true, // TimescaleDB: deletes a background job
+	"pg_terminate_backend": true,
+	"lo_unlink":            true,
+}
+
+// flaggedStatement is a statement the SQL guard classified as destructive.
+type flaggedStatement struct {
+	Index    int    `json:"index"`
+	SQL      string `json:"sql"`
+	Category string `json:"category"`
+	Reason   string `json:"reason"`
+}
+
+// sqlGuardError explains to the model why the SQL guard blocked a query and
+// what it can do about it. Its message is JSON, so the model gets it as
+// structured data.
+type sqlGuardError struct {
+	Blocked    bool               `json:"blocked"`
+	Policy     string             `json:"policy"`
+	Statements []flaggedStatement `json:"statements"`
+	Resolution string             `json:"resolution"`
+}
+
+func (e *sqlGuardError) Error() string {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("query blocked by the SQL safety guard (mcp_sql_guard=%s)", e.Policy)
+	}
+	return "Query blocked by the SQL safety guard:\n" + string(data)
+}
+
+// checkSQLGuard applies the SQL guard policy to query, returning a
+// *sqlGuardError if it contains destructive statements that the policy
+// doesn't let run. Queries the parser can't handle are let through for the
+// server to reject (or run, for syntax newer than the parser's).
+func checkSQLGuard(policy, query string, confirmed bool) error {
+	if policy == sqlGuardOff || (policy != sqlGuardReject && confirmed) {
+		return nil
+	}
+
+	flagged, err := classifySQL(query)
+	if err != nil || len(flagged) == 0 {
+		return nil
+	}
+
+	resolution := "Explain the statements above to the user and ask whether to run them. Only if the user explicitly agrees, call db_execute_query again with the same query and confirm_destructive: true."
+	if policy == sqlGuardReject {
+		resolution = "The mcp_sql_guard config option is set to reject, so these statements can't be run through this tool. The user can run them directly (e.g. with `tiger db connect`) or change the policy with `tiger config set mcp_sql_guard confirm`."
+	}
+	return &sqlGuardError{
+		Blocked:    true,
+		Policy:     policy,
+		Statements: flagged,
+		Resolution: resolution,
+	}
+}
+
+// classifySQL parses query with the Postgres parser and returns the
+// statements the SQL guard considers destructive.
+func classifySQL(query string) ([]flaggedStatement, error) {
+	tree, err := pgparser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var flagged []flaggedStatement
+	for i, raw := range tree.GetStmts() {
+		category := classifyStatement(raw.GetStmt())
+		if category == "" {
+			continue
+		}
+		flagged = append(flagged, flaggedStatement{
+			Index:    i + 1,
+			SQL:      statementText(query, raw),
+			Category: category,
+			Reason:   sqlCategoryReasons[category],
+		})
+	}
+	return flagged, nil
+}
+
+// statementText returns the source text of a parsed statement. The parser
+// reports byte offsets, with a zero length meaning "to the end of the query".
+func statementText(query string, raw *pgquery.RawStmt) string {
+	start := int(raw.GetStmtLocation())
+	end := len(query)
+	if n := int(raw.GetStmtLen()); n > 0 && start+n <= end {
+		end = start + n
+	}
+	if start < 0 || start > end {
+		return strings.TrimSpace(query)
+	}
+	return strings.TrimSpace(query[start:end])
+}
+
+// classifyStatement returns the destructive category of a statement, or ""
+// if it is safe to run unconfirmed. Statement types not known to be safe
+// count as DDL, so new or unusual statements err on the side of asking.
+func classifyStatement(node *pgquery.Node) string {
+	switch n := node.GetNode().(type) {
+	case nil:
+		return ""
+	case *pgquery.Node_DropStmt, *pgquery.Node_DropdbStmt, *pgquery.Node_DropRoleStmt,
+		*pgquery.Node_DropTableSpaceStmt, *pgquery.Node_DropOwnedStmt,
+		*pgquery.Node_DropSubscriptionStmt, *pgquery.Node_DropUserMappingStmt:
+		return sqlCategoryDrop
+	case *pgquery.Node_TruncateStmt:
+		return sqlCategoryTruncate
+	case *pgquery.Node_AlterSystemStmt:
+		return sqlCategoryAlterSystem
+	case *pgquery.Node_UpdateStmt:
+		if n.UpdateStmt.GetWhereClause() == nil {
+			return sqlCategoryDMLWithoutWhere
+		}
+		return classifyQuery(n.UpdateStmt.GetWithClause(), n.UpdateStmt)
+	case *pgquery.Node_DeleteStmt:
+		if n.DeleteStmt.GetWhereClause() == nil {
+			return sqlCategoryDMLWithoutWhere
+		}
+		return classifyQuery(n.DeleteStmt.GetWithClause(), n.DeleteStmt)
+	case *pgquery.Node_SelectStmt:
+		return classifyQuery(n.SelectStmt.GetWithClause(), n.SelectStmt)
+	case *pgquery.Node_InsertStmt:
+		return classifyQuery(n.InsertStmt.GetWithClause(), n.InsertStmt)
+	case *pgquery.Node_MergeStmt:
+		return classifyQuery(n.MergeStmt.GetWithClause(), n.MergeStmt)
+	case *pgquery.Node_CallStmt:
+		return classifyQuery(nil, n.CallStmt)
+	case *pgquery.Node_PrepareStmt:
+		return classifyStatement(n.PrepareStmt.GetQuery())
+	case *pgquery.Node_ExecuteStmt:
+		// The prepared statement may come from an earlier query, so what it
+		// does can't be known here
+		return sqlCategoryExecute
+	case *pgquery.Node_CopyStmt:
+		if n.CopyStmt.GetIsFrom() {
+			return sqlCategoryCopyFrom
+		}
+		// COPY (query) TO runs the query
+		return classifyStatement(n.CopyStmt.GetQuery())
+	case *pgquery.Node_ExplainStmt:
+		// EXPLAIN ANALYZE runs the statement it explains
+		if explainAnalyzes(n.ExplainStmt) {
+			return classifyStatement(n.ExplainStmt.GetQuery())
+		}
+		return ""
+	case *pgquery.Node_VariableSetStmt, *pgquery.Node_VariableShowStmt,
+		*pgquery.Node_TransactionStmt, *pgquery.Node_LockStmt, *pgquery.Node_DeallocateStmt,
+		*pgquery.Node_DeclareCursorStmt, *pgquery.Node_FetchStmt, *pgquery.Node_ClosePortalStmt,
+		*pgquery.Node_ListenStmt, *pgquery.Node_UnlistenStmt, *pgquery.Node_NotifyStmt,
+		*pgquery.Node_VacuumStmt, *pgquery.Node_CheckPointStmt,
+		*pgquery.Node_DiscardStmt, *pgquery.Node_ConstraintsSetStmt, *pgquery.Node_RefreshMatViewStmt:
+		return ""
+	default:
+		return sqlCategoryDDL
+	}
+}
+
+// classifyQuery classifies the data-modifying statements in a WITH clause
+// (e.g. WITH d AS (DELETE FROM t RETURNING *) SELECT ...) and the
+// destructive functions stmt calls anywhere, including in subqueries.
+func classifyQuery(with *pgquery.WithClause, stmt protoreflect.ProtoMessage) string {
+	for _, cte := range with.GetCtes() {
+		if category := classifyStatement(cte.GetCommonTableExpr().GetCtequery()); category != "" {
+			return category
+		}
+	}
+	if callsDestructiveFunction(stmt.ProtoReflect()) {
+		return sqlCategoryFunction
+	}
+	return ""
+}
+
+// callsDestructiveFunction walks a parse tree looking for calls to
+// destructiveFunctions.
+func callsDestructiveFunction(msg protoreflect.Message) bool {
+	if call, ok := msg.Interface().(*pgquery.FuncCall); ok {
+		names := call.GetFuncname()
+		if len(names) > 0 && destructiveFunctions[names[len(names)-1].GetString_().GetSval()] {
+			return true
+		}
+	}
+
+	found := false
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Message() == nil || fd.IsMap():
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len() && !found; i++ {
+				found = callsDestructiveFunction(list.Get(i).Message())
+			}
+		default:
+			found = callsDestructiveFunction(v.Message())
+		}
+		return !found
+	})
+	return found
+}
+
+func explainAnalyzes(stmt *pgquery.ExplainStmt) bool {
+	for _, opt := range stmt.GetOptions() {
+		if strings.EqualFold(opt.GetDefElem().GetDefname(), "analyze") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClassifySQL(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string // categories of the flagged statements, in order
+	}{
+		{"SELECT * FROM users", nil},
+		{"INSERT INTO users (name) VALUES ('alice')", nil},
+		{"UPDATE users SET name = 'bob' WHERE id = $1", nil},
+		{"DELETE FROM users WHERE id = 1", nil},
+		{"EXPLAIN DELETE FROM users", nil},
+		{"BEGIN; SET statement_timeout = '5s'; SHOW search_path; COMMIT", nil},
+		{"VACUUM ANALYZE users", nil},
+		{"UPDATE users SET active = false", []string{sqlCategoryDMLWithoutWhere}},
+		{"delete from users", []string{sqlCategoryDMLWithoutWhere}},
+		{"EXPLAIN ANALYZE DELETE FROM users", []string{sqlCategoryDMLWithoutWhere}},
+		{"WITH gone AS (DELETE FROM users RETURNING id) SELECT count(*) FROM gone", []string{sqlCategoryDMLWithoutWhere}},
+		{"DROP TABLE users", []string{sqlCategoryDrop}},
+		{"DROP DATABASE tsdb", []string{sqlCategoryDrop}},
+		{"TRUNCATE users, orders", []string{sqlCategoryTruncate}},
+		{"ALTER SYSTEM SET work_mem = '64MB'", []string{sqlCategoryAlterSystem}},
+		{"CREATE TABLE t (id int)", []string{sqlCategoryDDL}},
+		{"ALTER TABLE users ADD COLUMN age int", []string{sqlCategoryDDL}},
+		{"GRANT SELECT ON users TO readonly", []string{sqlCategoryDDL}},
+		{"DO $$ BEGIN PERFORM 1; END $$", []string{sqlCategoryDDL}},
+		{"SELECT 1; DROP TABLE users; TRUNCATE orders", []string{sqlCategoryDrop, sqlCategoryTruncate}},
+		{"PREPARE p AS SELECT * FROM users WHERE id = $1", nil},
+		{"PREPARE p AS DELETE FROM users", []string{sqlCategoryDMLWithoutWhere}},
+		{"PREPARE p AS DELETE FROM users; EXECUTE p", []string{sqlCategoryDMLWithoutWhere, sqlCategoryExecute}},
+		{"EXECUTE p(1)", []string{sqlCategoryExecute}},
+		{"COPY users TO STDOUT", nil},
+		{"COPY (SELECT * FROM users) TO STDOUT", nil},
+		{"COPY users FROM STDIN", []string{sqlCategoryCopyFrom}},
+		{"COPY (WITH d AS (DELETE FROM users RETURNING *) SELECT * FROM d) TO STDOUT", []string{sqlCategoryDMLWithoutWhere}},
+		{"SELECT now(), count(*) FROM users", nil},
+		{"SELECT drop_chunks('metrics', older_than => INTERVAL '7 days')", []string{sqlCategoryFunction}},
+		{"SELECT public.drop_chunks('metrics', INTERVAL '7 days')", []string{sqlCategoryFunction}},
+		{"SELECT * FROM users WHERE id IN (SELECT pg_terminate_backend(pid) FROM pg_stat_activity)", []string{sqlCategoryFunction}},
+		{"DELETE FROM users WHERE id = delete_job(1000)", []string{sqlCategoryFunction}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			flagged, err := classifySQL(tt.query)
+			if err != nil {
+				t.Fatalf("classifySQL() error = %v", err)
+			}
+			var got []string
+			for _, f := range flagged {
+				got = append(got, f.Category)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("categories = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifySQL_StatementText(t *testing.T) {
+	flagged, err := classifySQL("SELECT 1;\n  DROP TABLE users ;\nTRUNCATE orders")
+	if err != nil {
+		t.Fatalf("classifySQL() error = %v", err)
+	}
+	if len(flagged) != 2 {
+		t.Fatalf("flagged %d statements, want 2", len(flagged))
+	}
+	if flagged[0].Index != 2 || flagged[0].SQL != "DROP TABLE users" {
+		t.Errorf("first flagged = %+v, want statement 2 'DROP TABLE users'", flagged[0])
+	}
+	if flagged[1].Index != 3 || flagged[1].SQL != "TRUNCATE orders" {
+		t.Errorf("second flagged = %+v, want statement 3 'TRUNCATE orders'", flagged[1])
+	}
+}
+
+func TestCheckSQLGuard(t *testing.T) {
+	const destructive = "DROP TABLE users"
+
+	tests := []struct {
+		name      string
+		policy    string
+		query     string
+		confirmed bool
+		blocked   bool
+	}{
+		{"safe query", sqlGuardConfirm, "SELECT 1", false, false},
+		{"confirm policy without confirmation", sqlGuardConfirm, destructive, false, true},
+		{"confirm policy with confirmation", sqlGuardConfirm, destructive, true, false},
+		{"reject policy ignores confirmation", sqlGuardReject, destructive, true, true},
+		{"off policy", sqlGuardOff, destructive, false, false},
+		{"unparseable query is left to the server", sqlGuardReject, "DROP TABLEE users", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSQLGuard(tt.policy, tt.query, tt.confirmed)
+			if !tt.blocked {
+				if err != nil {
+					t.Errorf("checkSQLGuard() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var guardErr *sqlGuardError
+			if !errors.As(err, &guardErr) {
+				t.Fatalf("checkSQLGuard() error = %v, want *sqlGuardError", err)
+			}
+			if guardErr.Policy != tt.policy || len(guardErr.Statements) != 1 || guardErr.Statements[0].Category != sqlCategoryDrop {
+				t.Errorf("guard error = %+v", guardErr)
+			}
+			if !strings.Contains(err.Error(), `"category": "drop"`) {
+				t.Errorf("error message should carry the structured explanation, got %s", err)
+			}
+		})
+	}
+}
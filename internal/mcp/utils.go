@@ -1,12 +1,15 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/jackc/pgx/v5"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/timescale/tiger-cli/internal/api"
 	"github.com/timescale/tiger-cli/internal/common"
@@ -24,6 +27,34 @@ const (
 // Wait timeout for MCP tool operations
 const waitTimeout = 10 * time.Minute
 
+// waitProgress returns a common.WaitForServiceArgs.Progress callback that
+// relays wait status messages to the client as progress notifications, or nil
+// if the client didn't ask for progress (by sending a progress token with the
+// tool call). Notifications go out on every poll, even when the message is
+// unchanged, so clients that reset their request timeout on progress keep
+// waiting.
+func (s *Server) waitProgress(ctx context.Context, req *mcp.CallToolRequest) func(string) {
+	if req == nil || req.Session == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+
+	var progress float64
+	return func(message string) {
+		progress++
+		if err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Message:       message,
+			Progress:      progress,
+		}); err != nil {
+			s.logger.Debug("Failed to send progress notification", slog.Any("error", err))
+		}
+	}
+}
+
 // validServiceTypes returns a slice of all valid service type values
 func validServiceTypes() []string {
 	return []string{
@@ -161,3 +192,30 @@ func (s *Server) convertToServiceDetail(cfg *config.Config, service api.Service,
 
 	return detail
 }
+
+// connectForRoleManagement connects to a service as tsdbadmin, the role that
+// can create and drop other roles. Roles can't be changed on a read replica,
+// so unless readOnly is set (for listing), a replica ID is rejected. The
+// resolved target is returned along with the connection.
+func connectForRoleManagement(ctx context.Context, cfg *config.Config, client api.ClientWithResponsesInterface, projectID, serviceID string, readOnly bool) (*pgx.Conn, *common.ConnectionTarget, error) {
+	target, err := common.ResolveConnectionTargetByID(ctx, client, projectID, serviceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target.IsReplica && !readOnly {
+		return nil, nil, fmt.Errorf("%q is a read replica; manage roles on its primary service %q instead",
+			serviceID, target.CredentialService.ServiceID)
+	}
+	if err := common.CheckServiceReady(target.ConnectionService); err != nil {
+		return nil, nil, err
+	}
+	conn, err := common.ConnectTarget(ctx, cfg, target, common.ConnectionDetailsOptions{
+		Role:         "tsdbadmin",
+		WithPassword: true,
+		ReadOnly:     readOnly,
+	}, pgx.QueryExecModeDescribeExec)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, target, nil
+}
@@ -23,3 +23,15 @@ func IsCI() bool {
 		os.Getenv("BUILD_NUMBER") != "" || // Jenkins, TeamCity
 		os.Getenv("RUN_ID") != "" // TaskCluster, dsari
 }
+
+// TerminalWidth returns the width in columns of an [io.Writer] or [io.Reader]
+// that is a terminal, or 0 if it isn't one or its size can't be determined.
+// It is a variable so that tests can override it.
+var TerminalWidth = func(v any) int {
+	if f, ok := v.(*os.File); ok {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil {
+			return width
+		}
+	}
+	return 0
+}
@@ -29,8 +29,31 @@ func SupportedClients() []ClientInfo {
 // Optional fields:
 //   - CreateBackup: If true, creates a backup of the existing config file before modification
 //   - CustomConfigPath: Custom path to the config file (empty string uses default location)
+//   - Env: Environment variables to set for the MCP server process
+//   - ProjectDir: Install into the client's per-project config file in this directory
+//     instead of the user-global configuration
 //
 // Returns an error if the client is not supported, required options are missing, or installation fails.
 func Install(opts Options) error {
 	return cmd.InstallMCPForClient(opts)
 }
+
+// UninstallOptions configures MCP server removal.
+type UninstallOptions = cmd.UninstallOptions
+
+// Uninstall removes an MCP server configuration from the specified client.
+//
+// Required options:
+//   - ClientName: The name of the client to configure (e.g., "claude-code", "cursor", "windsurf")
+//   - ServerName: The name the MCP server was registered as (e.g., "my-mcp-server")
+//
+// Optional fields:
+//   - CreateBackup: If true, creates a backup of the existing config file before modification
+//   - CustomConfigPath: Custom path to the config file (empty string uses default location)
+//   - ProjectDir: Remove from the client's per-project config file in this directory
+//
+// Returns the path of the backup file (empty if none was created), or an error
+// if the client is not supported, the server is not installed, or removal fails.
+func Uninstall(opts UninstallOptions) (string, error) {
+	return cmd.UninstallMCPForClient(opts)
+}
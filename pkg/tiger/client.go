@@ -0,0 +1,109 @@
+// Package tiger provides a public Go API for Tiger Cloud operations: creating
+// an authenticated client from the Tiger CLI's configuration and credentials,
+// managing services (with optional waits for status changes), resolving
+// database connection details, and fetching database schemas.
+//
+// The package follows semantic versioning along with the Tiger CLI module:
+// exported identifiers are not removed or changed incompatibly within a major
+// version. Service, ConnectionDetails, DatabaseSchema, and the other model
+// types are defined by this package rather than generated from the Tiger
+// Cloud REST API, so they may gain fields but don't change incompatibly.
+package tiger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/config"
+)
+
+// ErrNotLoggedIn is returned by NewClient when no credentials were provided
+// and none are stored (see 'tiger auth login').
+var ErrNotLoggedIn = config.ErrNotLoggedIn
+
+// Options configures how NewClient loads configuration and credentials.
+type Options struct {
+	// ConfigDir is the Tiger CLI configuration directory. Defaults to
+	// TIGER_CONFIG_DIR, or ~/.config/tiger. Configuration values (e.g.
+	// api_url, password_storage) are read from it, and TIGER_* environment
+	// variables override them, exactly as for the CLI.
+	ConfigDir string
+
+	// Profile is the named profile whose credentials and configuration
+	// overrides to use (see 'tiger profile'). Defaults to TIGER_PROFILE, or
+	// the profile selected with 'tiger profile use'.
+	Profile string
+
+	// PublicKey and SecretKey are API credentials to authenticate with. If
+	// both are empty, the TIGER_PUBLIC_KEY and TIGER_SECRET_KEY environment
+	// variables are used, then the credentials stored by 'tiger auth login'.
+	PublicKey string
+	SecretKey string
+}
+
+// Client performs Tiger Cloud operations for a single project. It is safe for
+// concurrent use.
+type Client struct {
+	cfg       *config.Config
+	api       api.ClientWithResponsesInterface
+	projectID string
+}
+
+// NewClient loads the Tiger CLI configuration and credentials and returns a
+// Client for the credentials' project.
+func NewClient(ctx context.Context, opts Options) (*Client, error) {
+	if (opts.PublicKey == "") != (opts.SecretKey == "") {
+		return nil, errors.New("both PublicKey and SecretKey must be provided")
+	}
+
+	// config.Load only honors an explicitly set --config-dir flag, falling
+	// back to TIGER_CONFIG_DIR and the default location otherwise. Likewise,
+	// an unset --profile flag leaves the profile to TIGER_PROFILE and the
+	// config file.
+	flags := pflag.NewFlagSet("tiger", pflag.ContinueOnError)
+	flags.String("config-dir", "", "config directory")
+	flags.String("profile", "", "profile")
+	if opts.ConfigDir != "" {
+		if err := flags.Set("config-dir", opts.ConfigDir); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Profile != "" {
+		if err := flags.Set("profile", opts.Profile); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, err := config.Load(flags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if opts.PublicKey == "" {
+		client, projectID, err := common.NewAPIClient(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return &Client{cfg: cfg, api: client, projectID: projectID}, nil
+	}
+
+	client, err := api.NewTigerClient(cfg, opts.PublicKey+":"+opts.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+	authInfo, err := common.ValidateAPIKey(ctx, cfg, client)
+	if err != nil {
+		return nil, fmt.Errorf("API key validation failed: %w", err)
+	}
+	return &Client{cfg: cfg, api: client, projectID: authInfo.APIKey.Project.ID}, nil
+}
+
+// ProjectID returns the ID of the project the client operates on.
+func (c *Client) ProjectID() string {
+	return c.projectID
+}
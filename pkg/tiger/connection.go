@@ -0,0 +1,145 @@
+package tiger
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/timescale/tiger-cli/internal/common"
+)
+
+// DefaultRole is the database role services are created with.
+const DefaultRole = "tsdbadmin"
+
+// ConnectionDetails describes how to connect to a service's database. Its
+// String method returns a PostgreSQL connection URL.
+type ConnectionDetails struct {
+	Role string `json:"role"`
+	// Password is only set when ConnectionOptions.WithPassword was requested
+	// and the password was found.
+	Password string `json:"password,omitempty"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Database string `json:"database"`
+	// IsPooler reports whether Host and Port are the connection pooler's.
+	IsPooler bool `json:"is_pooler,omitempty"`
+	// ReadOnly opens the connection in Tiger Cloud's immutable read-only
+	// mode.
+	ReadOnly bool `json:"read_only,omitempty"`
+}
+
+// String returns the details as a PostgreSQL connection URL.
+func (d *ConnectionDetails) String() string {
+	return common.ConnectionURL(d.Role, d.Password, d.Host, d.Port, d.Database, d.ReadOnly)
+}
+
+// ConnectionOptions configures ConnectionDetails and Connect.
+type ConnectionOptions struct {
+	// Role is the database role to connect as. Defaults to DefaultRole.
+	Role string
+	// Pooled connects through the service's connection pooler. It is an error
+	// if a primary service has no pooler; read replicas without one fall back
+	// to a direct connection.
+	Pooled bool
+	// WithPassword looks up the role's password in the configured password
+	// storage (keyring or ~/.pgpass) and includes it in the details.
+	WithPassword bool
+	// ReadOnly opens the connection in Tiger Cloud's immutable read-only
+	// mode.
+	ReadOnly bool
+}
+
+// ConnectionDetails resolves the connection details for a service or read
+// replica set. Read replicas use their parent service's credentials.
+func (c *Client) ConnectionDetails(ctx context.Context, serviceID string, opts ConnectionOptions) (*ConnectionDetails, error) {
+	target, err := common.ResolveConnectionTargetByID(ctx, c.api, c.projectID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	details, err := target.Details(c.cfg, opts.detailsOptions())
+	if err != nil {
+		return nil, err
+	}
+	return &ConnectionDetails{
+		Role:     details.Role,
+		Password: details.Password,
+		Host:     details.Host,
+		Port:     details.Port,
+		Database: details.Database,
+		IsPooler: details.IsPooler,
+		ReadOnly: details.ReadOnly(),
+	}, nil
+}
+
+// Connect opens a database connection to a service or read replica set. The
+// password is always looked up in the configured password storage. The caller
+// must close the returned connection. Statements are described before
+// execution rather than cached, so the connection also works through the pooler.
+func (c *Client) Connect(ctx context.Context, serviceID string, opts ConnectionOptions) (*pgx.Conn, error) {
+	target, err := common.ResolveConnectionTargetByID(ctx, c.api, c.projectID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := common.CheckServiceReady(target.ConnectionService); err != nil {
+		return nil, err
+	}
+
+	detailsOpts := opts.detailsOptions()
+	detailsOpts.WithPassword = true
+	return common.ConnectTarget(ctx, c.cfg, target, detailsOpts, pgx.QueryExecModeDescribeExec)
+}
+
+// detailsOptions converts the options to their internal equivalent
+func (o ConnectionOptions) detailsOptions() common.ConnectionDetailsOptions {
+	role := o.Role
+	if role == "" {
+		role = DefaultRole
+	}
+	return common.ConnectionDetailsOptions{
+		Pooled:       o.Pooled,
+		Role:         role,
+		WithPassword: o.WithPassword,
+		ReadOnly:     o.ReadOnly,
+	}
+}
+
+// SchemaOptions controls what Schema fetches.
+type SchemaOptions struct {
+	// Role is the database role to connect as. Defaults to DefaultRole.
+	Role string
+	// Pooled connects through the service's connection pooler.
+	Pooled bool
+	// Schema, if non-empty, limits the fetch to a single namespace.
+	Schema string
+	// IncludeInternal adds catalog (pg_*) and extension-owned objects.
+	IncludeInternal bool
+	// IncludeDefinitions fetches view and routine definitions.
+	IncludeDefinitions bool
+	// IncludeComments fetches object comments.
+	IncludeComments bool
+}
+
+// Schema introspects the database schema of a service or read replica set
+// (tables, views, indexes, routines, TimescaleDB hypertables, and more) over
+// a read-only connection.
+func (c *Client) Schema(ctx context.Context, serviceID string, opts SchemaOptions) (*DatabaseSchema, error) {
+	target, err := common.ResolveConnectionTargetByID(ctx, c.api, c.projectID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	role := opts.Role
+	if role == "" {
+		role = DefaultRole
+	}
+	schema, err := common.FetchServiceSchema(ctx, c.cfg, target, role, opts.Pooled, common.SchemaOptions{
+		Schema:             opts.Schema,
+		IncludeInternal:    opts.IncludeInternal,
+		IncludeDefinitions: opts.IncludeDefinitions,
+		IncludeComments:    opts.IncludeComments,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newDatabaseSchema(schema), nil
+}
@@ -0,0 +1,339 @@
+package tiger
+
+import (
+	"slices"
+
+	"github.com/timescale/tiger-cli/internal/common"
+)
+
+// DatabaseSchema is the introspected schema of a service's database, grouped
+// by namespace (Postgres schema).
+type DatabaseSchema struct {
+	// ID and Name identify the service the schema was fetched from.
+	ID      string             `json:"id"`
+	Name    string             `json:"name"`
+	Schemas []NamespacedSchema `json:"schemas"`
+}
+
+// NamespacedSchema groups the objects belonging to a single Postgres schema.
+type NamespacedSchema struct {
+	Name string `json:"name"`
+	// Comment is only populated when SchemaOptions.IncludeComments is set,
+	// as are the other objects' comments.
+	Comment           string        `json:"comment,omitempty"`
+	Tables            []TableSchema `json:"tables,omitempty"`
+	Views             []ViewSchema  `json:"views,omitempty"`
+	MaterializedViews []ViewSchema  `json:"materialized_views,omitempty"`
+	Enums             []EnumSchema  `json:"enums,omitempty"`
+	Functions         []Routine     `json:"functions,omitempty"`
+	Procedures        []Routine     `json:"procedures,omitempty"`
+}
+
+// TableSchema describes a table, including partitioned, foreign, and
+// TimescaleDB hypertables.
+type TableSchema struct {
+	Name        string                `json:"name"`
+	Comment     string                `json:"comment,omitempty"`
+	Columns     []TableColumnSchema   `json:"columns,omitempty"`
+	Constraints []TableConstraint     `json:"constraints,omitempty"`
+	Indexes     []IndexSchema         `json:"indexes,omitempty"`
+	Checks      []CheckConstraint     `json:"checks,omitempty"`
+	Exclusions  []ExclusionConstraint `json:"exclusions,omitempty"`
+	Triggers    []TriggerSchema       `json:"triggers,omitempty"`
+	// Partitions lists the direct child partitions of a partitioned table.
+	Partitions []PartitionInfo `json:"partitions,omitempty"`
+	// Hypertable is set for TimescaleDB hypertables.
+	Hypertable *HypertableInfo `json:"hypertable,omitempty"`
+	// Foreign is set for foreign tables.
+	Foreign *ForeignTableInfo `json:"foreign,omitempty"`
+}
+
+// TableColumnSchema describes a table column.
+type TableColumnSchema struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Comment string `json:"comment,omitempty"`
+	NotNull bool   `json:"not_null,omitempty"`
+	// Default is the column's default expression, or empty if it has none.
+	Default string `json:"default,omitempty"`
+	// IsSerial is set for SERIAL columns (backed by a sequence rather than
+	// an identity).
+	IsSerial bool `json:"is_serial,omitempty"`
+	// IdentityType is "a" for GENERATED ALWAYS, "d" for GENERATED BY
+	// DEFAULT, or empty if the column isn't an identity column.
+	IdentityType string `json:"identity_type,omitempty"`
+}
+
+// Table constraint types.
+const (
+	ConstraintPrimaryKey = "PRIMARY KEY"
+	ConstraintUnique     = "UNIQUE"
+	ConstraintForeignKey = "FOREIGN KEY"
+)
+
+// TableConstraint describes a primary key, unique, or foreign key
+// constraint.
+type TableConstraint struct {
+	// Type is ConstraintPrimaryKey, ConstraintUnique, or ConstraintForeignKey.
+	Type    string   `json:"type"`
+	Name    string   `json:"name"`
+	Columns []string `json:"columns,omitempty"`
+	// RefTable and RefColumns are the referenced table and columns of a
+	// foreign key.
+	RefTable   string   `json:"ref_table,omitempty"`
+	RefColumns []string `json:"ref_columns,omitempty"`
+}
+
+// IndexSchema describes an index.
+type IndexSchema struct {
+	Name string `json:"name"`
+	// Columns are the indexed column expressions, e.g. "created_at DESC".
+	Columns    string `json:"columns"`
+	Definition string `json:"definition,omitempty"`
+	IsUnique   bool   `json:"is_unique,omitempty"`
+	// WhereClause is the predicate of a partial index.
+	WhereClause string `json:"where_clause,omitempty"`
+}
+
+// CheckConstraint describes a check constraint.
+type CheckConstraint struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns,omitempty"`
+	// Expression is the full constraint definition, e.g. "CHECK ((age > 0))".
+	Expression string `json:"expression"`
+}
+
+// ExclusionConstraint describes an exclusion constraint.
+type ExclusionConstraint struct {
+	Name string `json:"name"`
+	// Definition is the full constraint definition, e.g.
+	// "EXCLUDE USING gist (circle WITH &&)".
+	Definition string `json:"definition"`
+}
+
+// TriggerSchema describes a trigger on a table or view.
+type TriggerSchema struct {
+	Name         string `json:"name"`
+	Timing       string `json:"timing"`
+	Manipulation string `json:"manipulation"`
+	Statement    string `json:"statement"`
+}
+
+// PartitionInfo describes a child partition of a partitioned table.
+type PartitionInfo struct {
+	Name string `json:"name"`
+	// Schema is only set when the partition lives in a different schema than
+	// its parent table.
+	Schema string `json:"schema,omitempty"`
+	// Bound is the partition bound, e.g. "FOR VALUES FROM ('2024-01-01') TO
+	// ('2025-01-01')".
+	Bound string `json:"bound,omitempty"`
+}
+
+// HypertableInfo describes a TimescaleDB hypertable.
+type HypertableInfo struct {
+	CompressionEnabled bool `json:"compression_enabled"`
+	NumChunks          int  `json:"num_chunks"`
+}
+
+// ForeignTableInfo describes the foreign data wrapper binding of a foreign
+// table. Only table-level options are included.
+type ForeignTableInfo struct {
+	Server  string `json:"server"`
+	Wrapper string `json:"wrapper"`
+	// Options are the table's options as "key=value" strings.
+	Options []string `json:"options,omitempty"`
+}
+
+// ViewSchema describes a view or materialized view.
+type ViewSchema struct {
+	Name    string             `json:"name"`
+	Comment string             `json:"comment,omitempty"`
+	Columns []ViewColumnSchema `json:"columns,omitempty"`
+	// Definition is the view's defining query. Only populated when
+	// SchemaOptions.IncludeDefinitions is set.
+	Definition string `json:"definition,omitempty"`
+	// Indexes are only populated for materialized views.
+	Indexes  []IndexSchema   `json:"indexes,omitempty"`
+	Triggers []TriggerSchema `json:"triggers,omitempty"`
+	// ContinuousAggregate is set for TimescaleDB continuous aggregates.
+	ContinuousAggregate *ContinuousAggregateInfo `json:"continuous_aggregate,omitempty"`
+}
+
+// ViewColumnSchema describes a view column.
+type ViewColumnSchema struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ContinuousAggregateInfo describes a TimescaleDB continuous aggregate.
+type ContinuousAggregateInfo struct {
+	CompressionEnabled bool `json:"compression_enabled"`
+	// MaterializedOnly reports whether queries return only materialized data,
+	// rather than also combining not-yet-materialized recent data.
+	MaterializedOnly bool `json:"materialized_only"`
+}
+
+// EnumSchema describes an enum type.
+type EnumSchema struct {
+	Name    string   `json:"name"`
+	Comment string   `json:"comment,omitempty"`
+	Values  []string `json:"values,omitempty"`
+}
+
+// Routine types.
+const (
+	RoutineFunction  = "FUNCTION"
+	RoutineProcedure = "PROCEDURE"
+)
+
+// Routine describes a function or procedure.
+type Routine struct {
+	Name string `json:"name"`
+	// Arguments is the identity argument list (e.g. "integer, text"), which
+	// distinguishes overloaded routines.
+	Arguments string `json:"arguments,omitempty"`
+	// Type is RoutineFunction or RoutineProcedure.
+	Type    string `json:"type"`
+	Comment string `json:"comment,omitempty"`
+	// Definition is the routine's body. Only populated when
+	// SchemaOptions.IncludeDefinitions is set.
+	Definition string `json:"definition,omitempty"`
+}
+
+// newDatabaseSchema converts an introspected schema to a DatabaseSchema
+func newDatabaseSchema(schema *common.DatabaseSchema) *DatabaseSchema {
+	return &DatabaseSchema{
+		ID:   schema.ID,
+		Name: schema.Name,
+		Schemas: convertAll(schema.Schemas, func(ns common.NamespacedSchema) NamespacedSchema {
+			return NamespacedSchema{
+				Name:              ns.Name,
+				Comment:           ns.Comment,
+				Tables:            convertAll(ns.Tables, newTableSchema),
+				Views:             convertAll(ns.Views, newViewSchema),
+				MaterializedViews: convertAll(ns.MaterializedViews, newViewSchema),
+				Enums: convertAll(ns.Enums, func(e common.EnumSchema) EnumSchema {
+					return EnumSchema{Name: e.Name, Comment: e.Comment, Values: slices.Clone(e.Values)}
+				}),
+				Functions:  convertAll(ns.Functions, newRoutine),
+				Procedures: convertAll(ns.Procedures, newRoutine),
+			}
+		}),
+	}
+}
+
+func newTableSchema(t common.TableSchema) TableSchema {
+	table := TableSchema{
+		Name:    t.Name,
+		Comment: t.Comment,
+		Columns: convertAll(t.Columns, func(c common.TableColumnSchema) TableColumnSchema {
+			return TableColumnSchema{
+				Name:         c.Name,
+				Type:         c.Type,
+				Comment:      c.Comment,
+				NotNull:      c.NotNull,
+				Default:      c.Default,
+				IsSerial:     c.IsSerial,
+				IdentityType: c.IdentityType,
+			}
+		}),
+		Constraints: convertAll(t.Constraints, func(c common.TableConstraint) TableConstraint {
+			return TableConstraint{
+				Type:       string(c.Type),
+				Name:       c.Name,
+				Columns:    slices.Clone(c.Columns),
+				RefTable:   c.RefTable,
+				RefColumns: slices.Clone(c.RefColumns),
+			}
+		}),
+		Indexes: convertAll(t.Indexes, newIndexSchema),
+		Checks: convertAll(t.Checks, func(c common.CheckConstraint) CheckConstraint {
+			return CheckConstraint{Name: c.Name, Columns: slices.Clone(c.Columns), Expression: c.Expression}
+		}),
+		Exclusions: convertAll(t.Exclusions, func(e common.ExclusionConstraint) ExclusionConstraint {
+			return ExclusionConstraint{Name: e.Name, Definition: e.Definition}
+		}),
+		Triggers: convertAll(t.Triggers, newTriggerSchema),
+		Partitions: convertAll(t.Partitions, func(p common.PartitionInfo) PartitionInfo {
+			return PartitionInfo{Name: p.Name, Schema: p.Schema, Bound: p.Bound}
+		}),
+	}
+	if t.Hypertable != nil {
+		table.Hypertable = &HypertableInfo{
+			CompressionEnabled: t.Hypertable.CompressionEnabled,
+			NumChunks:          t.Hypertable.NumChunks,
+		}
+	}
+	if t.Foreign != nil {
+		table.Foreign = &ForeignTableInfo{
+			Server:  t.Foreign.Server,
+			Wrapper: t.Foreign.Wrapper,
+			Options: slices.Clone(t.Foreign.Options),
+		}
+	}
+	return table
+}
+
+func newViewSchema(v common.ViewSchema) ViewSchema {
+	view := ViewSchema{
+		Name:    v.Name,
+		Comment: v.Comment,
+		Columns: convertAll(v.Columns, func(c common.ViewColumnSchema) ViewColumnSchema {
+			return ViewColumnSchema{Name: c.Name, Type: c.Type, Comment: c.Comment}
+		}),
+		Definition: v.Definition,
+		Indexes:    convertAll(v.Indexes, newIndexSchema),
+		Triggers:   convertAll(v.Triggers, newTriggerSchema),
+	}
+	if v.ContinuousAggregate != nil {
+		view.ContinuousAggregate = &ContinuousAggregateInfo{
+			CompressionEnabled: v.ContinuousAggregate.CompressionEnabled,
+			MaterializedOnly:   v.ContinuousAggregate.MaterializedOnly,
+		}
+	}
+	return view
+}
+
+func newIndexSchema(i common.IndexSchema) IndexSchema {
+	return IndexSchema{
+		Name:        i.Name,
+		Columns:     i.Columns,
+		Definition:  i.Definition,
+		IsUnique:    i.IsUnique,
+		WhereClause: i.WhereClause,
+	}
+}
+
+func newTriggerSchema(t common.TriggerSchema) TriggerSchema {
+	return TriggerSchema{
+		Name:         t.Name,
+		Timing:       t.Timing,
+		Manipulation: t.Manipulation,
+		Statement:    t.Statement,
+	}
+}
+
+func newRoutine(r common.Routine) Routine {
+	return Routine{
+		Name:       r.Name,
+		Arguments:  r.Arguments,
+		Type:       string(r.Type),
+		Comment:    r.Comment,
+		Definition: r.Definition,
+	}
+}
+
+// convertAll converts every element of in with convert, keeping a nil slice
+// nil
+func convertAll[T, U any](in []T, convert func(T) U) []U {
+	if in == nil {
+		return nil
+	}
+	out := make([]U, len(in))
+	for i, v := range in {
+		out[i] = convert(v)
+	}
+	return out
+}
@@ -0,0 +1,394 @@
+package tiger
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/timescale/tiger-cli/internal/api"
+	"github.com/timescale/tiger-cli/internal/common"
+	"github.com/timescale/tiger-cli/internal/util"
+)
+
+// Service is a Tiger Cloud database service.
+type Service struct {
+	ServiceID string `json:"service_id"`
+	ProjectID string `json:"project_id"`
+	Name      string `json:"name"`
+	// Status is the service's current status, e.g. StatusReady.
+	Status string `json:"status"`
+	// Type is the service type: TIMESCALEDB, POSTGRES, or VECTOR.
+	Type       string    `json:"type"`
+	RegionCode string    `json:"region_code"`
+	Created    time.Time `json:"created"`
+	// Endpoint is the direct database endpoint. Nil until the service has
+	// been provisioned.
+	Endpoint *Endpoint `json:"endpoint,omitempty"`
+	// PoolerEndpoint is the connection pooler's endpoint. Nil if the service
+	// has no pooler.
+	PoolerEndpoint *Endpoint `json:"pooler_endpoint,omitempty"`
+	// CPUMillis and MemoryGBs are the allocated resources. Both are zero for
+	// shared-resource services.
+	CPUMillis int `json:"cpu_millis,omitempty"`
+	MemoryGBs int `json:"memory_gbs,omitempty"`
+	// HAReplicas is the number of high-availability replicas.
+	HAReplicas int `json:"ha_replicas"`
+	// ForkedFrom is the ID of the service this one was forked from, if any.
+	ForkedFrom      string           `json:"forked_from,omitempty"`
+	ReadReplicaSets []ReadReplicaSet `json:"read_replica_sets,omitempty"`
+	// InitialPassword is the tsdbadmin password. It is only set on the
+	// service returned by CreateService.
+	InitialPassword string `json:"initial_password,omitempty"`
+}
+
+// Endpoint is a network endpoint for connecting to a service.
+type Endpoint struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// ReadReplicaSet is a set of read replicas attached to a service. Its ID can
+// be used wherever a service ID is accepted to connect to the replicas.
+type ReadReplicaSet struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Status is one of creating, active, resizing, deleting, or error.
+	Status         string    `json:"status"`
+	Nodes          int       `json:"nodes"`
+	CPUMillis      int       `json:"cpu_millis"`
+	MemoryGBs      int       `json:"memory_gbs"`
+	Endpoint       *Endpoint `json:"endpoint,omitempty"`
+	PoolerEndpoint *Endpoint `json:"pooler_endpoint,omitempty"`
+}
+
+// Service statuses that can be waited for with WaitForStatus.
+const (
+	StatusReady  = "READY"
+	StatusPaused = "PAUSED"
+)
+
+// DefaultWaitTimeout is how long operations wait for a service to reach its
+// target state when WaitOptions.Timeout is zero.
+const DefaultWaitTimeout = 10 * time.Minute
+
+// WaitOptions configures waiting for a service operation to complete.
+type WaitOptions struct {
+	// Wait blocks until the operation completes (e.g. the service is READY
+	// after creation, or gone after deletion) instead of returning as soon as
+	// the request is accepted.
+	Wait bool
+	// Timeout bounds the wait. Defaults to DefaultWaitTimeout.
+	Timeout time.Duration
+	// Progress, if set, is called with a status message on every poll.
+	Progress func(message string)
+}
+
+// CreateServiceOptions configures a new service. The zero value creates a
+// service with an auto-generated name and the plan's default resources.
+type CreateServiceOptions struct {
+	// Name is the service name (auto-generated if empty).
+	Name string
+	// Addons to enable: "time-series" and/or "ai". Nil uses the plan's
+	// defaults; an empty, non-nil slice creates a PostgreSQL-only service.
+	Addons []string
+	// Region is the region code (e.g. "us-east-1"). Empty lets Tiger Cloud
+	// choose.
+	Region string
+	// CPUMemory is the CPU/memory combination, e.g. "0.5 CPU/2GB" or
+	// "shared/shared". Empty uses the plan's default.
+	CPUMemory string
+	// Replicas is the number of high-availability replicas.
+	Replicas int
+
+	WaitOptions
+}
+
+// ListServices returns all services in the project.
+func (c *Client) ListServices(ctx context.Context) ([]Service, error) {
+	resp, err := c.api.GetServicesWithResponse(ctx, c.projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	services := make([]Service, len(*resp.JSON200))
+	for i, service := range *resp.JSON200 {
+		services[i] = *newService(&service)
+	}
+	return services, nil
+}
+
+// GetService returns the service (or read replica set) with the given ID.
+func (c *Client) GetService(ctx context.Context, serviceID string) (*Service, error) {
+	service, err := common.GetService(ctx, c.api, c.projectID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return newService(service), nil
+}
+
+// CreateService creates a new service. The returned service's
+// InitialPassword holds the tsdbadmin password, which is also saved to the
+// configured password storage so ConnectionDetails can find it later.
+func (c *Client) CreateService(ctx context.Context, opts CreateServiceOptions) (*Service, error) {
+	if err := common.CheckReadOnly(c.cfg); err != nil {
+		return nil, err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = common.GenerateServiceName()
+	}
+
+	req := api.ServiceCreate{
+		Name:         name,
+		ReplicaCount: &opts.Replicas,
+	}
+	if opts.Addons != nil {
+		addons, err := common.ValidateAddons(opts.Addons)
+		if err != nil {
+			return nil, err
+		}
+		req.Addons = util.ConvertStringSlicePtr[api.ServiceCreateAddons](addons)
+	}
+	if opts.Region != "" {
+		req.RegionCode = &opts.Region
+	}
+	if opts.CPUMemory != "" {
+		cpuMillis, memoryGBs, err := common.ParseCPUMemory(opts.CPUMemory)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU/Memory specification: %w", err)
+		}
+		req.CPUMillis, req.MemoryGbs = &cpuMillis, &memoryGBs
+	}
+
+	resp, err := c.api.CreateServiceWithResponse(ctx, c.projectID, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create service: %w", err)
+	}
+	if resp.StatusCode() != http.StatusAccepted {
+		return nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	if resp.JSON202 == nil {
+		return nil, fmt.Errorf("empty response from API")
+	}
+	service := resp.JSON202
+
+	// Save the password before waiting, so it isn't lost if the wait fails
+	if service.InitialPassword != nil {
+		if _, err := common.SavePasswordWithResult(c.cfg, *service, *service.InitialPassword, "tsdbadmin"); err != nil {
+			return newService(service), fmt.Errorf("service created but failed to save password: %w", err)
+		}
+	}
+
+	if opts.Wait {
+		err = c.waitForStatus(ctx, service, StatusReady, opts.WaitOptions)
+	}
+	return newService(service), err
+}
+
+// DeleteService deletes a service. This is irreversible.
+func (c *Client) DeleteService(ctx context.Context, serviceID string, opts WaitOptions) error {
+	if err := common.CheckReadOnly(c.cfg); err != nil {
+		return err
+	}
+
+	resp, err := c.api.DeleteServiceWithResponse(ctx, c.projectID, serviceID)
+	if err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	if resp.StatusCode() != http.StatusAccepted {
+		return common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+
+	if !opts.Wait {
+		return nil
+	}
+	return common.WaitForService(ctx, common.WaitForServiceArgs{
+		Client:     c.api,
+		ProjectID:  c.projectID,
+		ServiceID:  serviceID,
+		Handler:    &common.DeletionWaitHandler{ServiceID: serviceID},
+		Timeout:    waitTimeout(opts),
+		TimeoutMsg: "service may still be deleting",
+		Progress:   opts.Progress,
+	})
+}
+
+// StartService starts a paused service.
+func (c *Client) StartService(ctx context.Context, serviceID string, opts WaitOptions) (*Service, error) {
+	if err := common.CheckReadOnly(c.cfg); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.api.StartServiceWithResponse(ctx, c.projectID, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start service: %w", err)
+	}
+	if resp.StatusCode() != http.StatusAccepted {
+		return nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	if resp.JSON202 == nil {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	service := resp.JSON202
+	if opts.Wait {
+		err = c.waitForStatus(ctx, service, StatusReady, opts)
+	}
+	return newService(service), err
+}
+
+// StopService pauses a running service.
+func (c *Client) StopService(ctx context.Context, serviceID string, opts WaitOptions) (*Service, error) {
+	if err := common.CheckReadOnly(c.cfg); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.api.StopServiceWithResponse(ctx, c.projectID, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop service: %w", err)
+	}
+	if resp.StatusCode() != http.StatusAccepted {
+		return nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	if resp.JSON202 == nil {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	service := resp.JSON202
+	if opts.Wait {
+		err = c.waitForStatus(ctx, service, StatusPaused, opts)
+	}
+	return newService(service), err
+}
+
+// ResizeService changes a service's CPU and memory allocation. cpuMemory is a
+// combination such as "2 CPU/8GB".
+func (c *Client) ResizeService(ctx context.Context, serviceID, cpuMemory string, opts WaitOptions) (*Service, error) {
+	if err := common.CheckReadOnly(c.cfg); err != nil {
+		return nil, err
+	}
+
+	cpuMillis, memoryGBs, err := common.ParseCPUMemory(cpuMemory)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CPU/Memory specification: %w", err)
+	}
+
+	resp, err := c.api.ResizeServiceWithResponse(ctx, c.projectID, serviceID, api.ResizeInput{
+		CPUMillis: cpuMillis,
+		MemoryGbs: memoryGBs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resize service: %w", err)
+	}
+	if resp.StatusCode() != http.StatusAccepted {
+		return nil, common.ExitWithErrorFromStatusCode(resp.StatusCode(), resp.JSON4XX)
+	}
+	if resp.JSON202 == nil {
+		return nil, fmt.Errorf("empty response from API")
+	}
+
+	service := resp.JSON202
+	if opts.Wait {
+		err = c.waitForStatus(ctx, service, StatusReady, opts)
+	}
+	return newService(service), err
+}
+
+// WaitForStatus polls a service until it reaches the given status (e.g.
+// StatusReady), fails, or the timeout elapses. opts.Wait is ignored.
+func (c *Client) WaitForStatus(ctx context.Context, serviceID, status string, opts WaitOptions) (*Service, error) {
+	service, err := common.GetService(ctx, c.api, c.projectID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	err = c.waitForStatus(ctx, service, status, opts)
+	return newService(service), err
+}
+
+// waitForStatus waits for service to reach status, updating its Status
+// field as it goes
+func (c *Client) waitForStatus(ctx context.Context, service *api.Service, status string, opts WaitOptions) error {
+	return common.WaitForService(ctx, common.WaitForServiceArgs{
+		Client:    c.api,
+		ProjectID: c.projectID,
+		ServiceID: service.ServiceID,
+		Handler: &common.StatusWaitHandler{
+			TargetStatus: status,
+			Service:      service,
+		},
+		Timeout:    waitTimeout(opts),
+		TimeoutMsg: fmt.Sprintf("service may still be transitioning to %s", status),
+		Progress:   opts.Progress,
+	})
+}
+
+// waitTimeout returns the configured wait timeout, or the default
+func waitTimeout(opts WaitOptions) time.Duration {
+	if opts.Timeout > 0 {
+		return opts.Timeout
+	}
+	return DefaultWaitTimeout
+}
+
+// newService converts an API service to a Service
+func newService(service *api.Service) *Service {
+	s := &Service{
+		ServiceID:       service.ServiceID,
+		ProjectID:       service.ProjectID,
+		Name:            service.Name,
+		Status:          string(service.Status),
+		Type:            string(service.ServiceType),
+		RegionCode:      service.RegionCode,
+		Created:         service.Created,
+		Endpoint:        newEndpoint(service.Endpoint),
+		InitialPassword: util.Deref(service.InitialPassword),
+	}
+	if service.ConnectionPooler != nil {
+		s.PoolerEndpoint = newEndpoint(service.ConnectionPooler.Endpoint)
+	}
+	if len(service.Resources) > 0 && service.Resources[0].Spec != nil {
+		s.CPUMillis = util.Deref(service.Resources[0].Spec.CPUMillis)
+		s.MemoryGBs = util.Deref(service.Resources[0].Spec.MemoryGbs)
+	}
+	if service.HaReplicas != nil {
+		s.HAReplicas = util.Deref(service.HaReplicas.ReplicaCount)
+	}
+	if service.ForkedFrom != nil {
+		s.ForkedFrom = util.Deref(service.ForkedFrom.ServiceID)
+	}
+	if service.ReadReplicaSets != nil {
+		for _, set := range *service.ReadReplicaSets {
+			replicaSet := ReadReplicaSet{
+				ID:        set.ID,
+				Name:      set.Name,
+				Status:    string(set.Status),
+				Nodes:     set.Nodes,
+				CPUMillis: set.CPUMillis,
+				MemoryGBs: set.MemoryGbs,
+				Endpoint:  newEndpoint(set.Endpoint),
+			}
+			if set.ConnectionPooler != nil {
+				replicaSet.PoolerEndpoint = newEndpoint(set.ConnectionPooler.Endpoint)
+			}
+			s.ReadReplicaSets = append(s.ReadReplicaSets, replicaSet)
+		}
+	}
+	return s
+}
+
+// newEndpoint converts an API endpoint to an Endpoint, or nil if it has no
+// host
+func newEndpoint(endpoint *api.Endpoint) *Endpoint {
+	if endpoint == nil || endpoint.Host == nil {
+		return nil
+	}
+	return &Endpoint{Host: *endpoint.Host, Port: util.Deref(endpoint.Port)}
+}
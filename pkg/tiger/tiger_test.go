@@ -0,0 +1,198 @@
+package tiger_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/timescale/tiger-cli/pkg/tiger"
+)
+
+// fakeAPI is an in-memory Tiger Cloud API serving a single project
+type fakeAPI struct {
+	mu       sync.Mutex
+	services map[string]map[string]any
+}
+
+func newFakeAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	f := &fakeAPI{services: map[string]map[string]any{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /auth/info", func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "pub" || pass != "sec" {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"message": "invalid credentials"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"type": "apiKey",
+			"api_key": map[string]any{
+				"name":         "sdk",
+				"public_key":   "pub",
+				"project":      map[string]any{"id": "proj-1", "name": "Project", "plan_type": "FREE"},
+				"issuing_user": map[string]any{"id": "user-1", "name": "User", "email": "user@example.com"},
+			},
+		})
+	})
+	mux.HandleFunc("GET /projects/proj-1/services", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		services := []map[string]any{}
+		for _, service := range f.services {
+			services = append(services, service)
+		}
+		writeJSON(w, http.StatusOK, services)
+	})
+	mux.HandleFunc("POST /projects/proj-1/services", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		service := map[string]any{
+			"service_id":   "svc-1",
+			"project_id":   "proj-1",
+			"name":         req["name"],
+			"status":       "QUEUED",
+			"service_type": "TIMESCALEDB",
+			"region_code":  "us-east-1",
+			"endpoint":     map[string]any{"host": "svc-1.example.com", "port": 5432},
+			"connection_pooler": map[string]any{
+				"endpoint": map[string]any{"host": "svc-1.pooler.example.com", "port": 6432},
+			},
+			"resources":        []map[string]any{{"spec": map[string]any{"cpu_millis": 500, "memory_gbs": 2}}},
+			"ha_replicas":      map[string]any{"replica_count": 1},
+			"initial_password": "s3cret",
+		}
+		f.services["svc-1"] = service
+		writeJSON(w, http.StatusAccepted, service)
+	})
+	mux.HandleFunc("GET /projects/proj-1/services/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		service, ok := f.services[r.PathValue("id")]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]any{"message": "not found"})
+			return
+		}
+		// Services become ready on the first poll
+		service["status"] = "READY"
+		delete(service, "initial_password")
+		writeJSON(w, http.StatusOK, service)
+	})
+	mux.HandleFunc("DELETE /projects/proj-1/services/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.services, r.PathValue("id"))
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// newTestClient returns a client for the fake API. readOnly sets the
+// read_only config option.
+func newTestClient(t *testing.T, readOnly bool) *tiger.Client {
+	t.Helper()
+
+	server := newFakeAPI(t)
+	t.Setenv("TIGER_API_URL", server.URL)
+	t.Setenv("TIGER_ANALYTICS", "false")
+	t.Setenv("TIGER_PASSWORD_STORAGE", "none")
+	t.Setenv("TIGER_READ_ONLY", fmt.Sprint(readOnly))
+
+	client, err := tiger.NewClient(t.Context(), tiger.Options{
+		ConfigDir: t.TempDir(),
+		PublicKey: "pub",
+		SecretKey: "sec",
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	client := newTestClient(t, false)
+	assert.Equal(t, "proj-1", client.ProjectID())
+
+	_, err := tiger.NewClient(t.Context(), tiger.Options{ConfigDir: t.TempDir(), PublicKey: "pub"})
+	assert.Error(t, err, "a public key without a secret key should be rejected")
+}
+
+func TestServiceLifecycle(t *testing.T) {
+	client := newTestClient(t, false)
+	ctx := t.Context()
+
+	var progress []string
+	service, err := client.CreateService(ctx, tiger.CreateServiceOptions{
+		Name:   "sdk-db",
+		Addons: []string{"time-series"},
+		WaitOptions: tiger.WaitOptions{
+			Wait:     true,
+			Progress: func(message string) { progress = append(progress, message) },
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "svc-1", service.ServiceID)
+	assert.Equal(t, tiger.StatusReady, service.Status)
+	assert.Equal(t, "s3cret", service.InitialPassword)
+	assert.NotEmpty(t, progress)
+
+	services, err := client.ListServices(ctx)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, tiger.Service{
+		ServiceID:      "svc-1",
+		ProjectID:      "proj-1",
+		Name:           "sdk-db",
+		Status:         tiger.StatusReady,
+		Type:           "TIMESCALEDB",
+		RegionCode:     "us-east-1",
+		Endpoint:       &tiger.Endpoint{Host: "svc-1.example.com", Port: 5432},
+		PoolerEndpoint: &tiger.Endpoint{Host: "svc-1.pooler.example.com", Port: 6432},
+		CPUMillis:      500,
+		MemoryGBs:      2,
+		HAReplicas:     1,
+	}, services[0])
+
+	details, err := client.ConnectionDetails(ctx, "svc-1", tiger.ConnectionOptions{ReadOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, &tiger.ConnectionDetails{
+		Role:     tiger.DefaultRole,
+		Host:     "svc-1.example.com",
+		Port:     5432,
+		Database: "tsdb",
+		ReadOnly: true,
+	}, details)
+	assert.Equal(t, "postgresql://tsdbadmin@svc-1.example.com:5432/tsdb?sslmode=require&options=-c%20tsdb_admin.read_only_connection%3Dtrue", details.String())
+
+	pooled, err := client.ConnectionDetails(ctx, "svc-1", tiger.ConnectionOptions{Pooled: true})
+	require.NoError(t, err)
+	assert.True(t, pooled.IsPooler)
+	assert.Equal(t, "postgresql://tsdbadmin@svc-1.pooler.example.com:6432/tsdb?sslmode=require", pooled.String())
+
+	require.NoError(t, client.DeleteService(ctx, "svc-1", tiger.WaitOptions{Wait: true}))
+	_, err = client.GetService(ctx, "svc-1")
+	assert.Error(t, err)
+}
+
+func TestReadOnlyConfigBlocksWrites(t *testing.T) {
+	client := newTestClient(t, true)
+
+	_, err := client.CreateService(t.Context(), tiger.CreateServiceOptions{})
+	assert.Error(t, err)
+	assert.Error(t, client.DeleteService(t.Context(), "svc-1", tiger.WaitOptions{}))
+}